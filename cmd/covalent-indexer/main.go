@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ElrondNetwork/covalent-indexer-go/factory"
+	"github.com/ElrondNetwork/covalent-indexer-go/outport"
+	"github.com/ElrondNetwork/elrond-go-core/core/pubkeyConverter"
+	"github.com/ElrondNetwork/elrond-go-core/hashing/blake2b"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+)
+
+const addressLength = 32
+
+var log = logger.GetOrCreate("covalent/cmd")
+
+var (
+	logLevel             = flag.String("log-level", "*:INFO", "Logger level(s), e.g. *:INFO,covalent:DEBUG")
+	outportURL           = flag.String("outport-url", "localhost:22111", "Address on which the node forwarder connects")
+	outportRoute         = flag.String("outport-route", "/outport", "Websocket route on which the node forwards driver calls")
+	covalentURL          = flag.String("covalent-url", "localhost:21111", "Address on which covalent connects")
+	routeSendData        = flag.String("route-send-data", "/block", "Websocket route on which block data is sent to covalent")
+	routeAcknowledgeData = flag.String("route-ack-data", "/acknowledge", "Websocket route on which covalent acknowledges data")
+	numOfShards          = flag.Uint("num-shards", 3, "Number of shards in the network, excluding the metachain")
+	shardID              = flag.Uint("shard-id", 0, "Shard id of the node which forwards data")
+	economicsConfig      = flag.String("economics-config", "./config/economics.toml", "Path to the node's economics toml file, used to compute transactions fees")
+	noTokenBalances      = flag.Bool("no-token-balances", false, "Acknowledges that the accounts token balance updates are not provided, since the node does not forward the accounts data tries")
+)
+
+// errTokenBalancesNotAcknowledged signals that the indexer was started without acknowledging that it can not provide
+// the accounts token balance updates
+var errTokenBalancesNotAcknowledged = errors.New("the node does not forward the accounts data tries, so the " +
+	"standalone indexer can not provide the accounts token balance updates: restart it with --no-token-balances " +
+	"to run without them, or use the in-node indexer")
+
+func main() {
+	flag.Parse()
+
+	err := logger.SetLogLevel(*logLevel)
+	if err != nil {
+		log.Error("could not set log level", "error", err)
+		os.Exit(1)
+	}
+
+	err = startIndexer()
+	if err != nil {
+		log.Error("covalent indexer stopped with error", "error", err)
+		os.Exit(1)
+	}
+}
+
+func startIndexer() error {
+	if !*noTokenBalances {
+		return errTokenBalancesNotAcknowledged
+	}
+	log.Warn("accounts token balance updates are not provided by the standalone indexer")
+
+	pubKeyConverter, err := pubkeyConverter.NewBech32PubkeyConverter(addressLength, log)
+	if err != nil {
+		return err
+	}
+
+	coordinator, err := newShardCoordinator(uint32(*numOfShards), uint32(*shardID))
+	if err != nil {
+		return err
+	}
+
+	marshaller := &marshal.GogoProtoMarshalizer{}
+	ci, err := factory.CreateCovalentIndexer(&factory.ArgsCovalentIndexerFactory{
+		Enabled:              true,
+		URL:                  *covalentURL,
+		RouteSendData:        *routeSendData,
+		RouteAcknowledgeData: *routeAcknowledgeData,
+		PubKeyConverter:      pubKeyConverter,
+		Hasher:               blake2b.NewBlake2b(),
+		Marshaller:           marshaller,
+		ShardCoordinator:     coordinator,
+		EconomicsConfigPath:  *economicsConfig,
+	})
+	if err != nil {
+		return err
+	}
+
+	receiver, err := outport.NewDriverReceiver(ci, marshaller)
+	if err != nil {
+		return err
+	}
+
+	router := mux.NewRouter()
+	router.HandleFunc(*outportRoute, func(w http.ResponseWriter, r *http.Request) {
+		log.Info("node forwarder connected", "route", *outportRoute)
+		upgrader := websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+		}
+
+		ws, errUpgrade := upgrader.Upgrade(w, r, nil)
+		if errUpgrade != nil {
+			log.Warn("could not upgrade http connection to websocket", "error", errUpgrade)
+			return
+		}
+
+		receiver.Listen(ws)
+		log.LogIfError(ws.Close())
+	})
+
+	server := &http.Server{
+		Addr:    *outportURL,
+		Handler: router,
+	}
+	go func() {
+		errServe := server.ListenAndServe()
+		if errServe != nil && errServe != http.ErrServerClosed {
+			log.Error("could not start outport webserver", "error", errServe)
+		}
+	}()
+
+	log.Info("covalent indexer started", "outport", *outportURL+*outportRoute, "covalent", *covalentURL)
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	<-sigs
+
+	log.Info("closing covalent indexer")
+	log.LogIfError(server.Close())
+	return ci.Close()
+}
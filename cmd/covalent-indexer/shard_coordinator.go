@@ -0,0 +1,78 @@
+package main
+
+import (
+	"math"
+
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+)
+
+// shardCoordinator computes the shard of an address the same way the node's multi shard coordinator does
+type shardCoordinator struct {
+	selfID         uint32
+	numberOfShards uint32
+	maskHigh       uint32
+	maskLow        uint32
+}
+
+func newShardCoordinator(numberOfShards uint32, selfID uint32) (*shardCoordinator, error) {
+	if numberOfShards < 1 {
+		return nil, covalent.ErrInvalidNumberOfShards
+	}
+	if selfID >= numberOfShards && selfID != core.MetachainShardId {
+		return nil, covalent.ErrInvalidShardID
+	}
+
+	n := math.Ceil(math.Log2(float64(numberOfShards)))
+	return &shardCoordinator{
+		selfID:         selfID,
+		numberOfShards: numberOfShards,
+		maskHigh:       (1 << uint(n)) - 1,
+		maskLow:        (1 << uint(n-1)) - 1,
+	}, nil
+}
+
+// ComputeId returns the shard id of the given address
+func (sc *shardCoordinator) ComputeId(address []byte) uint32 {
+	bytesNeeded := 1
+	switch {
+	case sc.numberOfShards > 16777216:
+		bytesNeeded = 4
+	case sc.numberOfShards > 65536:
+		bytesNeeded = 3
+	case sc.numberOfShards > 256:
+		bytesNeeded = 2
+	}
+
+	startingIndex := 0
+	if len(address) > bytesNeeded {
+		startingIndex = len(address) - bytesNeeded
+	}
+
+	buffNeeded := address[startingIndex:]
+	if core.IsSmartContractOnMetachain(buffNeeded, address) {
+		return core.MetachainShardId
+	}
+
+	addr := uint32(0)
+	for i := 0; i < len(buffNeeded); i++ {
+		addr = addr<<8 + uint32(buffNeeded[i])
+	}
+
+	shard := addr & sc.maskHigh
+	if shard > sc.numberOfShards-1 {
+		shard = addr & sc.maskLow
+	}
+
+	return shard
+}
+
+// SelfId returns the shard id of the indexed node
+func (sc *shardCoordinator) SelfId() uint32 {
+	return sc.selfID
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (sc *shardCoordinator) IsInterfaceNil() bool {
+	return sc == nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"crypto/rand"
+	"flag"
+	"math/big"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ElrondNetwork/covalent-indexer-go/outport"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/block"
+	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+)
+
+const (
+	hashLength    = 32
+	addressLength = 32
+)
+
+var log = logger.GetOrCreate("covalent/stub-forwarder")
+
+var (
+	logLevel   = flag.String("log-level", "*:INFO", "Logger level(s), e.g. *:INFO,covalent:DEBUG")
+	outportURL = flag.String("outport-url", "ws://localhost:22111/outport", "Websocket url of the standalone covalent indexer")
+	interval   = flag.Duration("interval", 6*time.Second, "Time between two generated blocks")
+)
+
+// stub-forwarder acts as a node which forwards generated dummy blocks to a standalone covalent indexer,
+// so that the whole pipeline can be tested locally, without running a node
+func main() {
+	flag.Parse()
+
+	err := logger.SetLogLevel(*logLevel)
+	if err != nil {
+		log.Error("could not set log level", "error", err)
+		os.Exit(1)
+	}
+
+	forwarder, err := outport.NewDriverForwarder(&outport.ArgsDriverForwarder{
+		URL:        *outportURL,
+		Marshaller: &marshal.GogoProtoMarshalizer{},
+	})
+	if err != nil {
+		log.Error("could not create driver forwarder", "error", err)
+		os.Exit(1)
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	prevHash := randomBytes(hashLength)
+	for nonce := uint64(1); ; nonce++ {
+		select {
+		case <-sigs:
+			log.LogIfError(forwarder.Close())
+			return
+		case <-ticker.C:
+		}
+
+		args, accounts := generateBlock(nonce, prevHash)
+		err = forwarder.SaveAccounts(args.Header.GetTimeStamp(), accounts)
+		log.LogIfError(err)
+
+		err = forwarder.SaveBlock(args)
+		if err != nil {
+			log.Error("could not forward block", "nonce", nonce, "error", err)
+			continue
+		}
+
+		log.Info("forwarded block", "nonce", nonce)
+		prevHash = args.HeaderHash
+	}
+}
+
+func generateBlock(nonce uint64, prevHash []byte) (*indexer.ArgsSaveBlockData, []data.UserAccountHandler) {
+	tx := &transaction.Transaction{
+		Nonce:     nonce,
+		Value:     big.NewInt(int64(nonce)),
+		RcvAddr:   randomBytes(addressLength),
+		SndAddr:   randomBytes(addressLength),
+		GasPrice:  1000000000,
+		GasLimit:  50000,
+		Signature: randomBytes(64),
+	}
+	txHash := randomBytes(hashLength)
+
+	header := &block.Header{
+		Nonce:           nonce,
+		Round:           nonce,
+		TimeStamp:       uint64(time.Now().Unix()),
+		PrevHash:        prevHash,
+		RootHash:        randomBytes(hashLength),
+		TxCount:         1,
+		AccumulatedFees: big.NewInt(0),
+		DeveloperFees:   big.NewInt(0),
+	}
+	body := &block.Body{MiniBlocks: []*block.MiniBlock{
+		{
+			TxHashes: [][]byte{txHash},
+			Type:     block.TxBlock,
+		},
+	}}
+
+	accounts := []data.UserAccountHandler{
+		&stubAccount{address: tx.SndAddr, balance: big.NewInt(0), nonce: nonce},
+		&stubAccount{address: tx.RcvAddr, balance: big.NewInt(int64(nonce)), nonce: 0},
+	}
+
+	return &indexer.ArgsSaveBlockData{
+		HeaderHash:     randomBytes(hashLength),
+		Body:           body,
+		Header:         header,
+		SignersIndexes: []uint64{0, 1, 2},
+		TransactionsPool: &indexer.Pool{
+			Txs: map[string]data.TransactionHandler{string(txHash): tx},
+		},
+	}, accounts
+}
+
+func randomBytes(n int) []byte {
+	buff := make([]byte, n)
+	_, _ = rand.Read(buff)
+	return buff
+}
+
+type stubAccount struct {
+	address []byte
+	balance *big.Int
+	nonce   uint64
+}
+
+// RetrieveValueFromDataTrieTracker returns nil, nil
+func (sa *stubAccount) RetrieveValueFromDataTrieTracker(_ []byte) ([]byte, error) {
+	return nil, nil
+}
+
+// GetBalance returns the account balance
+func (sa *stubAccount) GetBalance() *big.Int {
+	return sa.balance
+}
+
+// GetNonce returns the account nonce
+func (sa *stubAccount) GetNonce() uint64 {
+	return sa.nonce
+}
+
+// AddressBytes returns the account address
+func (sa *stubAccount) AddressBytes() []byte {
+	return sa.address
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (sa *stubAccount) IsInterfaceNil() bool {
+	return sa == nil
+}
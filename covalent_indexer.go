@@ -9,6 +9,7 @@ import (
 
 	"github.com/ElrondNetwork/covalent-indexer-go/process"
 	"github.com/ElrondNetwork/covalent-indexer-go/process/utility"
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
 	"github.com/ElrondNetwork/elrond-go-core/data"
 	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
 	logger "github.com/ElrondNetwork/elrond-go-logger"
@@ -195,8 +196,14 @@ func (ci *covalentIndexer) SaveBlock(args *indexer.ArgsSaveBlockData) error {
 	return nil
 }
 
-// RevertIndexedBlock returns nil
-func (ci *covalentIndexer) RevertIndexedBlock(data.HeaderHandler, data.BodyHandler) error {
+// RevertIndexedBlock restores the cached accounts states as they were before indexing the reverted block,
+// so that the balance and nonce changes of the following blocks are computed against the correct states
+func (ci *covalentIndexer) RevertIndexedBlock(header data.HeaderHandler, _ data.BodyHandler) error {
+	if check.IfNil(header) {
+		return ErrNilHeaderHandler
+	}
+
+	ci.processor.RevertBlock(header)
 	return nil
 }
 
@@ -210,13 +217,20 @@ func (ci *covalentIndexer) SaveValidatorsPubKeys(map[uint32][][]byte, uint32) er
 	return nil
 }
 
-// SaveValidatorsRating returns nil
-func (ci *covalentIndexer) SaveValidatorsRating(string, []*indexer.ValidatorRatingInfo) error {
-	return nil
+// SaveValidatorsRating converts validators rating info, which will be sent to covalent along with the next block
+func (ci *covalentIndexer) SaveValidatorsRating(indexID string, infoRating []*indexer.ValidatorRatingInfo) error {
+	err := ci.processor.ProcessValidatorsRating(indexID, infoRating)
+	if err != nil {
+		log.Error("SaveValidatorsRating failed. Could not process validators rating",
+			"error", err, "indexID", indexID)
+	}
+
+	return err
 }
 
-// SaveAccounts returns nil
-func (ci *covalentIndexer) SaveAccounts(uint64, []data.UserAccountHandler) error {
+// SaveAccounts keeps the accounts state, which will be sent to covalent along with the block having the same timestamp
+func (ci *covalentIndexer) SaveAccounts(blockTimestamp uint64, acc []data.UserAccountHandler) error {
+	ci.processor.ProcessAccountsSnapshot(blockTimestamp, acc)
 	return nil
 }
 
@@ -12,6 +12,8 @@ import (
 	"github.com/ElrondNetwork/covalent-indexer-go/testscommon/mock"
 	"github.com/ElrondNetwork/elrond-go-core/core/atomic"
 	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/block"
 	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
@@ -436,10 +438,60 @@ func TestCovalentDataIndexer_UnimplementedFunctions(t *testing.T) {
 		_ = ci.Close()
 	}()
 
-	assert.Nil(t, ci.RevertIndexedBlock(nil, nil))
 	assert.Nil(t, ci.SaveRoundsInfo(nil))
 	assert.Nil(t, ci.SaveValidatorsPubKeys(nil, 0))
-	assert.Nil(t, ci.SaveValidatorsRating("", nil))
 	assert.Nil(t, ci.SaveAccounts(0, nil))
 	assert.Nil(t, ci.FinalizedBlock(nil))
 }
+
+func TestCovalentDataIndexer_RevertIndexedBlock(t *testing.T) {
+	var revertedHeader data.HeaderHandler
+
+	ci, _ := covalent.NewCovalentDataIndexer(
+		&mock.DataHandlerStub{
+			RevertBlockCalled: func(header data.HeaderHandler) {
+				revertedHeader = header
+			},
+		},
+		&http.Server{
+			Addr: "localhost:21119",
+		})
+	defer func() {
+		_ = ci.Close()
+	}()
+
+	require.Equal(t, covalent.ErrNilHeaderHandler, ci.RevertIndexedBlock(nil, nil))
+	require.Nil(t, revertedHeader)
+
+	header := &block.Header{TimeStamp: 100}
+	require.Nil(t, ci.RevertIndexedBlock(header, &block.Body{}))
+	require.Equal(t, header, revertedHeader)
+}
+
+func TestCovalentDataIndexer_SaveValidatorsRating(t *testing.T) {
+	errProcessRatings := errors.New("error processing ratings")
+	calledIndexID := ""
+
+	ci, _ := covalent.NewCovalentDataIndexer(
+		&mock.DataHandlerStub{
+			ProcessValidatorsRatingCalled: func(indexID string, ratings []*indexer.ValidatorRatingInfo) error {
+				calledIndexID = indexID
+				if len(ratings) == 0 {
+					return errProcessRatings
+				}
+				return nil
+			},
+		},
+		&http.Server{
+			Addr: "localhost:21119",
+		})
+	defer func() {
+		_ = ci.Close()
+	}()
+
+	require.Equal(t, errProcessRatings, ci.SaveValidatorsRating("0_1", nil))
+	require.Equal(t, "0_1", calledIndexID)
+
+	require.Nil(t, ci.SaveValidatorsRating("0_2", []*indexer.ValidatorRatingInfo{{PublicKey: "aa"}}))
+	require.Equal(t, "0_2", calledIndexID)
+}
@@ -5,9 +5,6 @@ import "errors"
 // ErrNilPubKeyConverter signals that a pub key converter input parameter is nil
 var ErrNilPubKeyConverter = errors.New("received nil input value: pub key converter")
 
-// ErrNilAccountsAdapter signals that an accounts adapter input parameter is nil
-var ErrNilAccountsAdapter = errors.New("received nil input value: accounts adapter")
-
 // ErrBlockBodyAssertion signals that an error occurred when trying to assert BodyHandler interface of type block body
 var ErrBlockBodyAssertion = errors.New("error asserting BodyHandler interface of type block body")
 
@@ -31,3 +28,55 @@ var ErrNilDataHandler = errors.New("received nil input value: data handler")
 
 // ErrNilHTTPServer signals that a nil http server has been provided
 var ErrNilHTTPServer = errors.New("received nil input value: http server")
+
+// ErrInvalidRatingsIndexID signals that a validators rating index ID could not be parsed as shardID_epoch
+var ErrInvalidRatingsIndexID = errors.New("invalid validators rating index id")
+
+// ErrAccountNotFoundInSnapshot signals that an account was not provided in the block accounts snapshot
+// and there is no accounts adapter to load it from
+var ErrAccountNotFoundInSnapshot = errors.New("account not found in accounts snapshot")
+
+// ErrNilDriver signals that a nil driver has been provided
+var ErrNilDriver = errors.New("received nil input value: driver")
+
+// ErrEmptyURL signals that an empty url has been provided
+var ErrEmptyURL = errors.New("received empty url")
+
+// ErrUnknownHeaderType signals that a header of an unknown type could not be serialized/deserialized
+var ErrUnknownHeaderType = errors.New("unknown header type")
+
+// ErrUnknownMessageType signals that a forwarded driver call of an unknown type has been received
+var ErrUnknownMessageType = errors.New("unknown message type")
+
+// ErrForwarderClosed signals that a driver call could not be forwarded, since the forwarder has been closed
+var ErrForwarderClosed = errors.New("driver forwarder is closed")
+
+// ErrDataTrieNotForwarded signals that an account data trie is not available, since it was not forwarded by the node
+var ErrDataTrieNotForwarded = errors.New("account data trie was not forwarded")
+
+// ErrInvalidAcknowledge signals that the acknowledge of a forwarded driver call does not match the sent message
+var ErrInvalidAcknowledge = errors.New("invalid acknowledge received")
+
+// ErrInvalidNumberOfShards signals that an invalid number of shards has been provided
+var ErrInvalidNumberOfShards = errors.New("invalid number of shards")
+
+// ErrInvalidShardID signals that an invalid shard id has been provided
+var ErrInvalidShardID = errors.New("invalid shard id")
+
+// ErrNilFeeCalculator signals that a nil fee calculator has been provided
+var ErrNilFeeCalculator = errors.New("received nil input value: fee calculator")
+
+// ErrNilEconomicsConfig signals that a nil economics config has been provided
+var ErrNilEconomicsConfig = errors.New("received nil input value: economics config")
+
+// ErrInvalidMinGasLimit signals that an invalid min gas limit has been provided in economics config
+var ErrInvalidMinGasLimit = errors.New("invalid min gas limit")
+
+// ErrInvalidGasPerDataByte signals that an invalid gas per data byte has been provided in economics config
+var ErrInvalidGasPerDataByte = errors.New("invalid gas per data byte")
+
+// ErrInvalidGasPriceModifier signals that an invalid gas price modifier has been provided in economics config
+var ErrInvalidGasPriceModifier = errors.New("invalid gas price modifier")
+
+// ErrNilHeaderHandler signals that a nil header handler has been provided
+var ErrNilHeaderHandler = errors.New("received nil input value: header handler")
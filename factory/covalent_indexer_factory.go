@@ -5,6 +5,7 @@ import (
 
 	"github.com/ElrondNetwork/covalent-indexer-go"
 	"github.com/ElrondNetwork/covalent-indexer-go/process"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/economics"
 	"github.com/ElrondNetwork/covalent-indexer-go/process/factory"
 	"github.com/ElrondNetwork/elrond-go-core/core"
 	"github.com/ElrondNetwork/elrond-go-core/core/check"
@@ -29,16 +30,17 @@ type ArgsCovalentIndexerFactory struct {
 	Hasher               hashing.Hasher
 	Marshaller           marshal.Marshalizer
 	ShardCoordinator     process.ShardCoordinator
+	FeeCalculator        process.FeeCalculator
+	EconomicsConfigPath  string
 }
 
-// CreateCovalentIndexer creates a new Driver instance of type covalent data indexer
+// CreateCovalentIndexer creates a new Driver instance of type covalent data indexer. If no fee calculator is
+// provided, it is created from the node's economics configuration file found at EconomicsConfigPath. If neither
+// is provided, the transactions gas and fees are left empty
 func CreateCovalentIndexer(args *ArgsCovalentIndexerFactory) (covalent.Driver, error) {
 	if check.IfNil(args.PubKeyConverter) {
 		return nil, covalent.ErrNilPubKeyConverter
 	}
-	if check.IfNil(args.Accounts) {
-		return nil, covalent.ErrNilAccountsAdapter
-	}
 	if check.IfNil(args.Hasher) {
 		return nil, covalent.ErrNilHasher
 	}
@@ -46,12 +48,18 @@ func CreateCovalentIndexer(args *ArgsCovalentIndexerFactory) (covalent.Driver, e
 		return nil, covalent.ErrNilMarshaller
 	}
 
+	feeCalculator, err := createFeeCalculator(args)
+	if err != nil {
+		return nil, err
+	}
+
 	argsDataProcessor := &factory.ArgsDataProcessor{
 		PubKeyConvertor:  args.PubKeyConverter,
 		Accounts:         args.Accounts,
 		Hasher:           args.Hasher,
 		Marshaller:       args.Marshaller,
 		ShardCoordinator: args.ShardCoordinator,
+		FeeCalculator:    feeCalculator,
 	}
 
 	dataProcessor, err := factory.CreateDataProcessor(argsDataProcessor)
@@ -118,3 +126,20 @@ func CreateCovalentIndexer(args *ArgsCovalentIndexerFactory) (covalent.Driver, e
 
 	return ci, nil
 }
+
+func createFeeCalculator(args *ArgsCovalentIndexerFactory) (process.FeeCalculator, error) {
+	if !check.IfNil(args.FeeCalculator) {
+		return args.FeeCalculator, nil
+	}
+	if len(args.EconomicsConfigPath) == 0 {
+		log.Warn("no fee calculator or economics config provided, transactions gas and fees are left empty")
+		return economics.NewDisabledFeeCalculator(), nil
+	}
+
+	economicsConfig, err := economics.LoadEconomicsConfig(args.EconomicsConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return economics.NewFeeCalculator(economicsConfig)
+}
@@ -9,6 +9,9 @@ import (
 
 type DataHandler interface {
 	ProcessData(args *indexer.ArgsSaveBlockData) (*schema.BlockResult, error)
+	ProcessValidatorsRating(indexID string, ratings []*indexer.ValidatorRatingInfo) error
+	ProcessAccountsSnapshot(blockTimestamp uint64, accounts []data.UserAccountHandler)
+	RevertBlock(header data.HeaderHandler)
 }
 
 type Driver interface {
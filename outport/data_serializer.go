@@ -0,0 +1,444 @@
+package outport
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/covalent-indexer-go/process"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/block"
+	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
+	"github.com/ElrondNetwork/elrond-go-core/data/receipt"
+	"github.com/ElrondNetwork/elrond-go-core/data/rewardTx"
+	"github.com/ElrondNetwork/elrond-go-core/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
+)
+
+const (
+	shardHeaderType   = "Header"
+	shardHeaderV2Type = "HeaderV2"
+	metaHeaderType    = "MetaBlock"
+)
+
+// dataSerializer converts Driver calls arguments to/from message payloads. Protocol data structures
+// (headers, bodies, transactions, logs) are marshalled with the node marshaller, everything else is json encoded
+type dataSerializer struct {
+	marshaller marshal.Marshalizer
+}
+
+func newDataSerializer(marshaller marshal.Marshalizer) (*dataSerializer, error) {
+	if check.IfNil(marshaller) {
+		return nil, covalent.ErrNilMarshaller
+	}
+
+	return &dataSerializer{marshaller: marshaller}, nil
+}
+
+func (ds *dataSerializer) serializeSaveBlock(args *indexer.ArgsSaveBlockData) ([]byte, error) {
+	header, err := ds.marshalHeader(args.Header)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ds.marshalBody(args.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := args.TransactionsPool
+	if pool == nil {
+		pool = &indexer.Pool{}
+	}
+
+	saveBlock := &saveBlockData{
+		HeaderHash:             args.HeaderHash,
+		Header:                 header,
+		Body:                   body,
+		SignersIndexes:         args.SignersIndexes,
+		NotarizedHeadersHashes: args.NotarizedHeadersHashes,
+		HeaderGasConsumption:   args.HeaderGasConsumption,
+	}
+
+	txPools := []struct {
+		in  map[string]data.TransactionHandler
+		out *map[string][]byte
+	}{
+		{in: pool.Txs, out: &saveBlock.Txs},
+		{in: pool.Scrs, out: &saveBlock.Scrs},
+		{in: pool.Rewards, out: &saveBlock.Rewards},
+		{in: pool.Invalid, out: &saveBlock.Invalid},
+		{in: pool.Receipts, out: &saveBlock.Receipts},
+	}
+	for _, txPool := range txPools {
+		*txPool.out, err = ds.marshalTxPool(txPool.in)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	saveBlock.Logs, err = ds.marshalLogs(pool.Logs)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(saveBlock)
+}
+
+func (ds *dataSerializer) deserializeSaveBlock(payload []byte) (*indexer.ArgsSaveBlockData, error) {
+	saveBlock := &saveBlockData{}
+	err := json.Unmarshal(payload, saveBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := ds.unmarshalHeader(saveBlock.Header)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ds.unmarshalBody(saveBlock.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := &indexer.Pool{}
+	txPools := []struct {
+		in    map[string][]byte
+		out   *map[string]data.TransactionHandler
+		newTx func() data.TransactionHandler
+	}{
+		{in: saveBlock.Txs, out: &pool.Txs, newTx: func() data.TransactionHandler { return &transaction.Transaction{} }},
+		{in: saveBlock.Scrs, out: &pool.Scrs, newTx: func() data.TransactionHandler { return &smartContractResult.SmartContractResult{} }},
+		{in: saveBlock.Rewards, out: &pool.Rewards, newTx: func() data.TransactionHandler { return &rewardTx.RewardTx{} }},
+		{in: saveBlock.Invalid, out: &pool.Invalid, newTx: func() data.TransactionHandler { return &transaction.Transaction{} }},
+		{in: saveBlock.Receipts, out: &pool.Receipts, newTx: func() data.TransactionHandler { return &receipt.Receipt{} }},
+	}
+	for _, txPool := range txPools {
+		*txPool.out, err = ds.unmarshalTxPool(txPool.in, txPool.newTx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pool.Logs, err = ds.unmarshalLogs(saveBlock.Logs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &indexer.ArgsSaveBlockData{
+		HeaderHash:             saveBlock.HeaderHash,
+		Body:                   body,
+		Header:                 header,
+		SignersIndexes:         saveBlock.SignersIndexes,
+		NotarizedHeadersHashes: saveBlock.NotarizedHeadersHashes,
+		HeaderGasConsumption:   saveBlock.HeaderGasConsumption,
+		TransactionsPool:       pool,
+	}, nil
+}
+
+func (ds *dataSerializer) serializeRevertIndexedBlock(header data.HeaderHandler, body data.BodyHandler) ([]byte, error) {
+	marshalledHeader, err := ds.marshalHeader(header)
+	if err != nil {
+		return nil, err
+	}
+	marshalledBody, err := ds.marshalBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&revertIndexedBlockData{
+		Header: marshalledHeader,
+		Body:   marshalledBody,
+	})
+}
+
+func (ds *dataSerializer) deserializeRevertIndexedBlock(payload []byte) (data.HeaderHandler, data.BodyHandler, error) {
+	revertBlock := &revertIndexedBlockData{}
+	err := json.Unmarshal(payload, revertBlock)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header, err := ds.unmarshalHeader(revertBlock.Header)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := ds.unmarshalBody(revertBlock.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return header, body, nil
+}
+
+func (ds *dataSerializer) serializeAccounts(blockTimestamp uint64, accounts []data.UserAccountHandler) ([]byte, error) {
+	forwardedAccounts := make([]*userAccount, 0, len(accounts))
+	for _, account := range accounts {
+		if check.IfNil(account) {
+			continue
+		}
+
+		forwardedAccounts = append(forwardedAccounts, newUserAccount(account))
+	}
+
+	return json.Marshal(&accountsData{
+		BlockTimestamp: blockTimestamp,
+		Accounts:       forwardedAccounts,
+	})
+}
+
+func newUserAccount(account data.UserAccountHandler) *userAccount {
+	forwardedAccount := &userAccount{
+		Address: account.AddressBytes(),
+		Balance: account.GetBalance(),
+		Nonce:   account.GetNonce(),
+	}
+
+	details, ok := account.(process.UserAccountDetailsHandler)
+	if !ok {
+		return forwardedAccount
+	}
+
+	forwardedAccount.UserName = details.GetUserName()
+	forwardedAccount.OwnerAddress = details.GetOwnerAddress()
+	forwardedAccount.CodeHash = details.GetCodeHash()
+	forwardedAccount.RootHash = details.GetRootHash()
+	forwardedAccount.CodeMetadata = details.GetCodeMetadata()
+	forwardedAccount.DeveloperReward = details.GetDeveloperReward()
+
+	return forwardedAccount
+}
+
+func (ds *dataSerializer) deserializeAccounts(payload []byte) (uint64, []data.UserAccountHandler, error) {
+	forwardedAccounts := &accountsData{}
+	err := json.Unmarshal(payload, forwardedAccounts)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	accounts := make([]data.UserAccountHandler, 0, len(forwardedAccounts.Accounts))
+	for _, account := range forwardedAccounts.Accounts {
+		accounts = append(accounts, account)
+	}
+
+	return forwardedAccounts.BlockTimestamp, accounts, nil
+}
+
+func (ds *dataSerializer) marshalHeader(header data.HeaderHandler) (*headerData, error) {
+	var headerType string
+	switch header.(type) {
+	case *block.Header:
+		headerType = shardHeaderType
+	case *block.MetaBlock:
+		headerType = metaHeaderType
+	default:
+		return ds.marshalHeaderV2(header)
+	}
+
+	headerBytes, err := ds.marshaller.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return &headerData{
+		Type:   headerType,
+		Header: headerBytes,
+	}, nil
+}
+
+// marshalHeaderV2 forwards a version 2 shard header as its version 1 shard header fields, together with
+// the scheduled miniblocks execution data, which are the header fields read by the block processor
+func (ds *dataSerializer) marshalHeaderV2(header data.HeaderHandler) (*headerData, error) {
+	if check.IfNil(header) || header.GetShardID() == core.MetachainShardId {
+		return nil, covalent.ErrUnknownHeaderType
+	}
+	scheduledDataHandler, ok := header.(process.ScheduledDataHandler)
+	if !ok {
+		return nil, covalent.ErrUnknownHeaderType
+	}
+
+	headerBytes, err := ds.marshaller.Marshal(getShardHeaderFields(header))
+	if err != nil {
+		return nil, err
+	}
+
+	return &headerData{
+		Type:   shardHeaderV2Type,
+		Header: headerBytes,
+		ScheduledData: &scheduledData{
+			RootHash:        scheduledDataHandler.GetScheduledRootHash(),
+			AccumulatedFees: scheduledDataHandler.GetScheduledAccumulatedFees(),
+			DeveloperFees:   scheduledDataHandler.GetScheduledDeveloperFees(),
+			GasProvided:     scheduledDataHandler.GetScheduledGasProvided(),
+			GasPenalized:    scheduledDataHandler.GetScheduledGasPenalized(),
+			GasRefunded:     scheduledDataHandler.GetScheduledGasRefunded(),
+		},
+	}, nil
+}
+
+func (ds *dataSerializer) unmarshalHeader(marshalledHeader *headerData) (data.HeaderHandler, error) {
+	if marshalledHeader == nil {
+		return nil, covalent.ErrUnknownHeaderType
+	}
+
+	var header data.HeaderHandler
+	switch marshalledHeader.Type {
+	case shardHeaderType:
+		header = &block.Header{}
+	case metaHeaderType:
+		header = &block.MetaBlock{}
+	case shardHeaderV2Type:
+		return ds.unmarshalHeaderV2(marshalledHeader)
+	default:
+		return nil, covalent.ErrUnknownHeaderType
+	}
+
+	err := ds.marshaller.Unmarshal(header, marshalledHeader.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+func (ds *dataSerializer) unmarshalHeaderV2(marshalledHeader *headerData) (data.HeaderHandler, error) {
+	if marshalledHeader.ScheduledData == nil {
+		return nil, covalent.ErrUnknownHeaderType
+	}
+
+	header := &block.Header{}
+	err := ds.marshaller.Unmarshal(header, marshalledHeader.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	return &headerV2{
+		Header:    header,
+		scheduled: marshalledHeader.ScheduledData,
+	}, nil
+}
+
+func getShardHeaderFields(header data.HeaderHandler) *block.Header {
+	return &block.Header{
+		Nonce:              header.GetNonce(),
+		PrevHash:           header.GetPrevHash(),
+		PrevRandSeed:       header.GetPrevRandSeed(),
+		RandSeed:           header.GetRandSeed(),
+		PubKeysBitmap:      header.GetPubKeysBitmap(),
+		ShardID:            header.GetShardID(),
+		TimeStamp:          header.GetTimeStamp(),
+		Round:              header.GetRound(),
+		Epoch:              header.GetEpoch(),
+		Signature:          header.GetSignature(),
+		LeaderSignature:    header.GetLeaderSignature(),
+		RootHash:           header.GetRootHash(),
+		TxCount:            header.GetTxCount(),
+		EpochStartMetaHash: header.GetEpochStartMetaHash(),
+		ReceiptsHash:       header.GetReceiptsHash(),
+		ChainID:            header.GetChainID(),
+		SoftwareVersion:    header.GetSoftwareVersion(),
+		AccumulatedFees:    header.GetAccumulatedFees(),
+		DeveloperFees:      header.GetDeveloperFees(),
+		Reserved:           header.GetReserved(),
+	}
+}
+
+func (ds *dataSerializer) marshalBody(body data.BodyHandler) ([]byte, error) {
+	if check.IfNil(body) {
+		return nil, nil
+	}
+
+	return ds.marshaller.Marshal(body)
+}
+
+func (ds *dataSerializer) unmarshalBody(marshalledBody []byte) (data.BodyHandler, error) {
+	body := &block.Body{}
+	if len(marshalledBody) == 0 {
+		return body, nil
+	}
+
+	err := ds.marshaller.Unmarshal(body, marshalledBody)
+	if err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+func (ds *dataSerializer) marshalTxPool(txPool map[string]data.TransactionHandler) (map[string][]byte, error) {
+	marshalledTxPool := make(map[string][]byte, len(txPool))
+	for txHash, tx := range txPool {
+		txBytes, err := ds.marshaller.Marshal(tx)
+		if err != nil {
+			return nil, err
+		}
+
+		marshalledTxPool[hex.EncodeToString([]byte(txHash))] = txBytes
+	}
+
+	return marshalledTxPool, nil
+}
+
+func (ds *dataSerializer) unmarshalTxPool(
+	marshalledTxPool map[string][]byte,
+	newTx func() data.TransactionHandler,
+) (map[string]data.TransactionHandler, error) {
+	txPool := make(map[string]data.TransactionHandler, len(marshalledTxPool))
+	for hexTxHash, txBytes := range marshalledTxPool {
+		txHash, err := hex.DecodeString(hexTxHash)
+		if err != nil {
+			return nil, err
+		}
+
+		tx := newTx()
+		err = ds.marshaller.Unmarshal(tx, txBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		txPool[string(txHash)] = tx
+	}
+
+	return txPool, nil
+}
+
+func (ds *dataSerializer) marshalLogs(logs []*data.LogData) ([]*logData, error) {
+	marshalledLogs := make([]*logData, 0, len(logs))
+	for _, currLog := range logs {
+		if currLog == nil || check.IfNil(currLog.LogHandler) {
+			continue
+		}
+
+		logBytes, err := ds.marshaller.Marshal(currLog.LogHandler)
+		if err != nil {
+			return nil, err
+		}
+
+		marshalledLogs = append(marshalledLogs, &logData{
+			TxHash: []byte(currLog.TxHash),
+			Log:    logBytes,
+		})
+	}
+
+	return marshalledLogs, nil
+}
+
+func (ds *dataSerializer) unmarshalLogs(marshalledLogs []*logData) ([]*data.LogData, error) {
+	logs := make([]*data.LogData, 0, len(marshalledLogs))
+	for _, currLog := range marshalledLogs {
+		txLog := &transaction.Log{}
+		err := ds.marshaller.Unmarshal(txLog, currLog.Log)
+		if err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, &data.LogData{
+			LogHandler: txLog,
+			TxHash:     string(currLog.TxHash),
+		})
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,249 @@
+package outport
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/covalent-indexer-go/process"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"github.com/gorilla/websocket"
+)
+
+var log = logger.GetOrCreate("covalent/outport")
+
+// ArgsDriverForwarder holds all input dependencies required by the driver forwarder
+type ArgsDriverForwarder struct {
+	URL        string
+	Marshaller marshal.Marshalizer
+}
+
+type driverForwarder struct {
+	url        string
+	serializer *dataSerializer
+
+	conn    process.WSConn
+	mutConn sync.Mutex
+
+	messageID    uint64
+	mutForward   sync.Mutex
+	closeChan    chan struct{}
+	closeOnce    sync.Once
+	dialWSConnFn func(url string) (process.WSConn, error)
+}
+
+// NewDriverForwarder creates a thin Driver, to be used by the node, which forwards every call over a websocket
+// connection to a standalone covalent indexer. Each call blocks until the indexer acknowledges it,
+// reconnecting and resending it as long as the indexer is not reachable
+func NewDriverForwarder(args *ArgsDriverForwarder) (*driverForwarder, error) {
+	if len(args.URL) == 0 {
+		return nil, covalent.ErrEmptyURL
+	}
+	serializer, err := newDataSerializer(args.Marshaller)
+	if err != nil {
+		return nil, err
+	}
+
+	return &driverForwarder{
+		url:          args.URL,
+		serializer:   serializer,
+		closeChan:    make(chan struct{}),
+		dialWSConnFn: dialWSConn,
+	}, nil
+}
+
+func dialWSConn(url string) (process.WSConn, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	return conn, err
+}
+
+// SaveBlock forwards the block data to the covalent indexer
+func (df *driverForwarder) SaveBlock(args *indexer.ArgsSaveBlockData) error {
+	payload, err := df.serializer.serializeSaveBlock(args)
+	if err != nil {
+		return err
+	}
+
+	return df.forward(SaveBlockMessage, payload)
+}
+
+// RevertIndexedBlock forwards the reverted block to the covalent indexer
+func (df *driverForwarder) RevertIndexedBlock(header data.HeaderHandler, body data.BodyHandler) error {
+	payload, err := df.serializer.serializeRevertIndexedBlock(header, body)
+	if err != nil {
+		return err
+	}
+
+	return df.forward(RevertIndexedBlockMessage, payload)
+}
+
+// SaveRoundsInfo forwards the rounds info to the covalent indexer
+func (df *driverForwarder) SaveRoundsInfo(roundsInfos []*indexer.RoundInfo) error {
+	return df.marshalAndForward(SaveRoundsInfoMessage, roundsInfos)
+}
+
+// SaveValidatorsPubKeys forwards the validators public keys to the covalent indexer
+func (df *driverForwarder) SaveValidatorsPubKeys(validatorsPubKeys map[uint32][][]byte, epoch uint32) error {
+	return df.marshalAndForward(SaveValidatorsPubKeysMessage, &validatorsPubKeysData{
+		ValidatorsPubKeys: validatorsPubKeys,
+		Epoch:             epoch,
+	})
+}
+
+// SaveValidatorsRating forwards the validators rating to the covalent indexer
+func (df *driverForwarder) SaveValidatorsRating(indexID string, infoRating []*indexer.ValidatorRatingInfo) error {
+	return df.marshalAndForward(SaveValidatorsRatingMessage, &validatorsRatingData{
+		IndexID:    indexID,
+		InfoRating: infoRating,
+	})
+}
+
+// SaveAccounts forwards the accounts state to the covalent indexer
+func (df *driverForwarder) SaveAccounts(blockTimestamp uint64, acc []data.UserAccountHandler) error {
+	payload, err := df.serializer.serializeAccounts(blockTimestamp, acc)
+	if err != nil {
+		return err
+	}
+
+	return df.forward(SaveAccountsMessage, payload)
+}
+
+// FinalizedBlock forwards the finalized block hash to the covalent indexer
+func (df *driverForwarder) FinalizedBlock(headerHash []byte) error {
+	return df.marshalAndForward(FinalizedBlockMessage, headerHash)
+}
+
+func (df *driverForwarder) marshalAndForward(messageType string, obj interface{}) error {
+	payload, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	return df.forward(messageType, payload)
+}
+
+func (df *driverForwarder) forward(messageType string, payload []byte) error {
+	df.mutForward.Lock()
+	defer df.mutForward.Unlock()
+
+	df.messageID++
+	message, err := json.Marshal(&Message{
+		ID:      df.messageID,
+		Type:    messageType,
+		Payload: payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(time.Millisecond * covalent.RetrialTimeoutMS)
+	defer ticker.Stop()
+
+	for {
+		if df.isClosed() {
+			return covalent.ErrForwarderClosed
+		}
+
+		ack, errSend := df.sendWithAcknowledge(message)
+		if errSend == nil {
+			if len(ack.Error) > 0 {
+				return errors.New(ack.Error)
+			}
+			return nil
+		}
+
+		log.Debug("could not forward driver call to covalent indexer, retrying",
+			"message type", messageType, "error", errSend)
+		df.closeConnection()
+
+		select {
+		case <-df.closeChan:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (df *driverForwarder) isClosed() bool {
+	select {
+	case <-df.closeChan:
+		return true
+	default:
+		return false
+	}
+}
+
+func (df *driverForwarder) sendWithAcknowledge(message []byte) (*Acknowledge, error) {
+	conn, err := df.getConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	err = conn.WriteMessage(websocket.BinaryMessage, message)
+	if err != nil {
+		return nil, err
+	}
+
+	_, ackBytes, err := conn.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	ack := &Acknowledge{}
+	err = json.Unmarshal(ackBytes, ack)
+	if err != nil {
+		return nil, err
+	}
+	if ack.ID != df.messageID {
+		return nil, covalent.ErrInvalidAcknowledge
+	}
+
+	return ack, nil
+}
+
+func (df *driverForwarder) getConnection() (process.WSConn, error) {
+	df.mutConn.Lock()
+	defer df.mutConn.Unlock()
+
+	if df.conn != nil {
+		return df.conn, nil
+	}
+
+	conn, err := df.dialWSConnFn(df.url)
+	if err != nil {
+		return nil, err
+	}
+
+	df.conn = conn
+	return conn, nil
+}
+
+func (df *driverForwarder) closeConnection() {
+	df.mutConn.Lock()
+	defer df.mutConn.Unlock()
+
+	if df.conn != nil {
+		err := df.conn.Close()
+		log.LogIfError(err)
+	}
+	df.conn = nil
+}
+
+// Close stops forwarding driver calls and closes the websocket connection, if it exists
+func (df *driverForwarder) Close() error {
+	df.closeOnce.Do(func() {
+		close(df.closeChan)
+	})
+	df.closeConnection()
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (df *driverForwarder) IsInterfaceNil() bool {
+	return df == nil
+}
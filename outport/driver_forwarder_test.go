@@ -0,0 +1,368 @@
+package outport_test
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/covalent-indexer-go/outport"
+	"github.com/ElrondNetwork/covalent-indexer-go/process"
+	"github.com/ElrondNetwork/covalent-indexer-go/testscommon"
+	"github.com/ElrondNetwork/covalent-indexer-go/testscommon/mock"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/block"
+	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
+	"github.com/ElrondNetwork/elrond-go-core/data/receipt"
+	"github.com/ElrondNetwork/elrond-go-core/data/rewardTx"
+	"github.com/ElrondNetwork/elrond-go-core/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDriverForwarder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args        *outport.ArgsDriverForwarder
+		expectedErr error
+	}{
+		{
+			args:        &outport.ArgsDriverForwarder{URL: "", Marshaller: &marshal.GogoProtoMarshalizer{}},
+			expectedErr: covalent.ErrEmptyURL,
+		},
+		{
+			args:        &outport.ArgsDriverForwarder{URL: "ws://localhost:22111/outport", Marshaller: nil},
+			expectedErr: covalent.ErrNilMarshaller,
+		},
+		{
+			args:        &outport.ArgsDriverForwarder{URL: "ws://localhost:22111/outport", Marshaller: &marshal.GogoProtoMarshalizer{}},
+			expectedErr: nil,
+		},
+	}
+
+	for _, currTest := range tests {
+		_, err := outport.NewDriverForwarder(currTest.args)
+		require.Equal(t, currTest.expectedErr, err)
+	}
+}
+
+func TestNewDriverReceiver(t *testing.T) {
+	t.Parallel()
+
+	_, err := outport.NewDriverReceiver(nil, &marshal.GogoProtoMarshalizer{})
+	require.Equal(t, covalent.ErrNilDriver, err)
+
+	_, err = outport.NewDriverReceiver(&mock.DriverStub{}, nil)
+	require.Equal(t, covalent.ErrNilMarshaller, err)
+
+	_, err = outport.NewDriverReceiver(&mock.DriverStub{}, &marshal.GogoProtoMarshalizer{})
+	require.Nil(t, err)
+}
+
+func TestDriverForwarder_SaveBlock_ExpectSameBlockDataReceived(t *testing.T) {
+	t.Parallel()
+
+	args := generateArgsSaveBlock()
+	var receivedArgs *indexer.ArgsSaveBlockData
+	forwarder := createForwarderWithReceiver(t, &mock.DriverStub{
+		SaveBlockCalled: func(args *indexer.ArgsSaveBlockData) error {
+			receivedArgs = args
+			return nil
+		},
+	})
+
+	err := forwarder.SaveBlock(args)
+	require.Nil(t, err)
+	require.Equal(t, args, receivedArgs)
+}
+
+func TestDriverForwarder_RevertIndexedBlock_MetaBlock_ExpectSameBlockReceived(t *testing.T) {
+	t.Parallel()
+
+	header := &block.MetaBlock{Nonce: 4, Round: 5, RootHash: []byte("root hash")}
+	body := &block.Body{MiniBlocks: []*block.MiniBlock{{TxHashes: [][]byte{[]byte("tx hash")}}}}
+
+	var receivedHeader data.HeaderHandler
+	var receivedBody data.BodyHandler
+	forwarder := createForwarderWithReceiver(t, &mock.DriverStub{
+		RevertIndexedBlockCalled: func(header data.HeaderHandler, body data.BodyHandler) error {
+			receivedHeader = header
+			receivedBody = body
+			return nil
+		},
+	})
+
+	err := forwarder.RevertIndexedBlock(header, body)
+	require.Nil(t, err)
+	require.Equal(t, header, receivedHeader)
+	require.Equal(t, body, receivedBody)
+}
+
+func TestDriverForwarder_SaveBlock_HeaderV2_ExpectScheduledDataReceived(t *testing.T) {
+	t.Parallel()
+
+	shardHeader := &block.Header{
+		Nonce:           1,
+		Round:           2,
+		ShardID:         1,
+		RootHash:        []byte("root hash"),
+		AccumulatedFees: big.NewInt(4),
+		DeveloperFees:   big.NewInt(5),
+	}
+	header := &mock.HeaderV2Mock{
+		Header:                   shardHeader,
+		ScheduledRootHash:        []byte("scheduled root hash"),
+		ScheduledAccumulatedFees: big.NewInt(6),
+		ScheduledDeveloperFees:   big.NewInt(7),
+		ScheduledGasProvided:     8,
+		ScheduledGasPenalized:    9,
+		ScheduledGasRefunded:     10,
+	}
+
+	var receivedHeader data.HeaderHandler
+	forwarder := createForwarderWithReceiver(t, &mock.DriverStub{
+		SaveBlockCalled: func(args *indexer.ArgsSaveBlockData) error {
+			receivedHeader = args.Header
+			return nil
+		},
+	})
+
+	err := forwarder.SaveBlock(&indexer.ArgsSaveBlockData{Header: header})
+	require.Nil(t, err)
+
+	receivedScheduledData, ok := receivedHeader.(process.ScheduledDataHandler)
+	require.True(t, ok)
+	require.Equal(t, header.ScheduledRootHash, receivedScheduledData.GetScheduledRootHash())
+	require.Equal(t, header.ScheduledAccumulatedFees, receivedScheduledData.GetScheduledAccumulatedFees())
+	require.Equal(t, header.ScheduledDeveloperFees, receivedScheduledData.GetScheduledDeveloperFees())
+	require.Equal(t, header.ScheduledGasProvided, receivedScheduledData.GetScheduledGasProvided())
+	require.Equal(t, header.ScheduledGasPenalized, receivedScheduledData.GetScheduledGasPenalized())
+	require.Equal(t, header.ScheduledGasRefunded, receivedScheduledData.GetScheduledGasRefunded())
+
+	require.Equal(t, shardHeader.GetNonce(), receivedHeader.GetNonce())
+	require.Equal(t, shardHeader.GetRound(), receivedHeader.GetRound())
+	require.Equal(t, shardHeader.GetShardID(), receivedHeader.GetShardID())
+	require.Equal(t, shardHeader.GetRootHash(), receivedHeader.GetRootHash())
+	require.Equal(t, shardHeader.GetAccumulatedFees(), receivedHeader.GetAccumulatedFees())
+	require.Equal(t, shardHeader.GetDeveloperFees(), receivedHeader.GetDeveloperFees())
+}
+
+func TestDriverForwarder_OtherDriverCalls_ExpectSameDataReceived(t *testing.T) {
+	t.Parallel()
+
+	roundsInfos := []*indexer.RoundInfo{{Index: 1, SignersIndexes: []uint64{1, 2}, ShardId: 1, Timestamp: time.Second}}
+	validatorsPubKeys := map[uint32][][]byte{0: {[]byte("pk1")}, 1: {[]byte("pk2")}}
+	infoRating := []*indexer.ValidatorRatingInfo{{PublicKey: "aabb", Rating: 50}}
+	account := &mock.UserAccountMock{Address: []byte("addr"), CurrentBalance: 4, CurrentNonce: 5}
+
+	called := make(map[string]struct{})
+	forwarder := createForwarderWithReceiver(t, &mock.DriverStub{
+		SaveRoundsInfoCalled: func(received []*indexer.RoundInfo) error {
+			require.Equal(t, roundsInfos, received)
+			called[outport.SaveRoundsInfoMessage] = struct{}{}
+			return nil
+		},
+		SaveValidatorsPubKeysCalled: func(received map[uint32][][]byte, epoch uint32) error {
+			require.Equal(t, validatorsPubKeys, received)
+			require.Equal(t, uint32(3), epoch)
+			called[outport.SaveValidatorsPubKeysMessage] = struct{}{}
+			return nil
+		},
+		SaveValidatorsRatingCalled: func(indexID string, received []*indexer.ValidatorRatingInfo) error {
+			require.Equal(t, "1_2", indexID)
+			require.Equal(t, infoRating, received)
+			called[outport.SaveValidatorsRatingMessage] = struct{}{}
+			return nil
+		},
+		SaveAccountsCalled: func(blockTimestamp uint64, acc []data.UserAccountHandler) error {
+			require.Equal(t, uint64(123), blockTimestamp)
+			require.Len(t, acc, 1)
+			require.Equal(t, []byte("addr"), acc[0].AddressBytes())
+			require.Equal(t, big.NewInt(5), acc[0].GetBalance())
+			require.Equal(t, uint64(6), acc[0].GetNonce())
+			called[outport.SaveAccountsMessage] = struct{}{}
+			return nil
+		},
+		FinalizedBlockCalled: func(headerHash []byte) error {
+			require.Equal(t, []byte("header hash"), headerHash)
+			called[outport.FinalizedBlockMessage] = struct{}{}
+			return nil
+		},
+	})
+
+	require.Nil(t, forwarder.SaveRoundsInfo(roundsInfos))
+	require.Nil(t, forwarder.SaveValidatorsPubKeys(validatorsPubKeys, 3))
+	require.Nil(t, forwarder.SaveValidatorsRating("1_2", infoRating))
+	require.Nil(t, forwarder.SaveAccounts(123, []data.UserAccountHandler{account, nil}))
+	require.Nil(t, forwarder.FinalizedBlock([]byte("header hash")))
+	require.Len(t, called, 5)
+}
+
+func TestDriverForwarder_SaveAccounts_ExpectAccountDetailsReceived(t *testing.T) {
+	t.Parallel()
+
+	scAddress := append(make([]byte, 10), bytes.Repeat([]byte("c"), 22)...)
+	account := &mock.UserAccountMock{
+		Address:      scAddress,
+		UserName:     []byte("user name"),
+		OwnerAddress: []byte("owner"),
+		CodeHash:     []byte("code hash"),
+		RootHash:     []byte("root hash"),
+		CodeMetadata: []byte{1, 2},
+		DevReward:    big.NewInt(7),
+	}
+
+	var receivedAccounts []data.UserAccountHandler
+	forwarder := createForwarderWithReceiver(t, &mock.DriverStub{
+		SaveAccountsCalled: func(_ uint64, acc []data.UserAccountHandler) error {
+			receivedAccounts = acc
+			return nil
+		},
+	})
+
+	err := forwarder.SaveAccounts(123, []data.UserAccountHandler{account})
+	require.Nil(t, err)
+	require.Len(t, receivedAccounts, 1)
+
+	details, ok := receivedAccounts[0].(process.UserAccountDetailsHandler)
+	require.True(t, ok)
+	require.Equal(t, account.UserName, details.GetUserName())
+	require.Equal(t, account.OwnerAddress, details.GetOwnerAddress())
+	require.Equal(t, account.CodeHash, details.GetCodeHash())
+	require.Equal(t, account.RootHash, details.GetRootHash())
+	require.Equal(t, account.CodeMetadata, details.GetCodeMetadata())
+	require.Equal(t, account.DevReward, details.GetDeveloperReward())
+	require.True(t, core.IsSmartContractAddress(receivedAccounts[0].AddressBytes()))
+}
+
+func TestDriverForwarder_DriverError_ExpectErrorForwarded(t *testing.T) {
+	t.Parallel()
+
+	errDriver := errors.New("driver error")
+	forwarder := createForwarderWithReceiver(t, &mock.DriverStub{
+		FinalizedBlockCalled: func(headerHash []byte) error {
+			return errDriver
+		},
+	})
+
+	err := forwarder.FinalizedBlock([]byte("header hash"))
+	require.Equal(t, errDriver.Error(), err.Error())
+}
+
+func TestDriverForwarder_UnknownHeaderType_ExpectError(t *testing.T) {
+	t.Parallel()
+
+	forwarder := createForwarderWithReceiver(t, &mock.DriverStub{})
+
+	err := forwarder.SaveBlock(&indexer.ArgsSaveBlockData{Header: nil})
+	require.Equal(t, covalent.ErrUnknownHeaderType, err)
+}
+
+func TestDriverForwarder_IndexerNotReachable_CloseForwarder_ExpectErrForwarderClosed(t *testing.T) {
+	t.Parallel()
+
+	forwarder, _ := outport.NewDriverForwarder(&outport.ArgsDriverForwarder{
+		URL:        "ws://localhost:1/outport",
+		Marshaller: &marshal.GogoProtoMarshalizer{},
+	})
+
+	go func() {
+		time.Sleep(time.Millisecond * 4 * covalent.RetrialTimeoutMS)
+		_ = forwarder.Close()
+	}()
+
+	err := forwarder.FinalizedBlock([]byte("header hash"))
+	require.Equal(t, covalent.ErrForwarderClosed, err)
+}
+
+func createForwarderWithReceiver(t *testing.T, driver covalent.Driver) covalent.Driver {
+	marshaller := &marshal.GogoProtoMarshalizer{}
+	receiver, err := outport.NewDriverReceiver(driver, marshaller)
+	require.Nil(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{}
+		ws, errUpgrade := upgrader.Upgrade(w, r, nil)
+		if errUpgrade != nil {
+			return
+		}
+
+		receiver.Listen(ws)
+		_ = ws.Close()
+	}))
+
+	forwarder, err := outport.NewDriverForwarder(&outport.ArgsDriverForwarder{
+		URL:        "ws" + strings.TrimPrefix(server.URL, "http"),
+		Marshaller: marshaller,
+	})
+	require.Nil(t, err)
+
+	t.Cleanup(func() {
+		_ = forwarder.Close()
+		server.Close()
+	})
+
+	return forwarder
+}
+
+func generateArgsSaveBlock() *indexer.ArgsSaveBlockData {
+	txHash := testscommon.GenerateRandomFixedBytes(32)
+	scrHash := testscommon.GenerateRandomFixedBytes(32)
+	rewardHash := testscommon.GenerateRandomFixedBytes(32)
+	invalidTxHash := testscommon.GenerateRandomFixedBytes(32)
+	receiptHash := testscommon.GenerateRandomFixedBytes(32)
+
+	return &indexer.ArgsSaveBlockData{
+		HeaderHash: testscommon.GenerateRandomFixedBytes(32),
+		Header: &block.Header{
+			Nonce:           1,
+			Round:           2,
+			TimeStamp:       3,
+			RootHash:        []byte("root hash"),
+			AccumulatedFees: big.NewInt(4),
+			DeveloperFees:   big.NewInt(5),
+		},
+		Body: &block.Body{MiniBlocks: []*block.MiniBlock{
+			{TxHashes: [][]byte{txHash}, Type: block.TxBlock},
+			{TxHashes: [][]byte{scrHash}, Type: block.SmartContractResultBlock},
+		}},
+		SignersIndexes:         []uint64{1, 2, 3},
+		NotarizedHeadersHashes: []string{"0a", "1f"},
+		HeaderGasConsumption:   indexer.HeaderGasConsumption{GasProvided: 10, GasRefunded: 11},
+		TransactionsPool: &indexer.Pool{
+			Txs: map[string]data.TransactionHandler{
+				string(txHash): &transaction.Transaction{Nonce: 6, Value: big.NewInt(7), Data: []byte("data")},
+			},
+			Scrs: map[string]data.TransactionHandler{
+				string(scrHash): &smartContractResult.SmartContractResult{Nonce: 8, Value: big.NewInt(9), RelayedValue: big.NewInt(0)},
+			},
+			Rewards: map[string]data.TransactionHandler{
+				string(rewardHash): &rewardTx.RewardTx{Round: 10, Value: big.NewInt(11)},
+			},
+			Invalid: map[string]data.TransactionHandler{
+				string(invalidTxHash): &transaction.Transaction{Nonce: 12, Value: big.NewInt(13)},
+			},
+			Receipts: map[string]data.TransactionHandler{
+				string(receiptHash): &receipt.Receipt{Value: big.NewInt(14), TxHash: txHash},
+			},
+			Logs: []*data.LogData{
+				{
+					TxHash: string(txHash),
+					LogHandler: &transaction.Log{
+						Address: []byte("address"),
+						Events:  []*transaction.Event{{Identifier: []byte("identifier"), Topics: [][]byte{[]byte("topic")}}},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,130 @@
+package outport
+
+import (
+	"encoding/json"
+
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/covalent-indexer-go/process"
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
+	"github.com/gorilla/websocket"
+)
+
+type driverReceiver struct {
+	driver     covalent.Driver
+	serializer *dataSerializer
+}
+
+// NewDriverReceiver creates a new instance of driver receiver, which handles the Driver calls forwarded
+// by the node, by calling the same functions of the provided driver
+func NewDriverReceiver(driver covalent.Driver, marshaller marshal.Marshalizer) (*driverReceiver, error) {
+	if check.IfNil(driver) {
+		return nil, covalent.ErrNilDriver
+	}
+	serializer, err := newDataSerializer(marshaller)
+	if err != nil {
+		return nil, err
+	}
+
+	return &driverReceiver{
+		driver:     driver,
+		serializer: serializer,
+	}, nil
+}
+
+// Listen reads the forwarded driver calls from the websocket connection and acknowledges each one of them,
+// after it has been handled. It returns when the connection can no longer be read or written
+func (dr *driverReceiver) Listen(conn process.WSConn) {
+	for {
+		msgType, messageBytes, err := conn.ReadMessage()
+		if err != nil {
+			log.Debug("driver receiver stopped listening, could not read message", "error", err)
+			return
+		}
+		if msgType != websocket.BinaryMessage {
+			continue
+		}
+
+		ackBytes, err := json.Marshal(dr.handleMessage(messageBytes))
+		if err != nil {
+			log.Error("could not marshal acknowledge", "error", err)
+			continue
+		}
+
+		err = conn.WriteMessage(websocket.BinaryMessage, ackBytes)
+		if err != nil {
+			log.Debug("driver receiver stopped listening, could not send acknowledge", "error", err)
+			return
+		}
+	}
+}
+
+func (dr *driverReceiver) handleMessage(messageBytes []byte) *Acknowledge {
+	message := &Message{}
+	err := json.Unmarshal(messageBytes, message)
+	if err == nil {
+		err = dr.handleDriverCall(message)
+	}
+
+	ack := &Acknowledge{ID: message.ID}
+	if err != nil {
+		log.Warn("could not handle forwarded driver call", "message type", message.Type, "error", err)
+		ack.Error = err.Error()
+	}
+
+	return ack
+}
+
+func (dr *driverReceiver) handleDriverCall(message *Message) error {
+	switch message.Type {
+	case SaveBlockMessage:
+		args, err := dr.serializer.deserializeSaveBlock(message.Payload)
+		if err != nil {
+			return err
+		}
+		return dr.driver.SaveBlock(args)
+	case RevertIndexedBlockMessage:
+		header, body, err := dr.serializer.deserializeRevertIndexedBlock(message.Payload)
+		if err != nil {
+			return err
+		}
+		return dr.driver.RevertIndexedBlock(header, body)
+	case SaveRoundsInfoMessage:
+		roundsInfos := make([]*indexer.RoundInfo, 0)
+		err := json.Unmarshal(message.Payload, &roundsInfos)
+		if err != nil {
+			return err
+		}
+		return dr.driver.SaveRoundsInfo(roundsInfos)
+	case SaveValidatorsPubKeysMessage:
+		validatorsPubKeys := &validatorsPubKeysData{}
+		err := json.Unmarshal(message.Payload, validatorsPubKeys)
+		if err != nil {
+			return err
+		}
+		return dr.driver.SaveValidatorsPubKeys(validatorsPubKeys.ValidatorsPubKeys, validatorsPubKeys.Epoch)
+	case SaveValidatorsRatingMessage:
+		validatorsRating := &validatorsRatingData{}
+		err := json.Unmarshal(message.Payload, validatorsRating)
+		if err != nil {
+			return err
+		}
+		return dr.driver.SaveValidatorsRating(validatorsRating.IndexID, validatorsRating.InfoRating)
+	case SaveAccountsMessage:
+		blockTimestamp, accounts, err := dr.serializer.deserializeAccounts(message.Payload)
+		if err != nil {
+			return err
+		}
+		return dr.driver.SaveAccounts(blockTimestamp, accounts)
+	case FinalizedBlockMessage:
+		var headerHash []byte
+		err := json.Unmarshal(message.Payload, &headerHash)
+		if err != nil {
+			return err
+		}
+		return dr.driver.FinalizedBlock(headerHash)
+	default:
+		return covalent.ErrUnknownMessageType
+	}
+}
@@ -0,0 +1,198 @@
+package outport
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/elrond-go-core/data/block"
+	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
+)
+
+// Types of the Driver calls which are forwarded by the node to the standalone covalent indexer
+const (
+	SaveBlockMessage             = "SaveBlock"
+	RevertIndexedBlockMessage    = "RevertIndexedBlock"
+	SaveRoundsInfoMessage        = "SaveRoundsInfo"
+	SaveValidatorsPubKeysMessage = "SaveValidatorsPubKeys"
+	SaveValidatorsRatingMessage  = "SaveValidatorsRating"
+	SaveAccountsMessage          = "SaveAccounts"
+	FinalizedBlockMessage        = "FinalizedBlock"
+)
+
+// Message holds a forwarded Driver call, identified by an increasing ID
+type Message struct {
+	ID      uint64
+	Type    string
+	Payload []byte
+}
+
+// Acknowledge is sent back by the receiver, once a forwarded Driver call has been handled
+type Acknowledge struct {
+	ID    uint64
+	Error string
+}
+
+type headerData struct {
+	Type          string
+	Header        []byte
+	ScheduledData *scheduledData
+}
+
+type scheduledData struct {
+	RootHash        []byte
+	AccumulatedFees *big.Int
+	DeveloperFees   *big.Int
+	GasProvided     uint64
+	GasPenalized    uint64
+	GasRefunded     uint64
+}
+
+type logData struct {
+	TxHash []byte
+	Log    []byte
+}
+
+type saveBlockData struct {
+	HeaderHash             []byte
+	Header                 *headerData
+	Body                   []byte
+	SignersIndexes         []uint64
+	NotarizedHeadersHashes []string
+	HeaderGasConsumption   indexer.HeaderGasConsumption
+	Txs                    map[string][]byte
+	Scrs                   map[string][]byte
+	Rewards                map[string][]byte
+	Invalid                map[string][]byte
+	Receipts               map[string][]byte
+	Logs                   []*logData
+}
+
+type revertIndexedBlockData struct {
+	Header *headerData
+	Body   []byte
+}
+
+type validatorsPubKeysData struct {
+	ValidatorsPubKeys map[uint32][][]byte
+	Epoch             uint32
+}
+
+type validatorsRatingData struct {
+	IndexID    string
+	InfoRating []*indexer.ValidatorRatingInfo
+}
+
+type accountsData struct {
+	BlockTimestamp uint64
+	Accounts       []*userAccount
+}
+
+// userAccount is a data.UserAccountHandler built from the account data forwarded by the node
+type userAccount struct {
+	Address         []byte
+	Balance         *big.Int
+	Nonce           uint64
+	UserName        []byte
+	OwnerAddress    []byte
+	CodeHash        []byte
+	RootHash        []byte
+	CodeMetadata    []byte
+	DeveloperReward *big.Int
+}
+
+// RetrieveValueFromDataTrieTracker returns ErrDataTrieNotForwarded, since data tries are not forwarded by the node
+func (ua *userAccount) RetrieveValueFromDataTrieTracker(_ []byte) ([]byte, error) {
+	return nil, covalent.ErrDataTrieNotForwarded
+}
+
+// GetBalance returns the account balance
+func (ua *userAccount) GetBalance() *big.Int {
+	return ua.Balance
+}
+
+// GetNonce returns the account nonce
+func (ua *userAccount) GetNonce() uint64 {
+	return ua.Nonce
+}
+
+// AddressBytes returns the account address
+func (ua *userAccount) AddressBytes() []byte {
+	return ua.Address
+}
+
+// GetUserName returns the account user name
+func (ua *userAccount) GetUserName() []byte {
+	return ua.UserName
+}
+
+// GetOwnerAddress returns the address of the smart contract owner
+func (ua *userAccount) GetOwnerAddress() []byte {
+	return ua.OwnerAddress
+}
+
+// GetCodeHash returns the hash of the smart contract code
+func (ua *userAccount) GetCodeHash() []byte {
+	return ua.CodeHash
+}
+
+// GetRootHash returns the root hash of the account data trie
+func (ua *userAccount) GetRootHash() []byte {
+	return ua.RootHash
+}
+
+// GetCodeMetadata returns the smart contract code metadata
+func (ua *userAccount) GetCodeMetadata() []byte {
+	return ua.CodeMetadata
+}
+
+// GetDeveloperReward returns the developer reward accumulated by the smart contract
+func (ua *userAccount) GetDeveloperReward() *big.Int {
+	return ua.DeveloperReward
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ua *userAccount) IsInterfaceNil() bool {
+	return ua == nil
+}
+
+// headerV2 is a version 2 shard header built from the header data forwarded by the node, which wraps
+// the version 1 shard header fields and holds the scheduled miniblocks execution data
+type headerV2 struct {
+	*block.Header
+	scheduled *scheduledData
+}
+
+// GetScheduledRootHash returns the root hash after the scheduled miniblocks execution
+func (hv2 *headerV2) GetScheduledRootHash() []byte {
+	return hv2.scheduled.RootHash
+}
+
+// GetScheduledAccumulatedFees returns the fees accumulated by the scheduled miniblocks execution
+func (hv2 *headerV2) GetScheduledAccumulatedFees() *big.Int {
+	return hv2.scheduled.AccumulatedFees
+}
+
+// GetScheduledDeveloperFees returns the developer fees accumulated by the scheduled miniblocks execution
+func (hv2 *headerV2) GetScheduledDeveloperFees() *big.Int {
+	return hv2.scheduled.DeveloperFees
+}
+
+// GetScheduledGasProvided returns the gas provided for the scheduled miniblocks execution
+func (hv2 *headerV2) GetScheduledGasProvided() uint64 {
+	return hv2.scheduled.GasProvided
+}
+
+// GetScheduledGasPenalized returns the gas penalized by the scheduled miniblocks execution
+func (hv2 *headerV2) GetScheduledGasPenalized() uint64 {
+	return hv2.scheduled.GasPenalized
+}
+
+// GetScheduledGasRefunded returns the gas refunded by the scheduled miniblocks execution
+func (hv2 *headerV2) GetScheduledGasRefunded() uint64 {
+	return hv2.scheduled.GasRefunded
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (hv2 *headerV2) IsInterfaceNil() bool {
+	return hv2 == nil
+}
@@ -2,6 +2,9 @@ package accounts
 
 import (
 	"bytes"
+	"math/big"
+	"sort"
+	"sync"
 
 	"github.com/ElrondNetwork/covalent-indexer-go"
 	"github.com/ElrondNetwork/covalent-indexer-go/process"
@@ -10,52 +13,175 @@ import (
 	"github.com/ElrondNetwork/elrond-go-core/core"
 	"github.com/ElrondNetwork/elrond-go-core/core/check"
 	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/esdt"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
 	logger "github.com/ElrondNetwork/elrond-go-logger"
 )
 
 var log = logger.GetOrCreate("covalent/process/accounts")
 
+// MaxAccountsSnapshots defines how many accounts snapshots (one for each block timestamp) are kept
+// until a block with the same timestamp is processed
+const MaxAccountsSnapshots = 10
+
+// esdtOperations holds the identifiers of all esdt events which change the token balance of the event address,
+// other than the transfers, which are already provided as token transfers
+var esdtOperations = map[string]struct{}{
+	core.BuiltInFunctionESDTBurn:           {},
+	core.BuiltInFunctionESDTLocalMint:      {},
+	core.BuiltInFunctionESDTLocalBurn:      {},
+	core.BuiltInFunctionESDTNFTCreate:      {},
+	core.BuiltInFunctionESDTNFTAddQuantity: {},
+	core.BuiltInFunctionESDTNFTBurn:        {},
+	core.BuiltInFunctionESDTWipe:           {},
+}
+
+const (
+	tokenIdentifierTopicIndex = 0
+	tokenNonceTopicIndex      = 1
+	wipedAddressTopicIndex    = 3
+	minESDTOperationTopics    = 2
+)
+
+type accountsSnapshot map[string]data.UserAccountHandler
+
+type tokenKey struct {
+	identifier string
+	nonce      uint64
+}
+
+// touchedTokens holds, for each encoded address, all tokens which were changed in a block
+type touchedTokens map[string]map[tokenKey]struct{}
+
 type accountsProcessor struct {
 	shardCoordinator process.ShardCoordinator
 	pubKeyConverter  core.PubkeyConverter
 	accounts         covalent.AccountsAdapter
+	marshaller       marshal.Marshalizer
+
+	snapshots    map[uint64]accountsSnapshot
+	mutSnapshots sync.Mutex
+
+	balances *balancesCache
+
+	dataTrieNotForwardedOnce sync.Once
 }
 
-// NewAccountsProcessor creates a new instance of accounts processor
+// NewAccountsProcessor creates a new instance of accounts processor. The accounts adapter is optional:
+// if nil, accounts are only taken from the snapshots provided by SaveAccountsSnapshot
 func NewAccountsProcessor(
 	shardCoordinator process.ShardCoordinator,
 	accounts covalent.AccountsAdapter,
 	pubKeyConverter core.PubkeyConverter,
+	marshaller marshal.Marshalizer,
 ) (*accountsProcessor, error) {
 
 	if check.IfNil(shardCoordinator) {
 		return nil, covalent.ErrNilShardCoordinator
 	}
-	if check.IfNil(accounts) {
-		return nil, covalent.ErrNilAccountsAdapter
-	}
 	if check.IfNil(pubKeyConverter) {
 		return nil, covalent.ErrNilPubKeyConverter
 	}
+	if check.IfNil(marshaller) {
+		return nil, covalent.ErrNilMarshaller
+	}
 
 	return &accountsProcessor{
 		accounts:         accounts,
 		pubKeyConverter:  pubKeyConverter,
+		marshaller:       marshaller,
 		shardCoordinator: shardCoordinator,
+		snapshots:        make(map[uint64]accountsSnapshot),
+		balances:         newBalancesCache(MaxCachedAccounts, MaxRevertibleBlocks),
 	}, nil
 }
 
-// ProcessAccounts converts accounts data to a specific structure defined by avro schema
+// SaveAccountsSnapshot keeps the accounts state of the block with the given timestamp,
+// so that it can be used when the block is processed
+func (ap *accountsProcessor) SaveAccountsSnapshot(blockTimestamp uint64, accounts []data.UserAccountHandler) {
+	ap.mutSnapshots.Lock()
+	defer ap.mutSnapshots.Unlock()
+
+	snapshot, exists := ap.snapshots[blockTimestamp]
+	if !exists {
+		snapshot = make(accountsSnapshot)
+		ap.snapshots[blockTimestamp] = snapshot
+	}
+
+	for _, account := range accounts {
+		if check.IfNil(account) {
+			continue
+		}
+
+		address := utility.EncodePubKey(ap.pubKeyConverter, account.AddressBytes())
+		snapshot[string(address)] = account
+	}
+
+	ap.removeOldestSnapshotsIfNeeded()
+}
+
+func (ap *accountsProcessor) removeOldestSnapshotsIfNeeded() {
+	if len(ap.snapshots) <= MaxAccountsSnapshots {
+		return
+	}
+
+	timestamps := make([]uint64, 0, len(ap.snapshots))
+	for timestamp := range ap.snapshots {
+		timestamps = append(timestamps, timestamp)
+	}
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i] < timestamps[j]
+	})
+
+	// snapshots are removed once used, so all evicted snapshots were either saved after their block was processed,
+	// or their block was never processed
+	for _, timestamp := range timestamps[:len(timestamps)-MaxAccountsSnapshots] {
+		log.Warn("accountsProcessor: accounts snapshot evicted without being used",
+			"block timestamp", timestamp,
+			"num accounts", len(ap.snapshots[timestamp]))
+		delete(ap.snapshots, timestamp)
+	}
+}
+
+func (ap *accountsProcessor) popSnapshot(blockTimestamp uint64) accountsSnapshot {
+	ap.mutSnapshots.Lock()
+	defer ap.mutSnapshots.Unlock()
+
+	snapshot, exists := ap.snapshots[blockTimestamp]
+	if !exists {
+		return make(accountsSnapshot)
+	}
+
+	delete(ap.snapshots, blockTimestamp)
+	return snapshot
+}
+
+// ProcessAccounts converts accounts data to a specific structure defined by avro schema, sorted by address. For all
+// the addresses touched by an esdt operation, the balances of the changed tokens are read from the account's data trie.
+// The previous balance and nonce of each account are the last emitted ones, if still cached, otherwise they are unknown
 func (ap *accountsProcessor) ProcessAccounts(
 	processedTxs []*schema.Transaction,
 	processedSCRs []*schema.SCResult,
 	processedReceipts []*schema.Receipt,
+	processedLogs []*schema.Log,
+	tokenTransfers []*schema.TokenTransfer,
+	blockTimestamp uint64,
 ) []*schema.AccountBalanceUpdate {
+	snapshot := ap.popSnapshot(blockTimestamp)
 	addresses := ap.getAllAddresses(processedTxs, processedSCRs, processedReceipts)
+	for address := range snapshot {
+		addresses[address] = struct{}{}
+	}
+
+	tokens := ap.getTouchedTokens(processedLogs, tokenTransfers)
+	for address := range tokens {
+		addresses[address] = struct{}{}
+	}
+
 	accounts := make([]*schema.AccountBalanceUpdate, 0, len(addresses))
 
-	for address := range addresses {
-		account, err := ap.processAccount(address)
+	for _, address := range getSortedAddresses(addresses) {
+		account, err := ap.processAccount(address, snapshot, tokens[address], blockTimestamp)
 		if err != nil || account == nil {
 			log.Warn("cannot get account address", "address", address, "error", err)
 			continue
@@ -67,6 +193,16 @@ func (ap *accountsProcessor) ProcessAccounts(
 	return accounts
 }
 
+func getSortedAddresses(addresses map[string]struct{}) []string {
+	sortedAddresses := make([]string, 0, len(addresses))
+	for address := range addresses {
+		sortedAddresses = append(sortedAddresses, address)
+	}
+
+	sort.Strings(sortedAddresses)
+	return sortedAddresses
+}
+
 func (ap *accountsProcessor) getAllAddresses(
 	processedTxs []*schema.Transaction,
 	processedSCRs []*schema.SCResult,
@@ -92,17 +228,208 @@ func (ap *accountsProcessor) getAllAddresses(
 }
 
 func (ap *accountsProcessor) addAddressIfInSelfShard(addresses map[string]struct{}, address []byte) {
+	if ap.isInSelfShard(address) {
+		addresses[string(address)] = struct{}{}
+	}
+}
+
+func (ap *accountsProcessor) isInSelfShard(address []byte) bool {
 	if bytes.Equal(address, utility.MetaChainShardAddress()) {
+		return false
+	}
+
+	return ap.shardCoordinator.SelfId() == ap.shardCoordinator.ComputeId(address)
+}
+
+func (ap *accountsProcessor) getTouchedTokens(processedLogs []*schema.Log, tokenTransfers []*schema.TokenTransfer) touchedTokens {
+	tokens := make(touchedTokens)
+
+	for _, transfer := range tokenTransfers {
+		token := tokenKey{identifier: string(transfer.Identifier), nonce: uint64(transfer.Nonce)}
+		ap.addTokenIfInSelfShard(tokens, transfer.Sender, token)
+		ap.addTokenIfInSelfShard(tokens, transfer.Receiver, token)
+	}
+
+	for _, processedLog := range processedLogs {
+		for _, event := range processedLog.Events {
+			ap.addTokensFromEvent(tokens, event)
+		}
+	}
+
+	return tokens
+}
+
+func (ap *accountsProcessor) addTokensFromEvent(tokens touchedTokens, event *schema.Event) {
+	_, isESDTOperation := esdtOperations[string(event.Identifier)]
+	if !isESDTOperation || len(event.Topics) < minESDTOperationTopics {
 		return
 	}
-	if ap.shardCoordinator.SelfId() == ap.shardCoordinator.ComputeId(address) {
-		addresses[string(address)] = struct{}{}
+
+	token := tokenKey{
+		identifier: string(event.Topics[tokenIdentifierTopicIndex]),
+		nonce:      big.NewInt(0).SetBytes(event.Topics[tokenNonceTopicIndex]).Uint64(),
+	}
+	ap.addTokenIfInSelfShard(tokens, event.Address, token)
+
+	if string(event.Identifier) == core.BuiltInFunctionESDTWipe && len(event.Topics) > wipedAddressTopicIndex {
+		wipedAddress := utility.EncodePubKey(ap.pubKeyConverter, event.Topics[wipedAddressTopicIndex])
+		ap.addTokenIfInSelfShard(tokens, wipedAddress, token)
+	}
+}
+
+func (ap *accountsProcessor) addTokenIfInSelfShard(tokens touchedTokens, address []byte, token tokenKey) {
+	if !ap.isInSelfShard(address) {
+		return
+	}
+
+	addressTokens, found := tokens[string(address)]
+	if !found {
+		addressTokens = make(map[tokenKey]struct{})
+		tokens[string(address)] = addressTokens
+	}
+
+	addressTokens[token] = struct{}{}
+}
+
+func (ap *accountsProcessor) processAccount(
+	address string,
+	snapshot accountsSnapshot,
+	tokens map[tokenKey]struct{},
+	blockTimestamp uint64,
+) (*schema.AccountBalanceUpdate, error) {
+	account, found := snapshot[address]
+	if !found {
+		var err error
+		account, err = ap.loadAccount(address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	state := newAccountState(account)
+	accountUpdate := &schema.AccountBalanceUpdate{
+		Address:             []byte(address),
+		Balance:             utility.GetBytes(state.balance),
+		Nonce:               int64(state.nonce),
+		DeveloperReward:     utility.GetBytes(nil),
+		IsSmartContract:     core.IsSmartContractAddress(account.AddressBytes()),
+		TokenBalanceUpdates: ap.getTokenBalanceUpdates(account, tokens),
+	}
+	ap.addAccountDetails(accountUpdate, account)
+	ap.addPreviousState(accountUpdate, state, blockTimestamp)
+
+	return accountUpdate, nil
+}
+
+// addPreviousState adds the previously emitted balance and nonce of the account, as well as the balance delta,
+// leaving them null if the account is not cached
+func (ap *accountsProcessor) addPreviousState(
+	accountUpdate *schema.AccountBalanceUpdate,
+	state *accountState,
+	blockTimestamp uint64,
+) {
+	previousState := ap.balances.update(blockTimestamp, string(accountUpdate.Address), state)
+	if previousState == nil {
+		return
 	}
+
+	accountUpdate.PreviousBalance = utility.GetBytes(previousState.balance)
+	accountUpdate.Delta = utility.GetSignedBytes(big.NewInt(0).Sub(state.balance, previousState.balance))
+	accountUpdate.PreviousNonce = int64(previousState.nonce)
+}
+
+// RevertAccounts restores the cached accounts balances and nonces as they were before processing the block
+// with the given timestamp
+func (ap *accountsProcessor) RevertAccounts(blockTimestamp uint64) {
+	ap.balances.revert(blockTimestamp)
 }
 
-func (ap *accountsProcessor) processAccount(address string) (*schema.AccountBalanceUpdate, error) {
-	//TODO: This only works as long as covalent indexer is part of elrond node binary.
-	// This needs to be changed, so that account content is given as an input parameter, not loaded.
+// addAccountDetails adds the user name, owner, code and data trie details of the account, if it provides them
+func (ap *accountsProcessor) addAccountDetails(accountUpdate *schema.AccountBalanceUpdate, account data.UserAccountHandler) {
+	details, ok := account.(process.UserAccountDetailsHandler)
+	if !ok {
+		return
+	}
+
+	accountUpdate.UserName = details.GetUserName()
+	accountUpdate.CodeMetadata = details.GetCodeMetadata()
+	accountUpdate.DeveloperReward = utility.GetBytes(details.GetDeveloperReward())
+	if len(details.GetOwnerAddress()) > 0 {
+		accountUpdate.OwnerAddress = utility.EncodePubKey(ap.pubKeyConverter, details.GetOwnerAddress())
+	}
+	if len(details.GetCodeHash()) > 0 {
+		accountUpdate.CodeHash = details.GetCodeHash()
+	}
+	if len(details.GetRootHash()) > 0 {
+		accountUpdate.RootHash = details.GetRootHash()
+	}
+}
+
+// getTokenBalanceUpdates returns the balances of the touched tokens of the account. No token balance updates are
+// provided for the accounts forwarded to the standalone indexer, since their data tries are not forwarded by the node
+func (ap *accountsProcessor) getTokenBalanceUpdates(account data.UserAccountHandler, tokens map[tokenKey]struct{}) []*schema.TokenBalanceUpdate {
+	tokenBalanceUpdates := make([]*schema.TokenBalanceUpdate, 0, len(tokens))
+
+	for token := range tokens {
+		balance, err := ap.getTokenBalance(account, token)
+		if err == covalent.ErrDataTrieNotForwarded {
+			ap.dataTrieNotForwardedOnce.Do(func() {
+				log.Warn("accounts data tries are not forwarded by the node, token balance updates are not provided")
+			})
+			return make([]*schema.TokenBalanceUpdate, 0)
+		}
+		if err != nil {
+			log.Warn("cannot get token balance", "token", token.identifier, "nonce", token.nonce, "error", err)
+			continue
+		}
+
+		tokenBalanceUpdates = append(tokenBalanceUpdates, &schema.TokenBalanceUpdate{
+			Identifier: []byte(token.identifier),
+			Nonce:      int64(token.nonce),
+			Balance:    utility.GetBytes(balance),
+		})
+	}
+
+	sort.Slice(tokenBalanceUpdates, func(i, j int) bool {
+		if tokenBalanceUpdates[i].Nonce == tokenBalanceUpdates[j].Nonce {
+			return bytes.Compare(tokenBalanceUpdates[i].Identifier, tokenBalanceUpdates[j].Identifier) < 0
+		}
+		return tokenBalanceUpdates[i].Nonce < tokenBalanceUpdates[j].Nonce
+	})
+
+	return tokenBalanceUpdates
+}
+
+// getTokenBalance returns the token balance stored in the account's data trie. A missing token key means
+// that the whole balance was transferred, burned or wiped
+func (ap *accountsProcessor) getTokenBalance(account data.UserAccountHandler, token tokenKey) (*big.Int, error) {
+	tokenStorageKey := []byte(core.ElrondProtectedKeyPrefix + core.ESDTKeyIdentifier + token.identifier)
+	if token.nonce > 0 {
+		tokenStorageKey = append(tokenStorageKey, big.NewInt(0).SetUint64(token.nonce).Bytes()...)
+	}
+
+	marshalledToken, err := account.RetrieveValueFromDataTrieTracker(tokenStorageKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(marshalledToken) == 0 {
+		return big.NewInt(0), nil
+	}
+
+	esdtToken := &esdt.ESDigitalToken{}
+	err = ap.marshaller.Unmarshal(esdtToken, marshalledToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return esdtToken.Value, nil
+}
+
+func (ap *accountsProcessor) loadAccount(address string) (data.UserAccountHandler, error) {
+	if check.IfNil(ap.accounts) {
+		return nil, covalent.ErrAccountNotFoundInSnapshot
+	}
+
 	pubKey, err := ap.pubKeyConverter.Decode(address)
 	if err != nil {
 		return nil, err
@@ -117,9 +444,11 @@ func (ap *accountsProcessor) processAccount(address string) (*schema.AccountBala
 	if !castOk {
 		return nil, covalent.ErrCannotCastAccountHandlerToUserAccount
 	}
-	return &schema.AccountBalanceUpdate{
-		Address: []byte(address),
-		Balance: utility.GetBytes(account.GetBalance()),
-		Nonce:   int64(account.GetNonce()),
-	}, nil
+
+	return account, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ap *accountsProcessor) IsInterfaceNil() bool {
+	return ap == nil
 }
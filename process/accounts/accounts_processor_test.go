@@ -15,6 +15,9 @@ import (
 	"github.com/ElrondNetwork/covalent-indexer-go/testscommon"
 	"github.com/ElrondNetwork/covalent-indexer-go/testscommon/mock"
 	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/esdt"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
 	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
 	"github.com/stretchr/testify/require"
 )
@@ -23,30 +26,36 @@ func TestNewAccountsProcessor(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		args        func() (process.ShardCoordinator, covalent.AccountsAdapter, core.PubkeyConverter)
+		args        func() (process.ShardCoordinator, covalent.AccountsAdapter, core.PubkeyConverter, marshal.Marshalizer)
 		expectedErr error
 	}{
 		{
-			args: func() (process.ShardCoordinator, covalent.AccountsAdapter, core.PubkeyConverter) {
-				return nil, &mock.AccountsAdapterStub{}, &mock.PubKeyConverterStub{}
+			args: func() (process.ShardCoordinator, covalent.AccountsAdapter, core.PubkeyConverter, marshal.Marshalizer) {
+				return nil, &mock.AccountsAdapterStub{}, &mock.PubKeyConverterStub{}, &mock.MarshallerStub{}
 			},
 			expectedErr: covalent.ErrNilShardCoordinator,
 		},
 		{
-			args: func() (process.ShardCoordinator, covalent.AccountsAdapter, core.PubkeyConverter) {
-				return &mock.ShardCoordinatorMock{}, nil, &mock.PubKeyConverterStub{}
+			args: func() (process.ShardCoordinator, covalent.AccountsAdapter, core.PubkeyConverter, marshal.Marshalizer) {
+				return &mock.ShardCoordinatorMock{}, nil, &mock.PubKeyConverterStub{}, &mock.MarshallerStub{}
 			},
-			expectedErr: covalent.ErrNilAccountsAdapter,
+			expectedErr: nil,
 		},
 		{
-			args: func() (process.ShardCoordinator, covalent.AccountsAdapter, core.PubkeyConverter) {
-				return &mock.ShardCoordinatorMock{}, &mock.AccountsAdapterStub{}, nil
+			args: func() (process.ShardCoordinator, covalent.AccountsAdapter, core.PubkeyConverter, marshal.Marshalizer) {
+				return &mock.ShardCoordinatorMock{}, &mock.AccountsAdapterStub{}, nil, &mock.MarshallerStub{}
 			},
 			expectedErr: covalent.ErrNilPubKeyConverter,
 		},
 		{
-			args: func() (process.ShardCoordinator, covalent.AccountsAdapter, core.PubkeyConverter) {
-				return &mock.ShardCoordinatorMock{}, &mock.AccountsAdapterStub{}, &mock.PubKeyConverterStub{}
+			args: func() (process.ShardCoordinator, covalent.AccountsAdapter, core.PubkeyConverter, marshal.Marshalizer) {
+				return &mock.ShardCoordinatorMock{}, &mock.AccountsAdapterStub{}, &mock.PubKeyConverterStub{}, nil
+			},
+			expectedErr: covalent.ErrNilMarshaller,
+		},
+		{
+			args: func() (process.ShardCoordinator, covalent.AccountsAdapter, core.PubkeyConverter, marshal.Marshalizer) {
+				return &mock.ShardCoordinatorMock{}, &mock.AccountsAdapterStub{}, &mock.PubKeyConverterStub{}, &mock.MarshallerStub{}
 			},
 			expectedErr: nil,
 		},
@@ -65,12 +74,13 @@ func TestAccountsProcessor_ProcessAccounts_InvalidUserAccountHandler_ExpectZeroA
 			LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
 				return nil, nil
 			}},
-		&mock.PubKeyConverterStub{})
+		&mock.PubKeyConverterStub{},
+		&mock.MarshallerStub{})
 
 	tx := &schema.Transaction{
 		Receiver: testscommon.GenerateRandomBytes(),
 		Sender:   testscommon.GenerateRandomBytes()}
-	ret := ap.ProcessAccounts([]*schema.Transaction{tx}, []*schema.SCResult{}, []*schema.Receipt{})
+	ret := ap.ProcessAccounts([]*schema.Transaction{tx}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, 0)
 
 	require.Len(t, ret, 0)
 }
@@ -82,12 +92,13 @@ func TestAccountsProcessor_ProcessAccounts_InvalidLoadAccount_ExpectZeroAccounts
 			LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
 				return nil, errors.New("load account error")
 			}},
-		&mock.PubKeyConverterStub{})
+		&mock.PubKeyConverterStub{},
+		&mock.MarshallerStub{})
 
 	tx := &schema.Transaction{
 		Receiver: testscommon.GenerateRandomBytes(),
 		Sender:   testscommon.GenerateRandomBytes()}
-	ret := ap.ProcessAccounts([]*schema.Transaction{tx}, []*schema.SCResult{}, []*schema.Receipt{})
+	ret := ap.ProcessAccounts([]*schema.Transaction{tx}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, 0)
 
 	require.Len(t, ret, 0)
 }
@@ -96,12 +107,13 @@ func TestAccountsProcessor_ProcessAccounts_NotInSameShard_ExpectZeroAccounts(t *
 	ap, _ := accounts.NewAccountsProcessor(
 		&mock.ShardCoordinatorMock{SelfID: 4},
 		&mock.AccountsAdapterStub{UserAccountHandler: &mock.UserAccountMock{}},
-		&mock.PubKeyConverterStub{})
+		&mock.PubKeyConverterStub{},
+		&mock.MarshallerStub{})
 
 	tx := &schema.Transaction{
 		Receiver: testscommon.GenerateRandomBytes(),
 		Sender:   testscommon.GenerateRandomBytes()}
-	ret := ap.ProcessAccounts([]*schema.Transaction{tx}, []*schema.SCResult{}, []*schema.Receipt{})
+	ret := ap.ProcessAccounts([]*schema.Transaction{tx}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, 0)
 
 	require.Len(t, ret, 0)
 }
@@ -118,14 +130,15 @@ func TestAccountsProcessor_ProcessAccounts_OneSender_NilReceiver_ExpectOneAccoun
 				}
 				return make([]byte, 0), nil
 			},
-		})
+		},
+		&mock.MarshallerStub{})
 
 	tx := &schema.Transaction{
 		Sender:   addresses[0],
 		Receiver: nil,
 	}
 
-	ret := ap.ProcessAccounts([]*schema.Transaction{tx}, []*schema.SCResult{}, []*schema.Receipt{})
+	ret := ap.ProcessAccounts([]*schema.Transaction{tx}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, 0)
 
 	require.Len(t, ret, 1)
 	checkProcessedAccounts(t, addresses, ret)
@@ -143,14 +156,15 @@ func TestAccountsProcessor_ProcessAccounts_NilSender_OneReceiver_ExpectOneAccoun
 				}
 				return make([]byte, 0), nil
 			},
-		})
+		},
+		&mock.MarshallerStub{})
 
 	tx := &schema.Transaction{
 		Sender:   nil,
 		Receiver: addresses[0],
 	}
 
-	ret := ap.ProcessAccounts([]*schema.Transaction{tx}, []*schema.SCResult{}, []*schema.Receipt{})
+	ret := ap.ProcessAccounts([]*schema.Transaction{tx}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, 0)
 
 	require.Len(t, ret, 1)
 	checkProcessedAccounts(t, addresses, ret)
@@ -161,7 +175,8 @@ func TestAccountsProcessor_ProcessAccounts_FourAddresses_TwoIdentical_ExpectTwoA
 	ap, _ := accounts.NewAccountsProcessor(
 		&mock.ShardCoordinatorMock{},
 		&mock.AccountsAdapterStub{UserAccountHandler: &mock.UserAccountMock{}},
-		&mock.PubKeyConverterStub{})
+		&mock.PubKeyConverterStub{},
+		&mock.MarshallerStub{})
 
 	tx1 := &schema.Transaction{
 		Sender:   addresses[0],
@@ -172,7 +187,7 @@ func TestAccountsProcessor_ProcessAccounts_FourAddresses_TwoIdentical_ExpectTwoA
 		Receiver: addresses[0],
 	}
 
-	ret := ap.ProcessAccounts([]*schema.Transaction{tx1, tx2}, []*schema.SCResult{}, []*schema.Receipt{})
+	ret := ap.ProcessAccounts([]*schema.Transaction{tx1, tx2}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, 0)
 
 	require.Len(t, ret, 2)
 	checkProcessedAccounts(t, addresses, ret)
@@ -182,13 +197,14 @@ func TestAccountsProcessor_ProcessAccounts_OneAddress_OneMetaChainShardAddress_E
 	ap, _ := accounts.NewAccountsProcessor(
 		&mock.ShardCoordinatorMock{},
 		&mock.AccountsAdapterStub{UserAccountHandler: &mock.UserAccountMock{}},
-		&mock.PubKeyConverterStub{})
+		&mock.PubKeyConverterStub{},
+		&mock.MarshallerStub{})
 
 	tx := &schema.Transaction{
 		Receiver: []byte("adr1"),
 		Sender:   utility.MetaChainShardAddress()}
 
-	ret := ap.ProcessAccounts([]*schema.Transaction{tx}, []*schema.SCResult{}, []*schema.Receipt{})
+	ret := ap.ProcessAccounts([]*schema.Transaction{tx}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, 0)
 
 	require.Len(t, ret, 1)
 	require.Equal(t, []byte("adr1"), ret[0].Address)
@@ -210,13 +226,14 @@ func TestAccountsProcessor_ProcessAccounts_TwoAddresses_OneInvalidBech32_ExpectO
 				}
 				return make([]byte, 0), nil
 			},
-		})
+		},
+		&mock.MarshallerStub{})
 
 	tx := &schema.Transaction{
 		Receiver: []byte("adr1"),
 		Sender:   []byte(invalidAddress)}
 
-	ret := ap.ProcessAccounts([]*schema.Transaction{tx}, []*schema.SCResult{}, []*schema.Receipt{})
+	ret := ap.ProcessAccounts([]*schema.Transaction{tx}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, 0)
 
 	require.Len(t, ret, 1)
 	require.Equal(t, []byte("adr1"), ret[0].Address)
@@ -230,7 +247,8 @@ func TestAccountsProcessor_ProcessAccounts_SevenAddresses_ExpectSevenAccounts(t
 	ap, _ := accounts.NewAccountsProcessor(
 		&mock.ShardCoordinatorMock{},
 		&mock.AccountsAdapterStub{UserAccountHandler: &mock.UserAccountMock{}},
-		&mock.PubKeyConverterStub{})
+		&mock.PubKeyConverterStub{},
+		&mock.MarshallerStub{})
 
 	tx1 := &schema.Transaction{
 		Receiver: addresses[0],
@@ -253,12 +271,296 @@ func TestAccountsProcessor_ProcessAccounts_SevenAddresses_ExpectSevenAccounts(t
 	}
 	receipts := []*schema.Receipt{receipt}
 
-	ret := ap.ProcessAccounts(txs, scrs, receipts)
+	ret := ap.ProcessAccounts(txs, scrs, receipts, []*schema.Log{}, []*schema.TokenTransfer{}, 0)
 
 	require.Len(t, ret, 7)
 	checkProcessedAccounts(t, addresses, ret)
 }
 
+func TestAccountsProcessor_ProcessAccounts_NilAccountsAdapter_AccountsFromSnapshot(t *testing.T) {
+	ap, _ := accounts.NewAccountsProcessor(&mock.ShardCoordinatorMock{}, nil, &mock.PubKeyConverterStub{}, &mock.MarshallerStub{})
+
+	ap.SaveAccountsSnapshot(100, []data.UserAccountHandler{
+		&mock.UserAccountMock{Address: []byte("adr1"), CurrentBalance: 10, CurrentNonce: 20},
+		&mock.UserAccountMock{Address: []byte("adr2"), CurrentBalance: 30, CurrentNonce: 40},
+		nil,
+	})
+	ap.SaveAccountsSnapshot(200, []data.UserAccountHandler{
+		&mock.UserAccountMock{Address: []byte("adr3")},
+	})
+
+	tx := &schema.Transaction{
+		Sender:   []byte("erd1adr1"),
+		Receiver: []byte("erd1adr4"),
+	}
+	ret := ap.ProcessAccounts([]*schema.Transaction{tx}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, 100)
+
+	require.Len(t, ret, 2)
+	processedAccounts := make(map[string]*schema.AccountBalanceUpdate)
+	for _, account := range ret {
+		processedAccounts[string(account.Address)] = account
+	}
+	require.Equal(t, big.NewInt(11).Bytes(), processedAccounts["erd1adr1"].Balance)
+	require.Equal(t, int64(21), processedAccounts["erd1adr1"].Nonce)
+	require.Equal(t, big.NewInt(31).Bytes(), processedAccounts["erd1adr2"].Balance)
+	require.Equal(t, int64(41), processedAccounts["erd1adr2"].Nonce)
+
+	// snapshot is consumed after being processed
+	ret = ap.ProcessAccounts([]*schema.Transaction{}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, 100)
+	require.Len(t, ret, 0)
+
+	ret = ap.ProcessAccounts([]*schema.Transaction{}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, 200)
+	require.Len(t, ret, 1)
+	require.Equal(t, []byte("erd1adr3"), ret[0].Address)
+}
+
+func TestAccountsProcessor_ProcessAccounts_PreviousState(t *testing.T) {
+	ap, _ := accounts.NewAccountsProcessor(&mock.ShardCoordinatorMock{}, nil, &mock.PubKeyConverterStub{}, &mock.MarshallerStub{})
+
+	processBlock := func(blockTimestamp uint64, balance int64, nonce uint64) *schema.AccountBalanceUpdate {
+		// mocked account getters increment the current balance and nonce
+		ap.SaveAccountsSnapshot(blockTimestamp, []data.UserAccountHandler{
+			&mock.UserAccountMock{Address: []byte("adr1"), CurrentBalance: balance - 1, CurrentNonce: nonce - 1},
+		})
+		ret := ap.ProcessAccounts([]*schema.Transaction{}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, blockTimestamp)
+		require.Len(t, ret, 1)
+		return ret[0]
+	}
+	requireUnknownPreviousState := func(account *schema.AccountBalanceUpdate) {
+		require.Nil(t, account.PreviousBalance)
+		require.Nil(t, account.Delta)
+		require.Nil(t, account.PreviousNonce)
+	}
+
+	account := processBlock(100, 100, 1)
+	requireUnknownPreviousState(account)
+
+	account = processBlock(200, 30, 2)
+	require.Equal(t, big.NewInt(100).Bytes(), account.PreviousBalance)
+	require.Equal(t, utility.GetSignedBytes(big.NewInt(-70)), account.Delta)
+	require.Equal(t, int64(1), account.PreviousNonce)
+
+	// block 200 is replaced by another one with the same timestamp
+	ap.RevertAccounts(200)
+	account = processBlock(200, 150, 2)
+	require.Equal(t, big.NewInt(100).Bytes(), account.PreviousBalance)
+	require.Equal(t, utility.GetSignedBytes(big.NewInt(50)), account.Delta)
+	require.Equal(t, int64(1), account.PreviousNonce)
+
+	ap.RevertAccounts(200)
+	ap.RevertAccounts(100)
+	account = processBlock(100, 100, 1)
+	requireUnknownPreviousState(account)
+}
+
+func TestAccountsProcessor_ProcessAccounts_AccountDetails(t *testing.T) {
+	ap, _ := accounts.NewAccountsProcessor(&mock.ShardCoordinatorMock{}, nil, &mock.PubKeyConverterStub{}, &mock.MarshallerStub{})
+
+	scAddress := append(make([]byte, 8), []byte("\x05\x00contract address of 22")...)
+	codeHash := testscommon.GenerateRandomFixedBytes(32)
+	rootHash := testscommon.GenerateRandomFixedBytes(32)
+	ap.SaveAccountsSnapshot(100, []data.UserAccountHandler{
+		&mock.UserAccountMock{Address: []byte("user")},
+		&mock.UserAccountMock{
+			Address:      scAddress,
+			UserName:     []byte("contract.elrond"),
+			OwnerAddress: []byte("owner"),
+			CodeHash:     codeHash,
+			RootHash:     rootHash,
+			CodeMetadata: []byte{0x05, 0x00},
+			DevReward:    big.NewInt(1000),
+		},
+	})
+
+	ret := ap.ProcessAccounts([]*schema.Transaction{}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, 100)
+	require.Len(t, ret, 2)
+
+	scAccount, userAccount := ret[0], ret[1]
+	require.Equal(t, []byte("erd1"+string(scAddress)), scAccount.Address)
+	require.Equal(t, []byte("contract.elrond"), scAccount.UserName)
+	require.Equal(t, []byte("erd1owner"), scAccount.OwnerAddress)
+	require.Equal(t, codeHash, scAccount.CodeHash)
+	require.Equal(t, rootHash, scAccount.RootHash)
+	require.Equal(t, []byte{0x05, 0x00}, scAccount.CodeMetadata)
+	require.Equal(t, big.NewInt(1000).Bytes(), scAccount.DeveloperReward)
+	require.True(t, scAccount.IsSmartContract)
+
+	require.Equal(t, []byte("erd1user"), userAccount.Address)
+	require.Nil(t, userAccount.OwnerAddress)
+	require.Nil(t, userAccount.CodeHash)
+	require.Nil(t, userAccount.RootHash)
+	require.Equal(t, []byte{}, userAccount.DeveloperReward)
+	require.False(t, userAccount.IsSmartContract)
+}
+
+func TestAccountsProcessor_SaveAccountsSnapshot_TooManySnapshots_ExpectOldestRemoved(t *testing.T) {
+	ap, _ := accounts.NewAccountsProcessor(&mock.ShardCoordinatorMock{}, nil, &mock.PubKeyConverterStub{}, &mock.MarshallerStub{})
+
+	for timestamp := uint64(1); timestamp <= accounts.MaxAccountsSnapshots+1; timestamp++ {
+		ap.SaveAccountsSnapshot(timestamp, []data.UserAccountHandler{&mock.UserAccountMock{Address: []byte("adr")}})
+	}
+
+	ret := ap.ProcessAccounts([]*schema.Transaction{}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, 1)
+	require.Len(t, ret, 0)
+
+	ret = ap.ProcessAccounts([]*schema.Transaction{}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, 2)
+	require.Len(t, ret, 1)
+
+	ret = ap.ProcessAccounts([]*schema.Transaction{}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, accounts.MaxAccountsSnapshots+1)
+	require.Len(t, ret, 1)
+}
+
+func TestAccountsProcessor_ProcessAccounts_SnapshotSavedBeforeBlock_ExpectSnapshotUsed(t *testing.T) {
+	ap, _ := accounts.NewAccountsProcessor(&mock.ShardCoordinatorMock{}, nil, &mock.PubKeyConverterStub{}, &mock.MarshallerStub{})
+
+	tx := &schema.Transaction{Sender: []byte("erd1adr1"), Receiver: []byte("erd1adr2")}
+
+	// the node saves the accounts of a block before saving the block itself
+	ap.SaveAccountsSnapshot(100, []data.UserAccountHandler{
+		&mock.UserAccountMock{Address: []byte("adr1"), CurrentBalance: 10},
+		&mock.UserAccountMock{Address: []byte("adr2"), CurrentBalance: 20},
+	})
+	ret := ap.ProcessAccounts([]*schema.Transaction{tx}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, 100)
+
+	require.Len(t, ret, 2)
+	require.Equal(t, []byte("erd1adr1"), ret[0].Address)
+	require.Equal(t, big.NewInt(11).Bytes(), ret[0].Balance)
+	require.Equal(t, []byte("erd1adr2"), ret[1].Address)
+	require.Equal(t, big.NewInt(21).Bytes(), ret[1].Balance)
+}
+
+func TestAccountsProcessor_ProcessAccounts_SnapshotSavedAfterBlock_ExpectSnapshotUnusedUntilEvicted(t *testing.T) {
+	ap, _ := accounts.NewAccountsProcessor(&mock.ShardCoordinatorMock{}, nil, &mock.PubKeyConverterStub{}, &mock.MarshallerStub{})
+
+	tx := &schema.Transaction{Sender: []byte("erd1adr1"), Receiver: []byte("erd1adr2")}
+
+	// without an accounts adapter, the accounts of a block processed before its snapshot is saved can not be loaded
+	ret := ap.ProcessAccounts([]*schema.Transaction{tx}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, 100)
+	require.Len(t, ret, 0)
+
+	ap.SaveAccountsSnapshot(100, []data.UserAccountHandler{
+		&mock.UserAccountMock{Address: []byte("adr1"), CurrentBalance: 10},
+		&mock.UserAccountMock{Address: []byte("adr2"), CurrentBalance: 20},
+	})
+
+	// the late snapshot is not used by the next blocks, and it is evicted once enough newer snapshots are saved
+	for timestamp := uint64(101); timestamp <= 100+accounts.MaxAccountsSnapshots; timestamp++ {
+		ap.SaveAccountsSnapshot(timestamp, []data.UserAccountHandler{&mock.UserAccountMock{Address: []byte("adr3")}})
+		ret = ap.ProcessAccounts([]*schema.Transaction{}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, timestamp)
+		require.Len(t, ret, 1)
+		require.Equal(t, []byte("erd1adr3"), ret[0].Address)
+	}
+	for timestamp := uint64(201); timestamp <= 200+accounts.MaxAccountsSnapshots; timestamp++ {
+		ap.SaveAccountsSnapshot(timestamp, []data.UserAccountHandler{&mock.UserAccountMock{Address: []byte("adr3")}})
+	}
+
+	ret = ap.ProcessAccounts([]*schema.Transaction{}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, []*schema.TokenTransfer{}, 100)
+	require.Len(t, ret, 0)
+}
+
+func TestAccountsProcessor_ProcessAccounts_TouchedTokens_ExpectTokenBalanceUpdates(t *testing.T) {
+	marshaller := &mock.MarshallerStub{}
+	ap, _ := accounts.NewAccountsProcessor(&mock.ShardCoordinatorMock{}, nil, &mock.PubKeyConverterStub{}, marshaller)
+
+	nftNonce := big.NewInt(4).Bytes()
+	tokensInDataTrie := map[string]*big.Int{
+		"ELRONDesdtTKN-abcdef":                    big.NewInt(100),
+		"ELRONDesdtNFT-abcdef" + string(nftNonce): big.NewInt(1),
+		"ELRONDesdtSFT-abcdef" + string(nftNonce): big.NewInt(50),
+	}
+	retrieveValueFromDataTrie := func(key []byte) ([]byte, error) {
+		if string(key) == "ELRONDesdtBAD-abcdef" {
+			return nil, errors.New("data trie error")
+		}
+
+		value, found := tokensInDataTrie[string(key)]
+		if !found {
+			return nil, nil
+		}
+		return marshaller.Marshal(&esdt.ESDigitalToken{Value: value})
+	}
+
+	ap.SaveAccountsSnapshot(100, []data.UserAccountHandler{
+		&mock.UserAccountMock{Address: []byte("adr1"), RetrieveValueFromDataTrieTrackerCalled: retrieveValueFromDataTrie},
+		&mock.UserAccountMock{Address: []byte("adr2"), RetrieveValueFromDataTrieTrackerCalled: retrieveValueFromDataTrie},
+		&mock.UserAccountMock{Address: []byte("adr3"), RetrieveValueFromDataTrieTrackerCalled: retrieveValueFromDataTrie},
+		&mock.UserAccountMock{Address: []byte("adr4"), RetrieveValueFromDataTrieTrackerCalled: retrieveValueFromDataTrie},
+	})
+
+	tokenTransfers := []*schema.TokenTransfer{
+		{Identifier: []byte("TKN-abcdef"), Nonce: 0, Sender: []byte("erd1adr1"), Receiver: []byte("erd1adr2")},
+		{Identifier: []byte("NFT-abcdef"), Nonce: 4, Sender: []byte("erd1adr1"), Receiver: []byte("erd1adr2")},
+		{Identifier: []byte("BAD-abcdef"), Nonce: 0, Sender: []byte("erd1adr1"), Receiver: []byte("erd1adr2")},
+	}
+	logs := []*schema.Log{
+		{
+			Events: []*schema.Event{
+				{
+					Address:    []byte("erd1adr3"),
+					Identifier: []byte(core.BuiltInFunctionESDTNFTAddQuantity),
+					Topics:     [][]byte{[]byte("SFT-abcdef"), nftNonce, big.NewInt(10).Bytes()},
+				},
+				{
+					Address:    []byte("erd1adr3"),
+					Identifier: []byte(core.BuiltInFunctionESDTNFTCreate),
+					Topics:     [][]byte{[]byte("NFT-abcdef")},
+				},
+				{
+					Address:    []byte("erd1system"),
+					Identifier: []byte(core.BuiltInFunctionESDTWipe),
+					Topics:     [][]byte{[]byte("FRZ-abcdef"), {}, {}, []byte("adr4")},
+				},
+			},
+		},
+	}
+
+	ret := ap.ProcessAccounts([]*schema.Transaction{}, []*schema.SCResult{}, []*schema.Receipt{}, logs, tokenTransfers, 100)
+	require.Len(t, ret, 4)
+
+	processedAccounts := make(map[string]*schema.AccountBalanceUpdate)
+	for _, account := range ret {
+		processedAccounts[string(account.Address)] = account
+	}
+
+	expectedTokenBalanceUpdates := []*schema.TokenBalanceUpdate{
+		{Identifier: []byte("TKN-abcdef"), Nonce: 0, Balance: big.NewInt(100).Bytes()},
+		{Identifier: []byte("NFT-abcdef"), Nonce: 4, Balance: big.NewInt(1).Bytes()},
+	}
+	require.Equal(t, expectedTokenBalanceUpdates, processedAccounts["erd1adr1"].TokenBalanceUpdates)
+	require.Equal(t, expectedTokenBalanceUpdates, processedAccounts["erd1adr2"].TokenBalanceUpdates)
+	require.Equal(t, []*schema.TokenBalanceUpdate{
+		{Identifier: []byte("SFT-abcdef"), Nonce: 4, Balance: big.NewInt(50).Bytes()},
+	}, processedAccounts["erd1adr3"].TokenBalanceUpdates)
+	require.Equal(t, []*schema.TokenBalanceUpdate{
+		{Identifier: []byte("FRZ-abcdef"), Nonce: 0, Balance: big.NewInt(0).Bytes()},
+	}, processedAccounts["erd1adr4"].TokenBalanceUpdates)
+}
+
+func TestAccountsProcessor_ProcessAccounts_DataTrieNotForwarded_ExpectNoTokenBalanceUpdates(t *testing.T) {
+	ap, _ := accounts.NewAccountsProcessor(&mock.ShardCoordinatorMock{}, nil, &mock.PubKeyConverterStub{}, &mock.MarshallerStub{})
+
+	retrieveValueCalls := 0
+	ap.SaveAccountsSnapshot(100, []data.UserAccountHandler{
+		&mock.UserAccountMock{
+			Address: []byte("adr1"),
+			RetrieveValueFromDataTrieTrackerCalled: func(_ []byte) ([]byte, error) {
+				retrieveValueCalls++
+				return nil, covalent.ErrDataTrieNotForwarded
+			},
+		},
+	})
+
+	tokenTransfers := []*schema.TokenTransfer{
+		{Identifier: []byte("TKN-abcdef"), Nonce: 0, Sender: []byte("erd1adr1")},
+		{Identifier: []byte("NFT-abcdef"), Nonce: 4, Sender: []byte("erd1adr1")},
+	}
+
+	ret := ap.ProcessAccounts([]*schema.Transaction{}, []*schema.SCResult{}, []*schema.Receipt{}, []*schema.Log{}, tokenTransfers, 100)
+	require.Len(t, ret, 1)
+	require.Empty(t, ret[0].TokenBalanceUpdates)
+	require.Equal(t, 1, retrieveValueCalls)
+}
+
 func generateAddresses(n int) [][]byte {
 	addresses := make([][]byte, n)
 
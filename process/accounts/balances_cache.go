@@ -0,0 +1,157 @@
+package accounts
+
+import (
+	"container/list"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go-core/data"
+)
+
+// MaxCachedAccounts defines how many accounts states (last emitted balance and nonce) are kept in order to compute
+// the balance and nonce changes. When exceeded, the least recently updated accounts are evicted
+const MaxCachedAccounts = 100000
+
+// MaxRevertibleBlocks defines for how many of the last processed blocks the previous accounts states are kept,
+// so that the cached states can be restored when these blocks are reverted
+const MaxRevertibleBlocks = 100
+
+type accountState struct {
+	balance *big.Int
+	nonce   uint64
+}
+
+func newAccountState(account data.UserAccountHandler) *accountState {
+	balance := big.NewInt(0)
+	accountBalance := account.GetBalance()
+	if accountBalance != nil {
+		balance.Set(accountBalance)
+	}
+
+	return &accountState{
+		balance: balance,
+		nonce:   account.GetNonce(),
+	}
+}
+
+type cachedAccount struct {
+	address string
+	state   *accountState
+}
+
+// journalEntry holds the state of an account before being updated in a block, a nil state meaning
+// that the account was not cached
+type journalEntry struct {
+	address       string
+	previousState *accountState
+}
+
+// balancesCache is a bounded, least recently updated evicted, cache of the last emitted account states. For each
+// block, identified by its timestamp, the previous states of the updated accounts are journaled, so that they can
+// be restored when the block is reverted
+type balancesCache struct {
+	maxAccounts int
+	maxBlocks   int
+	accounts    map[string]*list.Element
+	lruList     *list.List
+	journals    map[uint64][]*journalEntry
+	mut         sync.Mutex
+}
+
+func newBalancesCache(maxAccounts int, maxBlocks int) *balancesCache {
+	return &balancesCache{
+		maxAccounts: maxAccounts,
+		maxBlocks:   maxBlocks,
+		accounts:    make(map[string]*list.Element),
+		lruList:     list.New(),
+		journals:    make(map[uint64][]*journalEntry),
+	}
+}
+
+// update stores the new state of the account in the block with the given timestamp and returns the previously
+// cached state, or nil if unknown
+func (bc *balancesCache) update(blockTimestamp uint64, address string, state *accountState) *accountState {
+	bc.mut.Lock()
+	defer bc.mut.Unlock()
+
+	previousState := bc.get(address)
+	bc.journals[blockTimestamp] = append(bc.journals[blockTimestamp], &journalEntry{
+		address:       address,
+		previousState: previousState,
+	})
+	bc.put(address, state)
+	bc.removeOldestJournalsIfNeeded()
+
+	return previousState
+}
+
+// revert restores the accounts states as they were before processing the block with the given timestamp
+func (bc *balancesCache) revert(blockTimestamp uint64) {
+	bc.mut.Lock()
+	defer bc.mut.Unlock()
+
+	journal := bc.journals[blockTimestamp]
+	for idx := len(journal) - 1; idx >= 0; idx-- {
+		entry := journal[idx]
+		if entry.previousState == nil {
+			bc.remove(entry.address)
+			continue
+		}
+
+		bc.put(entry.address, entry.previousState)
+	}
+
+	delete(bc.journals, blockTimestamp)
+}
+
+func (bc *balancesCache) get(address string) *accountState {
+	element, found := bc.accounts[address]
+	if !found {
+		return nil
+	}
+
+	return element.Value.(*cachedAccount).state
+}
+
+func (bc *balancesCache) put(address string, state *accountState) {
+	element, found := bc.accounts[address]
+	if found {
+		element.Value.(*cachedAccount).state = state
+		bc.lruList.MoveToFront(element)
+		return
+	}
+
+	bc.accounts[address] = bc.lruList.PushFront(&cachedAccount{address: address, state: state})
+	for bc.lruList.Len() > bc.maxAccounts {
+		bc.remove(bc.lruList.Back().Value.(*cachedAccount).address)
+	}
+}
+
+func (bc *balancesCache) remove(address string) {
+	element, found := bc.accounts[address]
+	if !found {
+		return
+	}
+
+	bc.lruList.Remove(element)
+	delete(bc.accounts, address)
+}
+
+func (bc *balancesCache) removeOldestJournalsIfNeeded() {
+	if len(bc.journals) <= bc.maxBlocks {
+		return
+	}
+
+	timestamps := make([]uint64, 0, len(bc.journals))
+	for timestamp := range bc.journals {
+		timestamps = append(timestamps, timestamp)
+	}
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i] < timestamps[j]
+	})
+
+	for _, timestamp := range timestamps[:len(timestamps)-bc.maxBlocks] {
+		delete(bc.journals, timestamp)
+	}
+}
@@ -15,6 +15,15 @@ import (
 
 const ProposerIndex = int64(0)
 
+// HeaderVersionV1 is the version of the shard and meta chain headers defined in block.Header and block.MetaBlock
+const HeaderVersionV1 = int32(1)
+
+// HeaderVersionV2 is the version of the headers which also hold scheduled miniblocks execution data
+const HeaderVersionV2 = int32(2)
+
+// scheduledRootHashLength is the size of the fixed avro hash in which the scheduled root hash is stored
+const scheduledRootHashLength = 32
+
 type blockProcessor struct {
 	marshaller        marshal.Marshalizer
 	miniBlocksHandler process.MiniBlockHandler
@@ -73,6 +82,17 @@ func (bp *blockProcessor) ProcessBlock(args *indexer.ArgsSaveBlockData) (*schema
 		DeveloperFees:         utility.GetBytes(header.GetDeveloperFees()),
 		EpochStartBlock:       header.IsStartOfEpochBlock(),
 		EpochStartInfo:        getEpochStartInfo(header),
+		ChainID:               header.GetChainID(),
+		HeaderVersion:         getHeaderVersion(header),
+		SoftwareVersion:       header.GetSoftwareVersion(),
+		RandSeed:              header.GetRandSeed(),
+		PrevRandSeed:          header.GetPrevRandSeed(),
+		LeaderSignature:       header.GetLeaderSignature(),
+		Signature:             header.GetSignature(),
+		ReceiptsHash:          header.GetReceiptsHash(),
+		EpochStartMetaHash:    header.GetEpochStartMetaHash(),
+		NotarizedShardHeaders: getNotarizedShardHeaders(header),
+		ScheduledData:         getScheduledData(header),
 	}, nil
 }
 
@@ -99,17 +119,46 @@ func getProposerIndex(signersIndexes []uint64) int64 {
 	return ProposerIndex
 }
 
-func getEpochStartInfo(header data.HeaderHandler) *schema.EpochStartInfo {
-	if header.GetShardID() != core.MetachainShardId {
-		return nil
+func getHeaderVersion(header data.HeaderHandler) int32 {
+	if _, ok := header.(process.ScheduledDataHandler); ok {
+		return HeaderVersionV2
 	}
 
-	metaHeader, ok := header.(*erdBlock.MetaBlock)
+	return HeaderVersionV1
+}
+
+func getScheduledData(header data.HeaderHandler) *schema.ScheduledData {
+	scheduledDataHandler, ok := header.(process.ScheduledDataHandler)
 	if !ok {
 		return nil
 	}
 
-	if !metaHeader.IsStartOfEpochBlock() {
+	var rootHash []byte
+	if len(scheduledDataHandler.GetScheduledRootHash()) == scheduledRootHashLength {
+		rootHash = scheduledDataHandler.GetScheduledRootHash()
+	}
+
+	return &schema.ScheduledData{
+		RootHash:        rootHash,
+		AccumulatedFees: utility.GetBytes(scheduledDataHandler.GetScheduledAccumulatedFees()),
+		DeveloperFees:   utility.GetBytes(scheduledDataHandler.GetScheduledDeveloperFees()),
+		GasProvided:     int64(scheduledDataHandler.GetScheduledGasProvided()),
+		GasPenalized:    int64(scheduledDataHandler.GetScheduledGasPenalized()),
+		GasRefunded:     int64(scheduledDataHandler.GetScheduledGasRefunded()),
+	}
+}
+
+func getEpochStartInfo(header data.HeaderHandler) *schema.EpochStartInfo {
+	if !header.IsStartOfEpochBlock() {
+		return nil
+	}
+
+	if header.GetShardID() != core.MetachainShardId {
+		return getShardEpochStartInfo(header)
+	}
+
+	metaHeader, ok := header.(*erdBlock.MetaBlock)
+	if !ok {
 		return nil
 	}
 
@@ -124,5 +173,78 @@ func getEpochStartInfo(header data.HeaderHandler) *schema.EpochStartInfo {
 		NodePrice:                        utility.GetBytes(economics.NodePrice),
 		PrevEpochStartRound:              int32(economics.PrevEpochStartRound),
 		PrevEpochStartHash:               economics.PrevEpochStartHash,
+		LastFinalizedHeaders:             getLastFinalizedHeaders(metaHeader.EpochStart.LastFinalizedHeaders),
 	}
 }
+
+// getShardEpochStartInfo returns the epoch start info of a shard block, which only references the epoch start
+// metachain block, since economics and last finalized headers are only found in metachain. The economics are null
+func getShardEpochStartInfo(header data.HeaderHandler) *schema.EpochStartInfo {
+	epochStartInfo := schema.NewEpochStartInfo()
+	epochStartInfo.EpochStartMetaHash = header.GetEpochStartMetaHash()
+
+	return epochStartInfo
+}
+
+func getLastFinalizedHeaders(lastFinalizedHeaders []erdBlock.EpochStartShardData) []*schema.EpochStartShardData {
+	ret := make([]*schema.EpochStartShardData, 0, len(lastFinalizedHeaders))
+	for _, shardData := range lastFinalizedHeaders {
+		ret = append(ret, &schema.EpochStartShardData{
+			ShardID:                 int32(shardData.ShardID),
+			Epoch:                   int32(shardData.Epoch),
+			Round:                   int64(shardData.Round),
+			Nonce:                   int64(shardData.Nonce),
+			HeaderHash:              shardData.HeaderHash,
+			RootHash:                shardData.RootHash,
+			FirstPendingMetaBlock:   shardData.FirstPendingMetaBlock,
+			LastFinishedMetaBlock:   shardData.LastFinishedMetaBlock,
+			PendingMiniBlockHeaders: getMiniBlockHeaders(shardData.PendingMiniBlockHeaders),
+		})
+	}
+
+	return ret
+}
+
+func getNotarizedShardHeaders(header data.HeaderHandler) []*schema.NotarizedShardHeader {
+	metaHeader, ok := header.(*erdBlock.MetaBlock)
+	if !ok {
+		return nil
+	}
+
+	notarizedShardHeaders := make([]*schema.NotarizedShardHeader, 0, len(metaHeader.ShardInfo))
+	for _, shardData := range metaHeader.ShardInfo {
+		notarizedShardHeaders = append(notarizedShardHeaders, &schema.NotarizedShardHeader{
+			ShardID:          int32(shardData.ShardID),
+			Nonce:            int64(shardData.Nonce),
+			Round:            int64(shardData.Round),
+			HeaderHash:       shardData.HeaderHash,
+			PrevHash:         shardData.PrevHash,
+			TxCount:          int32(shardData.TxCount),
+			AccumulatedFees:  utility.GetBytes(shardData.AccumulatedFees),
+			DeveloperFees:    utility.GetBytes(shardData.DeveloperFees),
+			MiniBlockHeaders: getMiniBlockHeaders(shardData.ShardMiniBlockHeaders),
+		})
+	}
+
+	return notarizedShardHeaders
+}
+
+func getMiniBlockHeaders(miniBlockHeaders []erdBlock.MiniBlockHeader) []*schema.MiniBlockHeader {
+	ret := make([]*schema.MiniBlockHeader, 0, len(miniBlockHeaders))
+	for _, mbHeader := range miniBlockHeaders {
+		ret = append(ret, &schema.MiniBlockHeader{
+			Hash:            mbHeader.Hash,
+			SenderShardID:   int32(mbHeader.SenderShardID),
+			ReceiverShardID: int32(mbHeader.ReceiverShardID),
+			TxCount:         int32(mbHeader.TxCount),
+			Type:            int32(mbHeader.Type),
+		})
+	}
+
+	return ret
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (bp *blockProcessor) IsInterfaceNil() bool {
+	return bp == nil
+}
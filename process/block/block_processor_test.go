@@ -156,8 +156,23 @@ func TestBlockProcessor_ProcessBlock(t *testing.T) {
 	require.Equal(t, int32(args.Header.GetTxCount()), ret.TxCount)
 	require.Equal(t, args.Header.GetAccumulatedFees().Bytes(), ret.AccumulatedFees)
 	require.Equal(t, args.Header.GetDeveloperFees().Bytes(), ret.DeveloperFees)
-
-	require.Equal(t, ret.EpochStartInfo, (*schema.EpochStartInfo)(nil))
+	requireHeaderFieldsEqual(t, args.Header, ret)
+	require.Equal(t, block.HeaderVersionV1, ret.HeaderVersion)
+	require.Nil(t, ret.ScheduledData)
+	require.Equal(t, []byte("epoch start meta hash"), ret.EpochStartMetaHash)
+
+	require.Nil(t, ret.NotarizedShardHeaders)
+
+	expectedEpochStartInfo := schema.NewEpochStartInfo()
+	expectedEpochStartInfo.EpochStartMetaHash = []byte("epoch start meta hash")
+	require.Equal(t, expectedEpochStartInfo, ret.EpochStartInfo)
+	require.Nil(t, ret.EpochStartInfo.TotalSupply)
+	require.Nil(t, ret.EpochStartInfo.TotalToDistribute)
+	require.Nil(t, ret.EpochStartInfo.TotalNewlyMinted)
+	require.Nil(t, ret.EpochStartInfo.RewardsPerBlock)
+	require.Nil(t, ret.EpochStartInfo.RewardsForProtocolSustainability)
+	require.Nil(t, ret.EpochStartInfo.NodePrice)
+	require.Nil(t, ret.EpochStartInfo.PrevEpochStartRound)
 }
 
 func TestBlockProcessor_ProcessMetaBlock(t *testing.T) {
@@ -184,6 +199,25 @@ func TestBlockProcessor_ProcessMetaBlock(t *testing.T) {
 	require.Equal(t, args.Header.GetAccumulatedFees().Bytes(), ret.AccumulatedFees)
 	require.Equal(t, args.Header.GetDeveloperFees().Bytes(), ret.DeveloperFees)
 
+	requireHeaderFieldsEqual(t, args.Header, ret)
+	require.Equal(t, block.HeaderVersionV1, ret.HeaderVersion)
+	require.Nil(t, ret.ScheduledData)
+	require.Nil(t, ret.EpochStartMetaHash)
+
+	shardData := args.Header.(*erdBlock.MetaBlock).ShardInfo[0]
+	require.Len(t, ret.NotarizedShardHeaders, 1)
+	require.Equal(t, int32(shardData.ShardID), ret.NotarizedShardHeaders[0].ShardID)
+	require.Equal(t, int64(shardData.Nonce), ret.NotarizedShardHeaders[0].Nonce)
+	require.Equal(t, int64(shardData.Round), ret.NotarizedShardHeaders[0].Round)
+	require.Equal(t, shardData.HeaderHash, ret.NotarizedShardHeaders[0].HeaderHash)
+	require.Equal(t, shardData.PrevHash, ret.NotarizedShardHeaders[0].PrevHash)
+	require.Equal(t, int32(shardData.TxCount), ret.NotarizedShardHeaders[0].TxCount)
+	require.Equal(t, shardData.AccumulatedFees.Bytes(), ret.NotarizedShardHeaders[0].AccumulatedFees)
+	require.Equal(t, shardData.DeveloperFees.Bytes(), ret.NotarizedShardHeaders[0].DeveloperFees)
+	require.Equal(t, []*schema.MiniBlockHeader{
+		{Hash: []byte("mb hash"), SenderShardID: 1, ReceiverShardID: 2, TxCount: 3, Type: int32(erdBlock.SmartContractResultBlock)},
+	}, ret.NotarizedShardHeaders[0].MiniBlockHeaders)
+
 	metaBlockEconomics := args.Header.(*erdBlock.MetaBlock).GetEpochStart().Economics
 
 	require.Equal(t, metaBlockEconomics.TotalSupply.Bytes(), ret.EpochStartInfo.TotalSupply)
@@ -194,6 +228,24 @@ func TestBlockProcessor_ProcessMetaBlock(t *testing.T) {
 	require.Equal(t, metaBlockEconomics.NodePrice.Bytes(), ret.EpochStartInfo.NodePrice)
 	require.Equal(t, int32(metaBlockEconomics.PrevEpochStartRound), ret.EpochStartInfo.PrevEpochStartRound)
 	require.Equal(t, metaBlockEconomics.PrevEpochStartHash, ret.EpochStartInfo.PrevEpochStartHash)
+	require.Nil(t, ret.EpochStartInfo.EpochStartMetaHash)
+
+	lastFinalizedHeader := args.Header.(*erdBlock.MetaBlock).GetEpochStart().LastFinalizedHeaders[0]
+	require.Equal(t, []*schema.EpochStartShardData{
+		{
+			ShardID:               int32(lastFinalizedHeader.ShardID),
+			Epoch:                 int32(lastFinalizedHeader.Epoch),
+			Round:                 int64(lastFinalizedHeader.Round),
+			Nonce:                 int64(lastFinalizedHeader.Nonce),
+			HeaderHash:            lastFinalizedHeader.HeaderHash,
+			RootHash:              lastFinalizedHeader.RootHash,
+			FirstPendingMetaBlock: lastFinalizedHeader.FirstPendingMetaBlock,
+			LastFinishedMetaBlock: lastFinalizedHeader.LastFinishedMetaBlock,
+			PendingMiniBlockHeaders: []*schema.MiniBlockHeader{
+				{Hash: []byte("pending mb hash"), SenderShardID: 0, ReceiverShardID: 1, TxCount: 4, Type: int32(erdBlock.TxBlock)},
+			},
+		},
+	}, ret.EpochStartInfo.LastFinalizedHeaders)
 }
 
 func TestBlockProcessor_ProcessMetaBlock_NotStartOfEpochBlock_ExpectNilEpochStartInfo(t *testing.T) {
@@ -209,6 +261,91 @@ func TestBlockProcessor_ProcessMetaBlock_NotStartOfEpochBlock_ExpectNilEpochStar
 	require.Equal(t, (*schema.EpochStartInfo)(nil), ret.EpochStartInfo)
 }
 
+func TestBlockProcessor_ProcessBlock_NotStartOfEpochBlock_ExpectNilEpochStartInfo(t *testing.T) {
+	bp, _ := block.NewBlockProcessor(&mock.MarshallerStub{}, &mock.MiniBlockHandlerStub{})
+
+	header := getInitialisedBlockHeader()
+	header.EpochStartMetaHash = nil
+
+	ret, _ := bp.ProcessBlock(&indexer.ArgsSaveBlockData{
+		Header: header,
+		Body:   &erdBlock.Body{}})
+
+	require.Equal(t, (*schema.EpochStartInfo)(nil), ret.EpochStartInfo)
+}
+
+func TestBlockProcessor_ProcessBlock_HeaderV2_ExpectScheduledData(t *testing.T) {
+	t.Parallel()
+
+	bp, _ := block.NewBlockProcessor(&mock.MarshallerStub{}, &mock.MiniBlockHandlerStub{})
+
+	header := &mock.HeaderV2Mock{
+		Header:                   getInitialisedBlockHeader(),
+		ScheduledRootHash:        []byte("scheduled root hash of 32 bytes."),
+		ScheduledAccumulatedFees: big.NewInt(22),
+		ScheduledDeveloperFees:   big.NewInt(23),
+		ScheduledGasProvided:     24,
+		ScheduledGasPenalized:    25,
+		ScheduledGasRefunded:     26,
+	}
+	ret, err := bp.ProcessBlock(&indexer.ArgsSaveBlockData{
+		HeaderHash: []byte("header hash"),
+		Header:     header,
+		Body:       &erdBlock.Body{}})
+	require.Nil(t, err)
+
+	requireHeaderFieldsEqual(t, header, ret)
+	require.Equal(t, int64(header.GetNonce()), ret.Nonce)
+	require.Equal(t, header.GetRootHash(), ret.StateRootHash)
+	require.Equal(t, block.HeaderVersionV2, ret.HeaderVersion)
+	require.Equal(t, &schema.ScheduledData{
+		RootHash:        []byte("scheduled root hash of 32 bytes."),
+		AccumulatedFees: big.NewInt(22).Bytes(),
+		DeveloperFees:   big.NewInt(23).Bytes(),
+		GasProvided:     24,
+		GasPenalized:    25,
+		GasRefunded:     26,
+	}, ret.ScheduledData)
+}
+
+func TestBlockProcessor_ProcessBlock_HeaderV2EmptyScheduledRootHash_ExpectEncodableBlock(t *testing.T) {
+	t.Parallel()
+
+	bp, _ := block.NewBlockProcessor(&mock.MarshallerStub{}, &mock.MiniBlockHandlerStub{})
+
+	hash := []byte("01234567890123456789012345678901")
+	header := &mock.HeaderV2Mock{
+		Header: &erdBlock.Header{
+			PrevHash:        hash,
+			RootHash:        hash,
+			AccumulatedFees: big.NewInt(0),
+			DeveloperFees:   big.NewInt(0),
+		},
+		ScheduledRootHash:        []byte{},
+		ScheduledAccumulatedFees: big.NewInt(0),
+		ScheduledDeveloperFees:   big.NewInt(0),
+	}
+	ret, err := bp.ProcessBlock(&indexer.ArgsSaveBlockData{
+		HeaderHash: hash,
+		Header:     header,
+		Body:       &erdBlock.Body{}})
+	require.Nil(t, err)
+	require.Nil(t, ret.ScheduledData.RootHash)
+
+	_, err = utility.Encode(ret)
+	require.Nil(t, err)
+}
+
+func requireHeaderFieldsEqual(t *testing.T, header data.HeaderHandler, processedBlock *schema.Block) {
+	require.Equal(t, header.GetChainID(), processedBlock.ChainID)
+	require.Equal(t, header.GetSoftwareVersion(), processedBlock.SoftwareVersion)
+	require.Equal(t, header.GetRandSeed(), processedBlock.RandSeed)
+	require.Equal(t, header.GetPrevRandSeed(), processedBlock.PrevRandSeed)
+	require.Equal(t, header.GetLeaderSignature(), processedBlock.LeaderSignature)
+	require.Equal(t, header.GetSignature(), processedBlock.Signature)
+	require.Equal(t, header.GetReceiptsHash(), processedBlock.ReceiptsHash)
+}
+
 func getInitializedArgs(metaBlock bool) *indexer.ArgsSaveBlockData {
 	var header data.HeaderHandler
 
@@ -241,7 +378,21 @@ func getInitializedMetaBlockHeader() *erdBlock.MetaBlock {
 		RandSeed:        []byte("meta rand seed"),
 		RootHash:        []byte("meta root hash"),
 		EpochStart: erdBlock.EpochStart{
-			LastFinalizedHeaders: []erdBlock.EpochStartShardData{{}},
+			LastFinalizedHeaders: []erdBlock.EpochStartShardData{
+				{
+					ShardID:               0,
+					Epoch:                 19,
+					Round:                 20,
+					Nonce:                 21,
+					HeaderHash:            []byte("last finalized header hash"),
+					RootHash:              []byte("last finalized root hash"),
+					FirstPendingMetaBlock: []byte("first pending meta block"),
+					LastFinishedMetaBlock: []byte("last finished meta block"),
+					PendingMiniBlockHeaders: []erdBlock.MiniBlockHeader{
+						{Hash: []byte("pending mb hash"), SenderShardID: 0, ReceiverShardID: 1, TxCount: 4, Type: erdBlock.TxBlock},
+					},
+				},
+			},
 			Economics: erdBlock.Economics{
 				TotalSupply:                      big.NewInt(5),
 				TotalToDistribute:                big.NewInt(6),
@@ -253,6 +404,21 @@ func getInitializedMetaBlockHeader() *erdBlock.MetaBlock {
 				PrevEpochStartHash:               []byte("meta prev epoch hash"),
 			},
 		},
+		ShardInfo: []erdBlock.ShardData{
+			{
+				ShardID:         1,
+				Nonce:           14,
+				Round:           15,
+				HeaderHash:      []byte("shard header hash"),
+				PrevHash:        []byte("shard prev hash"),
+				TxCount:         16,
+				AccumulatedFees: big.NewInt(17),
+				DeveloperFees:   big.NewInt(18),
+				ShardMiniBlockHeaders: []erdBlock.MiniBlockHeader{
+					{Hash: []byte("mb hash"), SenderShardID: 1, ReceiverShardID: 2, TxCount: 3, Type: erdBlock.SmartContractResultBlock},
+				},
+			},
+		},
 		ChainID:         []byte("meta chain id"),
 		AccumulatedFees: big.NewInt(11),
 		DeveloperFees:   big.NewInt(12),
@@ -44,9 +44,9 @@ func (mbp *miniBlocksProcessor) ProcessMiniBlocks(header data.HeaderHandler, bod
 	erdMiniBlocks := erdBody.GetMiniBlocks()
 	miniBlocks := make([]*schema.MiniBlock, 0, len(erdMiniBlocks))
 
-	for _, mb := range erdMiniBlocks {
+	for idx, mb := range erdMiniBlocks {
 
-		miniBlock, err := mbp.processMiniBlock(mb, header)
+		miniBlock, err := mbp.processMiniBlock(mb, idx, header)
 		if err != nil {
 			log.Warn("miniBlocksProcessor.ProcessMiniBlocks cannot process miniBlock", "error", err)
 			continue
@@ -58,7 +58,7 @@ func (mbp *miniBlocksProcessor) ProcessMiniBlocks(header data.HeaderHandler, bod
 	return miniBlocks, nil
 }
 
-func (mbp *miniBlocksProcessor) processMiniBlock(miniBlock *block.MiniBlock, header data.HeaderHandler) (*schema.MiniBlock, error) {
+func (mbp *miniBlocksProcessor) processMiniBlock(miniBlock *block.MiniBlock, index int, header data.HeaderHandler) (*schema.MiniBlock, error) {
 	miniBlockHash, err := core.CalculateHash(mbp.marshaller, mbp.hasher, miniBlock)
 	if err != nil {
 		return nil, err
@@ -66,6 +66,7 @@ func (mbp *miniBlocksProcessor) processMiniBlock(miniBlock *block.MiniBlock, hea
 
 	return &schema.MiniBlock{
 		Hash:            miniBlockHash,
+		Index:           int32(index),
 		TxHashes:        miniBlock.GetTxHashes(),
 		SenderShardID:   int32(miniBlock.SenderShardID),
 		ReceiverShardID: int32(miniBlock.ReceiverShardID),
@@ -73,6 +73,7 @@ func TestMiniBlocksProcessor_ProcessMiniBlocks(t *testing.T) {
 	require.Equal(t, int32(1), ret[0].ReceiverShardID)
 	require.Equal(t, int32(2), ret[0].SenderShardID)
 	require.Equal(t, int32(3), ret[0].Type)
+	require.Equal(t, int32(0), ret[0].Index)
 
 	require.Equal(t, []byte("ok"), ret[1].Hash)
 	require.Equal(t, [][]byte{[]byte("y"), []byte("z")}, ret[1].TxHashes)
@@ -80,6 +81,7 @@ func TestMiniBlocksProcessor_ProcessMiniBlocks(t *testing.T) {
 	require.Equal(t, int32(4), ret[1].ReceiverShardID)
 	require.Equal(t, int32(5), ret[1].SenderShardID)
 	require.Equal(t, int32(6), ret[1].Type)
+	require.Equal(t, int32(1), ret[1].Index)
 }
 
 func TestMiniBlocksProcessor_ProcessMiniBlocks_InvalidMarshaller_ExpectZeroMBProcessed(t *testing.T) {
@@ -0,0 +1,141 @@
+package calltree
+
+import (
+	"github.com/ElrondNetwork/covalent-indexer-go/schema"
+	"github.com/ElrondNetwork/elrond-go-core/data/vm"
+)
+
+// UnknownDepth is the depth of the call tree nodes whose chain of parents up to the original transaction is not
+// fully available in the block, some of their ancestors having been executed in previous blocks
+const UnknownDepth = int32(-1)
+
+type callTreeProcessor struct{}
+
+// NewCallTreeProcessor creates a new instance of call tree processor
+func NewCallTreeProcessor() *callTreeProcessor {
+	return &callTreeProcessor{}
+}
+
+// ProcessCallTrees builds, for each original transaction, the tree of smart contract results produced in the block,
+// linking each result to its parent through the previous transaction hash and pairing asynchronous calls with their
+// callbacks. Trees are marked as partial when some of their ancestors were executed in previous blocks or when some
+// asynchronous calls have no callback in the block, their execution being left for later blocks
+func (ctp *callTreeProcessor) ProcessCallTrees(scrs []*schema.SCResult) []*schema.CallTree {
+	scrsByHash := make(map[string]*schema.SCResult, len(scrs))
+	for _, scr := range scrs {
+		scrsByHash[string(scr.Hash)] = scr
+	}
+
+	trees := make([]*schema.CallTree, 0)
+	treesByOriginalTx := make(map[string]*schema.CallTree)
+	nodesByHash := make(map[string]*schema.CallTreeNode, len(scrs))
+	depths := make(map[string]int32, len(scrs))
+
+	for _, scr := range scrs {
+		originalTxHash := getOriginalTxHash(scr)
+		tree, found := treesByOriginalTx[string(originalTxHash)]
+		if !found {
+			tree = &schema.CallTree{
+				OriginalTxHash: originalTxHash,
+				Nodes:          make([]*schema.CallTreeNode, 0),
+			}
+			treesByOriginalTx[string(originalTxHash)] = tree
+			trees = append(trees, tree)
+		}
+
+		node := &schema.CallTreeNode{
+			Hash:       scr.Hash,
+			ParentHash: scr.PrevTxHash,
+			Depth:      computeDepth(scr, scrsByHash, depths),
+			CallType:   scr.CallType,
+		}
+		tree.Nodes = append(tree.Nodes, node)
+		nodesByHash[string(scr.Hash)] = node
+
+		if node.Depth == UnknownDepth {
+			tree.IsPartial = true
+		}
+	}
+
+	pairCallbacks(trees, nodesByHash)
+
+	return trees
+}
+
+// pairCallbacks links each asynchronous call to the callback generated by its execution and marks the trees
+// having asynchronous calls whose callbacks were not yet generated as partial
+func pairCallbacks(trees []*schema.CallTree, nodesByHash map[string]*schema.CallTreeNode) {
+	for _, tree := range trees {
+		for _, node := range tree.Nodes {
+			if node.CallType != int32(vm.AsynchronousCallBack) {
+				continue
+			}
+
+			asyncCall, found := nodesByHash[string(node.ParentHash)]
+			if found && asyncCall.CallType == int32(vm.AsynchronousCall) {
+				asyncCall.CallbackHash = node.Hash
+			}
+		}
+	}
+
+	for _, tree := range trees {
+		for _, node := range tree.Nodes {
+			if node.CallType == int32(vm.AsynchronousCall) && node.CallbackHash == nil {
+				tree.IsPartial = true
+			}
+		}
+	}
+}
+
+// computeDepth computes the depth of a smart contract result in its call tree, the results generated directly by
+// the original transaction having depth 1
+func computeDepth(scr *schema.SCResult, scrsByHash map[string]*schema.SCResult, depths map[string]int32) int32 {
+	chain := make([]*schema.SCResult, 0)
+	visited := make(map[string]struct{})
+	depth := UnknownDepth
+
+	for current := scr; ; {
+		knownDepth, found := depths[string(current.Hash)]
+		if found {
+			depth = knownDepth
+			break
+		}
+
+		chain = append(chain, current)
+		visited[string(current.Hash)] = struct{}{}
+
+		if string(current.PrevTxHash) == string(getOriginalTxHash(current)) {
+			depth = 0
+			break
+		}
+
+		parent, parentInBlock := scrsByHash[string(current.PrevTxHash)]
+		_, isCycle := visited[string(current.PrevTxHash)]
+		if !parentInBlock || isCycle {
+			break
+		}
+		current = parent
+	}
+
+	for idx := len(chain) - 1; idx >= 0; idx-- {
+		if depth != UnknownDepth {
+			depth++
+		}
+		depths[string(chain[idx].Hash)] = depth
+	}
+
+	return depths[string(scr.Hash)]
+}
+
+func getOriginalTxHash(scr *schema.SCResult) []byte {
+	if len(scr.OriginalTxHash) == 0 {
+		return scr.PrevTxHash
+	}
+
+	return scr.OriginalTxHash
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ctp *callTreeProcessor) IsInterfaceNil() bool {
+	return ctp == nil
+}
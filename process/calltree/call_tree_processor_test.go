@@ -0,0 +1,105 @@
+package calltree_test
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/covalent-indexer-go/process/calltree"
+	"github.com/ElrondNetwork/covalent-indexer-go/schema"
+	"github.com/ElrondNetwork/elrond-go-core/data/vm"
+	"github.com/stretchr/testify/require"
+)
+
+func newSCR(hash string, prevTxHash string, originalTxHash string, callType vm.CallType) *schema.SCResult {
+	return &schema.SCResult{
+		Hash:           []byte(hash),
+		PrevTxHash:     []byte(prevTxHash),
+		OriginalTxHash: []byte(originalTxHash),
+		CallType:       int32(callType),
+	}
+}
+
+func TestCallTreeProcessor_ProcessCallTrees_NoSCRs_ExpectNoTrees(t *testing.T) {
+	t.Parallel()
+
+	ctp := calltree.NewCallTreeProcessor()
+
+	ret := ctp.ProcessCallTrees(nil)
+	require.Len(t, ret, 0)
+}
+
+func TestCallTreeProcessor_ProcessCallTrees_CompleteTreeWithCallback(t *testing.T) {
+	t.Parallel()
+
+	ctp := calltree.NewCallTreeProcessor()
+
+	// callback is listed before its async call, to check that order does not matter
+	scrs := []*schema.SCResult{
+		newSCR("callback", "async call", "tx", vm.AsynchronousCallBack),
+		newSCR("async call", "tx", "tx", vm.AsynchronousCall),
+		newSCR("direct call", "tx", "tx", vm.DirectCall),
+		newSCR("refund", "callback", "tx", vm.DirectCall),
+	}
+
+	ret := ctp.ProcessCallTrees(scrs)
+	require.Len(t, ret, 1)
+	require.Equal(t, &schema.CallTree{
+		OriginalTxHash: []byte("tx"),
+		IsPartial:      false,
+		Nodes: []*schema.CallTreeNode{
+			{Hash: []byte("callback"), ParentHash: []byte("async call"), Depth: 2, CallType: int32(vm.AsynchronousCallBack)},
+			{Hash: []byte("async call"), ParentHash: []byte("tx"), Depth: 1, CallType: int32(vm.AsynchronousCall), CallbackHash: []byte("callback")},
+			{Hash: []byte("direct call"), ParentHash: []byte("tx"), Depth: 1, CallType: int32(vm.DirectCall)},
+			{Hash: []byte("refund"), ParentHash: []byte("callback"), Depth: 3, CallType: int32(vm.DirectCall)},
+		},
+	}, ret[0])
+}
+
+func TestCallTreeProcessor_ProcessCallTrees_PartialTrees(t *testing.T) {
+	t.Parallel()
+
+	ctp := calltree.NewCallTreeProcessor()
+
+	scrs := []*schema.SCResult{
+		// async call whose callback will be generated in a later block
+		newSCR("async call", "tx1", "tx1", vm.AsynchronousCall),
+		// callback of an async call executed in a previous block
+		newSCR("callback", "previous async call", "tx2", vm.AsynchronousCallBack),
+		newSCR("refund", "callback", "tx2", vm.DirectCall),
+		// complete tree
+		newSCR("direct call", "tx3", "tx3", vm.DirectCall),
+	}
+
+	ret := ctp.ProcessCallTrees(scrs)
+	require.Len(t, ret, 3)
+
+	require.Equal(t, []byte("tx1"), ret[0].OriginalTxHash)
+	require.True(t, ret[0].IsPartial)
+	require.Nil(t, ret[0].Nodes[0].CallbackHash)
+	require.Equal(t, int32(1), ret[0].Nodes[0].Depth)
+
+	require.Equal(t, []byte("tx2"), ret[1].OriginalTxHash)
+	require.True(t, ret[1].IsPartial)
+	require.Len(t, ret[1].Nodes, 2)
+	require.Equal(t, calltree.UnknownDepth, ret[1].Nodes[0].Depth)
+	require.Equal(t, calltree.UnknownDepth, ret[1].Nodes[1].Depth)
+
+	require.Equal(t, []byte("tx3"), ret[2].OriginalTxHash)
+	require.False(t, ret[2].IsPartial)
+}
+
+func TestCallTreeProcessor_ProcessCallTrees_Cycle_ExpectUnknownDepth(t *testing.T) {
+	t.Parallel()
+
+	ctp := calltree.NewCallTreeProcessor()
+
+	scrs := []*schema.SCResult{
+		newSCR("scr1", "scr2", "tx", vm.DirectCall),
+		newSCR("scr2", "scr1", "tx", vm.DirectCall),
+	}
+
+	ret := ctp.ProcessCallTrees(scrs)
+	require.Len(t, ret, 1)
+	require.True(t, ret[0].IsPartial)
+	require.Equal(t, calltree.UnknownDepth, ret[0].Nodes[0].Depth)
+	require.Equal(t, calltree.UnknownDepth, ret[0].Nodes[1].Depth)
+}
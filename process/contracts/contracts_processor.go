@@ -0,0 +1,301 @@
+package contracts
+
+import (
+	"encoding/binary"
+
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/covalent-indexer-go/process"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/utility"
+	"github.com/ElrondNetwork/covalent-indexer-go/schema"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/hashing"
+	"github.com/ElrondNetwork/elrond-go-core/hashing/keccak"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/ElrondNetwork/elrond-vm-common/parsers"
+)
+
+const (
+	// ContractDeploy defines the type of the event emitted when a smart contract is deployed
+	ContractDeploy = "deploy"
+	// ContractUpgrade defines the type of the event emitted when the code of a smart contract is upgraded
+	ContractUpgrade = "upgrade"
+	// ContractChangeOwner defines the type of the event emitted when the owner of a smart contract is changed
+	ContractChangeOwner = "changeOwner"
+)
+
+const (
+	scDeployIdentifier  = "SCDeploy"
+	scUpgradeIdentifier = "SCUpgrade"
+	upgradeFunction     = "upgradeContract"
+)
+
+// eventTypes maps the identifiers of the log events emitted by the node to contract event types
+var eventTypes = map[string]string{
+	scDeployIdentifier:                     ContractDeploy,
+	scUpgradeIdentifier:                    ContractUpgrade,
+	core.BuiltInFunctionChangeOwnerAddress: ContractChangeOwner,
+}
+
+type deployArgsParser interface {
+	ParseData(data string) (*parsers.DeployArgs, error)
+}
+
+type contractsProcessor struct {
+	shardCoordinator process.ShardCoordinator
+	pubKeyConverter  core.PubkeyConverter
+	hasher           hashing.Hasher
+	addressHasher    hashing.Hasher
+	callArgsParser   vmcommon.CallArgsParser
+	deployArgsParser deployArgsParser
+}
+
+// NewContractsProcessor creates a new instance of contracts processor
+func NewContractsProcessor(
+	shardCoordinator process.ShardCoordinator,
+	pubKeyConverter core.PubkeyConverter,
+	hasher hashing.Hasher,
+) (*contractsProcessor, error) {
+	if check.IfNil(shardCoordinator) {
+		return nil, covalent.ErrNilShardCoordinator
+	}
+	if check.IfNil(pubKeyConverter) {
+		return nil, covalent.ErrNilPubKeyConverter
+	}
+	if check.IfNil(hasher) {
+		return nil, covalent.ErrNilHasher
+	}
+
+	return &contractsProcessor{
+		shardCoordinator: shardCoordinator,
+		pubKeyConverter:  pubKeyConverter,
+		hasher:           hasher,
+		addressHasher:    keccak.NewKeccak(),
+		callArgsParser:   parsers.NewCallArgsParser(),
+		deployArgsParser: parsers.NewDeployArgsParser(),
+	}, nil
+}
+
+// ProcessContractEvents detects smart contract deploys, upgrades and owner changes from the SCDeploy, SCUpgrade
+// and ChangeOwnerAddress log events, as well as from the call data of transactions and smart contract results.
+// Events found in logs are enriched with the code hash and code metadata found in call data, while call data
+// operations without a corresponding log event are only kept if their originating transaction did not fail.
+// Call data is only used by the shard in which the operation is executed, since cross shard transactions and
+// smart contract results are also found in the pool of their source shard
+func (cp *contractsProcessor) ProcessContractEvents(
+	txs map[string]data.TransactionHandler,
+	scrs map[string]data.TransactionHandler,
+	logs []*data.LogData,
+) []*schema.ContractEvent {
+	events := utility.NewEventsMerger()
+
+	for _, logData := range logs {
+		if logData == nil || check.IfNil(logData.LogHandler) {
+			continue
+		}
+
+		txHash := utility.GetOriginalTxHash(scrs[logData.TxHash], logData.TxHash)
+		for _, event := range logData.LogHandler.GetLogEvents() {
+			cp.processLogEvent(event, txHash, events)
+		}
+	}
+
+	for _, hash := range utility.SortedHashes(txs) {
+		cp.processCallData(txs[hash], []byte(hash), events)
+	}
+	for _, hash := range utility.SortedHashes(scrs) {
+		cp.processCallData(scrs[hash], []byte(utility.GetOriginalTxHash(scrs[hash], hash)), events)
+	}
+
+	contractEvents := make([]*schema.ContractEvent, 0, len(events.Events()))
+	for _, event := range events.Events() {
+		contractEvents = append(contractEvents, event.(*schema.ContractEvent))
+	}
+
+	return contractEvents
+}
+
+func (cp *contractsProcessor) processLogEvent(event data.EventHandler, txHash string, events *utility.EventsMerger) {
+	if check.IfNil(event) {
+		return
+	}
+
+	identifier := string(event.GetIdentifier())
+	if identifier == utility.SignalErrorOperation {
+		events.MarkFailed(txHash)
+		return
+	}
+
+	eventType, found := eventTypes[identifier]
+	if !found {
+		return
+	}
+
+	topics := event.GetTopics()
+	contractAddress := event.GetAddress()
+	var owner []byte
+
+	switch eventType {
+	case ContractChangeOwner:
+		owner = utility.GetTopic(topics, 0)
+	default:
+		if contract := utility.GetTopic(topics, 0); len(contract) != 0 {
+			contractAddress = contract
+		}
+		owner = utility.GetTopic(topics, 1)
+	}
+	if len(contractAddress) == 0 || len(owner) == 0 {
+		return
+	}
+
+	contractEvent := &schema.ContractEvent{
+		TxHash:          []byte(txHash),
+		Type:            eventType,
+		ContractAddress: utility.EncodePubKey(cp.pubKeyConverter, contractAddress),
+		Owner:           utility.EncodePubKey(cp.pubKeyConverter, owner),
+	}
+	events.AddFromLog(txHash, eventType, eventKey(contractEvent), contractEvent)
+}
+
+func (cp *contractsProcessor) processCallData(tx data.TransactionHandler, txHash []byte, events *utility.EventsMerger) {
+	if check.IfNil(tx) || len(tx.GetData()) == 0 || !cp.isExecutedInSelfShard(tx) {
+		return
+	}
+
+	contractEvent := cp.contractEventFromCallData(tx)
+	if contractEvent == nil {
+		return
+	}
+
+	contractEvent.TxHash = txHash
+	existingEvent := events.AddFromCallData(string(txHash), contractEvent.Type, eventKey(contractEvent), contractEvent)
+	if fromLog, ok := existingEvent.(*schema.ContractEvent); ok {
+		enrichEvent(fromLog, contractEvent)
+	}
+}
+
+// isExecutedInSelfShard checks if the call data of the transaction is executed in the current shard: deploys are
+// executed in the shard of the deployer, while all other operations are executed in the shard of the contract
+func (cp *contractsProcessor) isExecutedInSelfShard(tx data.TransactionHandler) bool {
+	executingAddress := tx.GetRcvAddr()
+	if isDeploy(tx) {
+		executingAddress = tx.GetSndAddr()
+	}
+
+	return cp.shardCoordinator.ComputeId(executingAddress) == cp.shardCoordinator.SelfId()
+}
+
+func (cp *contractsProcessor) contractEventFromCallData(tx data.TransactionHandler) *schema.ContractEvent {
+	if isDeploy(tx) {
+		return cp.deployEventFromCallData(tx)
+	}
+
+	function, args, err := cp.callArgsParser.ParseData(string(tx.GetData()))
+	if err != nil {
+		return nil
+	}
+
+	receiver := tx.GetRcvAddr()
+	switch {
+	case function == upgradeFunction && len(args) >= 2:
+		return &schema.ContractEvent{
+			Type:            ContractUpgrade,
+			ContractAddress: utility.EncodePubKey(cp.pubKeyConverter, receiver),
+			Owner:           utility.EncodePubKey(cp.pubKeyConverter, tx.GetSndAddr()),
+			CodeHash:        cp.hasher.Compute(string(args[0])),
+			CodeMetadata:    processCodeMetadata(args[1]),
+		}
+	case function == core.BuiltInFunctionChangeOwnerAddress && len(args) >= 1:
+		return &schema.ContractEvent{
+			Type:            ContractChangeOwner,
+			ContractAddress: utility.EncodePubKey(cp.pubKeyConverter, receiver),
+			Owner:           utility.EncodePubKey(cp.pubKeyConverter, args[0]),
+			PreviousOwner:   utility.EncodePubKey(cp.pubKeyConverter, tx.GetSndAddr()),
+		}
+	default:
+		return nil
+	}
+}
+
+func (cp *contractsProcessor) deployEventFromCallData(tx data.TransactionHandler) *schema.ContractEvent {
+	deployArgs, err := cp.deployArgsParser.ParseData(string(tx.GetData()))
+	if err != nil {
+		return nil
+	}
+
+	contractAddress := cp.computeContractAddress(tx.GetSndAddr(), tx.GetNonce(), deployArgs.VMType)
+	if contractAddress == nil {
+		return nil
+	}
+
+	return &schema.ContractEvent{
+		Type:            ContractDeploy,
+		ContractAddress: utility.EncodePubKey(cp.pubKeyConverter, contractAddress),
+		Owner:           utility.EncodePubKey(cp.pubKeyConverter, tx.GetSndAddr()),
+		CodeHash:        cp.hasher.Compute(string(deployArgs.Code)),
+		CodeMetadata:    processCodeMetadata(deployArgs.CodeMetadata.ToBytes()),
+	}
+}
+
+// computeContractAddress computes the address of a newly deployed contract the same way the node does: the hash of
+// the creator address and nonce, prefixed by the vm type and ending with the shard identifier of the creator
+func (cp *contractsProcessor) computeContractAddress(creator []byte, nonce uint64, vmType []byte) []byte {
+	addressLength := len(creator)
+	if addressLength < core.NumInitCharactersForScAddress+core.ShardIdentiferLen || len(vmType) != core.VMTypeLen {
+		return nil
+	}
+
+	nonceBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(nonceBytes, nonce)
+
+	base := cp.addressHasher.Compute(string(append(append([]byte{}, creator...), nonceBytes...)))
+	if len(base) < addressLength {
+		return nil
+	}
+
+	address := make([]byte, addressLength)
+	copy(address[core.NumInitCharactersForScAddress:], base[core.NumInitCharactersForScAddress:addressLength])
+	copy(address[core.NumInitCharactersForScAddress-core.VMTypeLen:], vmType)
+	copy(address[addressLength-core.ShardIdentiferLen:], creator[addressLength-core.ShardIdentiferLen:])
+
+	return address
+}
+
+func isDeploy(tx data.TransactionHandler) bool {
+	receiver := tx.GetRcvAddr()
+	return len(receiver) != 0 && core.IsEmptyAddress(receiver)
+}
+
+func processCodeMetadata(codeMetadataBytes []byte) *schema.CodeMetadata {
+	codeMetadata := vmcommon.CodeMetadataFromBytes(codeMetadataBytes)
+
+	return &schema.CodeMetadata{
+		Upgradeable: codeMetadata.Upgradeable,
+		Readable:    codeMetadata.Readable,
+		Payable:     codeMetadata.Payable,
+		PayableBySC: codeMetadata.PayableBySC,
+	}
+}
+
+// enrichEvent adds to an event found in logs the details which are only found in call data
+func enrichEvent(event *schema.ContractEvent, fromCallData *schema.ContractEvent) {
+	if event.CodeHash == nil {
+		event.CodeHash = fromCallData.CodeHash
+	}
+	if event.CodeMetadata == nil {
+		event.CodeMetadata = fromCallData.CodeMetadata
+	}
+	if event.PreviousOwner == nil {
+		event.PreviousOwner = fromCallData.PreviousOwner
+	}
+}
+
+func eventKey(event *schema.ContractEvent) string {
+	return string(event.TxHash) + "|" + event.Type + "|" + string(event.ContractAddress)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (cp *contractsProcessor) IsInterfaceNil() bool {
+	return cp == nil
+}
@@ -0,0 +1,284 @@
+package contracts_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/covalent-indexer-go/process"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/contracts"
+	"github.com/ElrondNetwork/covalent-indexer-go/schema"
+	"github.com/ElrondNetwork/covalent-indexer-go/testscommon/mock"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/ElrondNetwork/elrond-go-core/hashing"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	code         = "0061736d"
+	wasmVMType   = "0500"
+	codeMetadata = "0102"
+
+	// deployerHex is erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th and deployedContractHex is
+	// erd1qqqqqqqqqqqqqpgqak8zt22wl2ph4tswtyc39namqx6ysa2sd8ss4xmlj3, the address of its first deployed contract
+	deployerHex         = "0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1"
+	deployedContractHex = "00000000000000000500ed8e25a94efa837aae0e593112cfbb01b448755069e1"
+)
+
+func TestNewContractsProcessor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		shardCoordinator process.ShardCoordinator
+		pubKeyConverter  core.PubkeyConverter
+		hasher           hashing.Hasher
+		expectedErr      error
+	}{
+		{
+			shardCoordinator: nil,
+			pubKeyConverter:  &mock.PubKeyConverterStub{},
+			hasher:           &mock.HasherMock{},
+			expectedErr:      covalent.ErrNilShardCoordinator,
+		},
+		{
+			shardCoordinator: &mock.ShardCoordinatorMock{},
+			pubKeyConverter:  nil,
+			hasher:           &mock.HasherMock{},
+			expectedErr:      covalent.ErrNilPubKeyConverter,
+		},
+		{
+			shardCoordinator: &mock.ShardCoordinatorMock{},
+			pubKeyConverter:  &mock.PubKeyConverterStub{},
+			hasher:           nil,
+			expectedErr:      covalent.ErrNilHasher,
+		},
+		{
+			shardCoordinator: &mock.ShardCoordinatorMock{},
+			pubKeyConverter:  &mock.PubKeyConverterStub{},
+			hasher:           &mock.HasherMock{},
+			expectedErr:      nil,
+		},
+	}
+
+	for _, currTest := range tests {
+		_, err := contracts.NewContractsProcessor(currTest.shardCoordinator, currTest.pubKeyConverter, currTest.hasher)
+		require.Equal(t, currTest.expectedErr, err)
+	}
+}
+
+func TestContractsProcessor_ProcessContractEvents_DeployFromCallData(t *testing.T) {
+	t.Parallel()
+
+	cp, _ := contracts.NewContractsProcessor(&mock.ShardCoordinatorMock{}, &mock.PubKeyConverterStub{}, &mock.HasherMock{})
+
+	deployer, _ := hex.DecodeString(deployerHex)
+	expectedAddress, _ := hex.DecodeString(deployedContractHex)
+	txs := map[string]data.TransactionHandler{
+		"txHash": &transaction.Transaction{
+			Nonce:   0,
+			SndAddr: deployer,
+			RcvAddr: make([]byte, 32),
+			Data:    []byte(code + "@" + wasmVMType + "@" + codeMetadata),
+		},
+	}
+
+	ret := cp.ProcessContractEvents(txs, nil, nil)
+	require.Len(t, ret, 1)
+	require.Equal(t, &schema.ContractEvent{
+		TxHash:          []byte("txHash"),
+		Type:            contracts.ContractDeploy,
+		ContractAddress: []byte("erd1" + string(expectedAddress)),
+		Owner:           []byte("erd1" + string(deployer)),
+		CodeHash:        []byte("ok"),
+		CodeMetadata: &schema.CodeMetadata{
+			Upgradeable: true,
+			Readable:    false,
+			Payable:     true,
+			PayableBySC: false,
+		},
+	}, ret[0])
+}
+
+func TestContractsProcessor_ProcessContractEvents_DeployFromLogEnrichedWithCallData(t *testing.T) {
+	t.Parallel()
+
+	cp, _ := contracts.NewContractsProcessor(&mock.ShardCoordinatorMock{}, &mock.PubKeyConverterStub{}, &mock.HasherMock{})
+
+	deployer, _ := hex.DecodeString(deployerHex)
+	contractAddress, _ := hex.DecodeString(deployedContractHex)
+	txs := map[string]data.TransactionHandler{
+		"txHash": &transaction.Transaction{
+			Nonce:   0,
+			SndAddr: deployer,
+			RcvAddr: make([]byte, 32),
+			Data:    []byte(code + "@" + wasmVMType + "@" + codeMetadata),
+		},
+	}
+	logs := []*data.LogData{
+		{
+			TxHash: "txHash",
+			LogHandler: &transaction.Log{
+				Events: []*transaction.Event{
+					{
+						Address:    contractAddress,
+						Identifier: []byte("SCDeploy"),
+						Topics:     [][]byte{contractAddress, deployer},
+					},
+				},
+			},
+		},
+	}
+
+	ret := cp.ProcessContractEvents(txs, nil, logs)
+	require.Len(t, ret, 1)
+	require.Equal(t, contracts.ContractDeploy, ret[0].Type)
+	require.Equal(t, []byte("erd1"+string(contractAddress)), ret[0].ContractAddress)
+	require.Equal(t, []byte("erd1"+string(deployer)), ret[0].Owner)
+	require.Equal(t, []byte("ok"), ret[0].CodeHash)
+	require.NotNil(t, ret[0].CodeMetadata)
+}
+
+func TestContractsProcessor_ProcessContractEvents_UpgradeFromSCR_ExpectOriginalTxHash(t *testing.T) {
+	t.Parallel()
+
+	cp, _ := contracts.NewContractsProcessor(&mock.ShardCoordinatorMock{}, &mock.PubKeyConverterStub{}, &mock.HasherMock{})
+
+	scrs := map[string]data.TransactionHandler{
+		"scrHash": &smartContractResult.SmartContractResult{
+			SndAddr:        []byte("owner"),
+			RcvAddr:        []byte("contract"),
+			Data:           []byte("upgradeContract@" + code + "@" + codeMetadata),
+			OriginalTxHash: []byte("txHash"),
+		},
+	}
+
+	ret := cp.ProcessContractEvents(nil, scrs, nil)
+	require.Len(t, ret, 1)
+	require.Equal(t, []byte("txHash"), ret[0].TxHash)
+	require.Equal(t, contracts.ContractUpgrade, ret[0].Type)
+	require.Equal(t, []byte("erd1contract"), ret[0].ContractAddress)
+	require.Equal(t, []byte("erd1owner"), ret[0].Owner)
+	require.Nil(t, ret[0].PreviousOwner)
+}
+
+func TestContractsProcessor_ProcessContractEvents_ChangeOwner(t *testing.T) {
+	t.Parallel()
+
+	cp, _ := contracts.NewContractsProcessor(&mock.ShardCoordinatorMock{}, &mock.PubKeyConverterStub{}, &mock.HasherMock{})
+
+	txs := map[string]data.TransactionHandler{
+		"txHash": &transaction.Transaction{
+			SndAddr: []byte("oldOwner"),
+			RcvAddr: []byte("contract"),
+			Data:    []byte(core.BuiltInFunctionChangeOwnerAddress + "@" + hex.EncodeToString([]byte("newOwner"))),
+		},
+	}
+
+	ret := cp.ProcessContractEvents(txs, nil, nil)
+	require.Len(t, ret, 1)
+	require.Equal(t, &schema.ContractEvent{
+		TxHash:          []byte("txHash"),
+		Type:            contracts.ContractChangeOwner,
+		ContractAddress: []byte("erd1contract"),
+		Owner:           []byte("erd1newOwner"),
+		PreviousOwner:   []byte("erd1oldOwner"),
+	}, ret[0])
+}
+
+func TestContractsProcessor_ProcessContractEvents_CrossShardChangeOwner(t *testing.T) {
+	t.Parallel()
+
+	shardOfAddress := map[string]uint32{
+		"oldOwner": 0,
+		"contract": 1,
+	}
+	computeId := func(address []byte) uint32 {
+		return shardOfAddress[string(address)]
+	}
+	txs := map[string]data.TransactionHandler{
+		"txHash": &transaction.Transaction{
+			SndAddr: []byte("oldOwner"),
+			RcvAddr: []byte("contract"),
+			Data:    []byte(core.BuiltInFunctionChangeOwnerAddress + "@" + hex.EncodeToString([]byte("newOwner"))),
+		},
+	}
+
+	senderShardProcessor, _ := contracts.NewContractsProcessor(
+		&mock.ShardCoordinatorMock{SelfID: 0, ComputeIdCalled: computeId},
+		&mock.PubKeyConverterStub{},
+		&mock.HasherMock{})
+	ret := senderShardProcessor.ProcessContractEvents(txs, nil, nil)
+	require.Len(t, ret, 0)
+
+	receiverShardProcessor, _ := contracts.NewContractsProcessor(
+		&mock.ShardCoordinatorMock{SelfID: 1, ComputeIdCalled: computeId},
+		&mock.PubKeyConverterStub{},
+		&mock.HasherMock{})
+	ret = receiverShardProcessor.ProcessContractEvents(txs, nil, nil)
+	require.Len(t, ret, 1)
+	require.Equal(t, contracts.ContractChangeOwner, ret[0].Type)
+	require.Equal(t, []byte("erd1contract"), ret[0].ContractAddress)
+	require.Equal(t, []byte("erd1newOwner"), ret[0].Owner)
+	require.Equal(t, []byte("erd1oldOwner"), ret[0].PreviousOwner)
+}
+
+func TestContractsProcessor_ProcessContractEvents_FailedTx_ExpectNoEvent(t *testing.T) {
+	t.Parallel()
+
+	cp, _ := contracts.NewContractsProcessor(&mock.ShardCoordinatorMock{}, &mock.PubKeyConverterStub{}, &mock.HasherMock{})
+
+	txs := map[string]data.TransactionHandler{
+		"txHash": &transaction.Transaction{
+			SndAddr: []byte("owner"),
+			RcvAddr: []byte("contract"),
+			Data:    []byte("upgradeContract@" + code + "@" + codeMetadata),
+		},
+	}
+	logs := []*data.LogData{
+		{
+			TxHash: "txHash",
+			LogHandler: &transaction.Log{
+				Events: []*transaction.Event{{Identifier: []byte("signalError")}},
+			},
+		},
+	}
+
+	ret := cp.ProcessContractEvents(txs, nil, logs)
+	require.Len(t, ret, 0)
+}
+
+func TestContractsProcessor_ProcessContractEvents_LogAndCallDataMismatch_ExpectOnlyLogEvent(t *testing.T) {
+	t.Parallel()
+
+	cp, _ := contracts.NewContractsProcessor(&mock.ShardCoordinatorMock{}, &mock.PubKeyConverterStub{}, &mock.HasherMock{})
+
+	txs := map[string]data.TransactionHandler{
+		"txHash": &transaction.Transaction{
+			SndAddr: []byte("owner"),
+			RcvAddr: []byte("proxy"),
+			Data:    []byte("upgradeContract@" + code + "@" + codeMetadata),
+		},
+	}
+	logs := []*data.LogData{
+		{
+			TxHash: "txHash",
+			LogHandler: &transaction.Log{
+				Events: []*transaction.Event{
+					{
+						Address:    []byte("contract"),
+						Identifier: []byte("SCUpgrade"),
+						Topics:     [][]byte{[]byte("contract"), []byte("owner")},
+					},
+				},
+			},
+		},
+	}
+
+	ret := cp.ProcessContractEvents(txs, nil, logs)
+	require.Len(t, ret, 1)
+	require.Equal(t, []byte("erd1contract"), ret[0].ContractAddress)
+	require.Nil(t, ret[0].CodeHash)
+}
@@ -1,7 +1,10 @@
 package process
 
 import (
+	"sync"
+
 	"github.com/ElrondNetwork/covalent-indexer-go/schema"
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
 	"github.com/ElrondNetwork/elrond-go-core/data"
 	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
 )
@@ -13,25 +16,92 @@ type dataProcessor struct {
 	scHandler          SCResultsHandler
 	logHandler         LogHandler
 	accountsHandler    AccountsHandler
+	ratingsHandler     RatingsHandler
+	tokensHandler      TokenTransfersHandler
+	peersHandler       PeerChangesHandler
+	contractsHandler   ContractEventsHandler
+	callTreesHandler   CallTreesHandler
+	stakingHandler     StakingEventsHandler
+	registryHandler    TokenRegistryHandler
+
+	pendingRatings    []*schema.ValidatorRating
+	mutPendingRatings sync.Mutex
+}
+
+// ArgsDataProcessor holds all the sub-processors used by the data processor
+type ArgsDataProcessor struct {
+	BlockHandler       BlockHandler
+	TransactionHandler TransactionHandler
+	SCResultsHandler   SCResultsHandler
+	ReceiptHandler     ReceiptHandler
+	LogHandler         LogHandler
+	AccountsHandler    AccountsHandler
+	RatingsHandler     RatingsHandler
+	TokensHandler      TokenTransfersHandler
+	PeersHandler       PeerChangesHandler
+	ContractsHandler   ContractEventsHandler
+	CallTreesHandler   CallTreesHandler
+	StakingHandler     StakingEventsHandler
+	RegistryHandler    TokenRegistryHandler
 }
 
 // NewDataProcessor creates a new instance of data processor, which handles all sub-processes
-func NewDataProcessor(
-	blockHandler BlockHandler,
-	transactionHandler TransactionHandler,
-	scHandler SCResultsHandler,
-	receiptHandler ReceiptHandler,
-	logHandler LogHandler,
-	accountsHandler AccountsHandler,
-) (*dataProcessor, error) {
+func NewDataProcessor(args *ArgsDataProcessor) (*dataProcessor, error) {
+	if check.IfNil(args.BlockHandler) {
+		return nil, ErrNilBlockHandler
+	}
+	if check.IfNil(args.TransactionHandler) {
+		return nil, ErrNilTransactionHandler
+	}
+	if check.IfNil(args.SCResultsHandler) {
+		return nil, ErrNilSCResultsHandler
+	}
+	if check.IfNil(args.ReceiptHandler) {
+		return nil, ErrNilReceiptHandler
+	}
+	if check.IfNil(args.LogHandler) {
+		return nil, ErrNilLogHandler
+	}
+	if check.IfNil(args.AccountsHandler) {
+		return nil, ErrNilAccountsHandler
+	}
+	if check.IfNil(args.RatingsHandler) {
+		return nil, ErrNilRatingsHandler
+	}
+	if check.IfNil(args.TokensHandler) {
+		return nil, ErrNilTokenTransfersHandler
+	}
+	if check.IfNil(args.PeersHandler) {
+		return nil, ErrNilPeerChangesHandler
+	}
+	if check.IfNil(args.ContractsHandler) {
+		return nil, ErrNilContractEventsHandler
+	}
+	if check.IfNil(args.CallTreesHandler) {
+		return nil, ErrNilCallTreesHandler
+	}
+	if check.IfNil(args.StakingHandler) {
+		return nil, ErrNilStakingEventsHandler
+	}
+	if check.IfNil(args.RegistryHandler) {
+		return nil, ErrNilTokenRegistryHandler
+	}
 
 	return &dataProcessor{
-		blockHandler:       blockHandler,
-		transactionHandler: transactionHandler,
-		scHandler:          scHandler,
-		receiptHandler:     receiptHandler,
-		logHandler:         logHandler,
-		accountsHandler:    accountsHandler,
+		blockHandler:       args.BlockHandler,
+		transactionHandler: args.TransactionHandler,
+		scHandler:          args.SCResultsHandler,
+		receiptHandler:     args.ReceiptHandler,
+		logHandler:         args.LogHandler,
+		accountsHandler:    args.AccountsHandler,
+		ratingsHandler:     args.RatingsHandler,
+		tokensHandler:      args.TokensHandler,
+		peersHandler:       args.PeersHandler,
+		contractsHandler:   args.ContractsHandler,
+		callTreesHandler:   args.CallTreesHandler,
+		stakingHandler:     args.StakingHandler,
+		registryHandler:    args.RegistryHandler,
+		pendingRatings:     make([]*schema.ValidatorRating, 0),
 	}, nil
 }
 
@@ -49,21 +119,90 @@ func (dp *dataProcessor) ProcessData(args *indexer.ArgsSaveBlockData) (*schema.B
 		return nil, err
 	}
 
-	smartContractResults := dp.scHandler.ProcessSCRs(pool.Scrs, args.Header.GetTimeStamp())
-	receipts := dp.receiptHandler.ProcessReceipts(pool.Receipts, args.Header.GetTimeStamp())
-	logs := dp.logHandler.ProcessLogs(pool.Logs)
-	accountUpdates := dp.accountsHandler.ProcessAccounts(transactions, smartContractResults, receipts)
+	smartContractResults, err := dp.scHandler.ProcessSCRs(args.Header, args.HeaderHash, args.Body, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts, err := dp.receiptHandler.ProcessReceipts(args.Header, args.HeaderHash, args.Body, pool.Receipts)
+	if err != nil {
+		return nil, err
+	}
+
+	peerChanges, err := dp.peersHandler.ProcessPeerChanges(args.Header, args.HeaderHash, args.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := dp.logHandler.ProcessLogs(pool.Logs, args.HeaderHash)
+	tokenRegistryEvents := dp.registryHandler.ProcessTokenRegistryEvents(args.Header, pool.Txs, pool.Scrs, pool.Logs)
+	tokenTransfers := dp.tokensHandler.ProcessTokenTransfers(pool.Txs, pool.Scrs, pool.Logs)
+	dp.registryHandler.EnrichTokenTransfers(tokenTransfers)
+	contractEvents := dp.contractsHandler.ProcessContractEvents(pool.Txs, pool.Scrs, pool.Logs)
+	callTrees := dp.callTreesHandler.ProcessCallTrees(smartContractResults)
+	stakingEvents := dp.stakingHandler.ProcessStakingEvents(args.Header, pool.Txs, pool.Scrs, pool.Logs)
+	accountUpdates := dp.accountsHandler.ProcessAccounts(
+		transactions,
+		smartContractResults,
+		receipts,
+		logs,
+		tokenTransfers,
+		args.Header.GetTimeStamp())
 
 	return &schema.BlockResult{
-		Block:        block,
-		Transactions: transactions,
-		Receipts:     receipts,
-		SCResults:    smartContractResults,
-		Logs:         logs,
-		StateChanges: accountUpdates,
+		Block:               block,
+		Transactions:        transactions,
+		Receipts:            receipts,
+		SCResults:           smartContractResults,
+		Logs:                logs,
+		StateChanges:        accountUpdates,
+		ValidatorsRating:    dp.popPendingRatings(),
+		TokenTransfers:      tokenTransfers,
+		PeerChanges:         peerChanges,
+		ContractEvents:      contractEvents,
+		CallTrees:           callTrees,
+		StakingEvents:       stakingEvents,
+		TokenRegistryEvents: tokenRegistryEvents,
 	}, nil
 }
 
+// ProcessValidatorsRating converts validators rating data and keeps it until the next processed block,
+// so that it is delivered to covalent along with the block result
+func (dp *dataProcessor) ProcessValidatorsRating(indexID string, ratings []*indexer.ValidatorRatingInfo) error {
+	validatorsRating, err := dp.ratingsHandler.ProcessRatings(indexID, ratings)
+	if err != nil {
+		return err
+	}
+
+	dp.mutPendingRatings.Lock()
+	dp.pendingRatings = append(dp.pendingRatings, validatorsRating...)
+	dp.mutPendingRatings.Unlock()
+
+	return nil
+}
+
+// ProcessAccountsSnapshot keeps the accounts state of the block with the given timestamp, so that
+// state changes are taken from it when the block is processed
+func (dp *dataProcessor) ProcessAccountsSnapshot(blockTimestamp uint64, accounts []data.UserAccountHandler) {
+	dp.accountsHandler.SaveAccountsSnapshot(blockTimestamp, accounts)
+}
+
+// RevertBlock restores the cached accounts states and the token registry as they were before processing the given block
+func (dp *dataProcessor) RevertBlock(header data.HeaderHandler) {
+	dp.accountsHandler.RevertAccounts(header.GetTimeStamp())
+	dp.registryHandler.RevertTokenRegistry(header.GetTimeStamp())
+}
+
+func (dp *dataProcessor) popPendingRatings() []*schema.ValidatorRating {
+	dp.mutPendingRatings.Lock()
+	defer dp.mutPendingRatings.Unlock()
+
+	validatorsRating := dp.pendingRatings
+	dp.pendingRatings = make([]*schema.ValidatorRating, 0)
+
+	return validatorsRating
+}
+
 func getPool(args *indexer.ArgsSaveBlockData) *indexer.Pool {
 	pool := &indexer.Pool{
 		Txs:      make(map[string]data.TransactionHandler),
@@ -0,0 +1,178 @@
+package process_test
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/covalent-indexer-go/process"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/accounts"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/block"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/calltree"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/contracts"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/logs"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/peers"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/ratings"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/receipts"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/registry"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/staking"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/tokens"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/transactions"
+	"github.com/ElrondNetwork/covalent-indexer-go/testscommon/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsDataProcessor() *process.ArgsDataProcessor {
+	pubKeyConverter := &mock.PubKeyConverterStub{}
+	hasher := &mock.HasherMock{}
+	marshaller := &mock.MarshallerStub{}
+
+	blockHandler, _ := block.NewBlockProcessor(marshaller, &mock.MiniBlockHandlerStub{})
+	transactionHandler, _ := transactions.NewTransactionProcessor(pubKeyConverter, hasher, marshaller, &mock.FeeCalculatorStub{})
+	scResultsHandler, _ := transactions.NewSCResultsProcessor(pubKeyConverter, hasher, marshaller)
+	receiptHandler, _ := receipts.NewReceiptsProcessor(pubKeyConverter, hasher, marshaller)
+	logHandler, _ := logs.NewLogsProcessor(pubKeyConverter, marshaller)
+	accountsHandler, _ := accounts.NewAccountsProcessor(&mock.ShardCoordinatorMock{}, &mock.AccountsAdapterStub{}, pubKeyConverter, marshaller)
+	tokensHandler, _ := tokens.NewTokenTransfersProcessor(pubKeyConverter, marshaller)
+	peersHandler, _ := peers.NewPeerChangesProcessor(hasher, marshaller)
+	contractsHandler, _ := contracts.NewContractsProcessor(&mock.ShardCoordinatorMock{}, pubKeyConverter, hasher)
+	stakingHandler, _ := staking.NewStakingProcessor(pubKeyConverter)
+	registryHandler, _ := registry.NewTokenRegistryProcessor(pubKeyConverter)
+
+	return &process.ArgsDataProcessor{
+		BlockHandler:       blockHandler,
+		TransactionHandler: transactionHandler,
+		SCResultsHandler:   scResultsHandler,
+		ReceiptHandler:     receiptHandler,
+		LogHandler:         logHandler,
+		AccountsHandler:    accountsHandler,
+		RatingsHandler:     ratings.NewRatingsProcessor(),
+		TokensHandler:      tokensHandler,
+		PeersHandler:       peersHandler,
+		ContractsHandler:   contractsHandler,
+		CallTreesHandler:   calltree.NewCallTreeProcessor(),
+		StakingHandler:     stakingHandler,
+		RegistryHandler:    registryHandler,
+	}
+}
+
+func TestNewDataProcessor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args        func() *process.ArgsDataProcessor
+		expectedErr error
+	}{
+		{
+			args: func() *process.ArgsDataProcessor {
+				args := createMockArgsDataProcessor()
+				args.BlockHandler = nil
+				return args
+			},
+			expectedErr: process.ErrNilBlockHandler,
+		},
+		{
+			args: func() *process.ArgsDataProcessor {
+				args := createMockArgsDataProcessor()
+				args.TransactionHandler = nil
+				return args
+			},
+			expectedErr: process.ErrNilTransactionHandler,
+		},
+		{
+			args: func() *process.ArgsDataProcessor {
+				args := createMockArgsDataProcessor()
+				args.SCResultsHandler = nil
+				return args
+			},
+			expectedErr: process.ErrNilSCResultsHandler,
+		},
+		{
+			args: func() *process.ArgsDataProcessor {
+				args := createMockArgsDataProcessor()
+				args.ReceiptHandler = nil
+				return args
+			},
+			expectedErr: process.ErrNilReceiptHandler,
+		},
+		{
+			args: func() *process.ArgsDataProcessor {
+				args := createMockArgsDataProcessor()
+				args.LogHandler = nil
+				return args
+			},
+			expectedErr: process.ErrNilLogHandler,
+		},
+		{
+			args: func() *process.ArgsDataProcessor {
+				args := createMockArgsDataProcessor()
+				args.AccountsHandler = nil
+				return args
+			},
+			expectedErr: process.ErrNilAccountsHandler,
+		},
+		{
+			args: func() *process.ArgsDataProcessor {
+				args := createMockArgsDataProcessor()
+				args.RatingsHandler = nil
+				return args
+			},
+			expectedErr: process.ErrNilRatingsHandler,
+		},
+		{
+			args: func() *process.ArgsDataProcessor {
+				args := createMockArgsDataProcessor()
+				args.TokensHandler = nil
+				return args
+			},
+			expectedErr: process.ErrNilTokenTransfersHandler,
+		},
+		{
+			args: func() *process.ArgsDataProcessor {
+				args := createMockArgsDataProcessor()
+				args.PeersHandler = nil
+				return args
+			},
+			expectedErr: process.ErrNilPeerChangesHandler,
+		},
+		{
+			args: func() *process.ArgsDataProcessor {
+				args := createMockArgsDataProcessor()
+				args.ContractsHandler = nil
+				return args
+			},
+			expectedErr: process.ErrNilContractEventsHandler,
+		},
+		{
+			args: func() *process.ArgsDataProcessor {
+				args := createMockArgsDataProcessor()
+				args.CallTreesHandler = nil
+				return args
+			},
+			expectedErr: process.ErrNilCallTreesHandler,
+		},
+		{
+			args: func() *process.ArgsDataProcessor {
+				args := createMockArgsDataProcessor()
+				args.StakingHandler = nil
+				return args
+			},
+			expectedErr: process.ErrNilStakingEventsHandler,
+		},
+		{
+			args: func() *process.ArgsDataProcessor {
+				args := createMockArgsDataProcessor()
+				args.RegistryHandler = nil
+				return args
+			},
+			expectedErr: process.ErrNilTokenRegistryHandler,
+		},
+		{
+			args:        createMockArgsDataProcessor,
+			expectedErr: nil,
+		},
+	}
+
+	for _, currTest := range tests {
+		_, err := process.NewDataProcessor(currTest.args())
+		require.Equal(t, currTest.expectedErr, err)
+	}
+}
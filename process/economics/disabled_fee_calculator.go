@@ -0,0 +1,51 @@
+package economics
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go-core/data"
+)
+
+type disabledFeeCalculator struct {
+}
+
+// NewDisabledFeeCalculator creates a fee calculator which leaves the transactions gas and fees null,
+// used when the economics configuration of the network is not provided
+func NewDisabledFeeCalculator() *disabledFeeCalculator {
+	return &disabledFeeCalculator{}
+}
+
+// ComputeGasLimit returns 0
+func (dfc *disabledFeeCalculator) ComputeGasLimit(_ data.TransactionWithFeeHandler) uint64 {
+	return 0
+}
+
+// ComputeMoveBalanceFee returns 0
+func (dfc *disabledFeeCalculator) ComputeMoveBalanceFee(_ data.TransactionWithFeeHandler) *big.Int {
+	return big.NewInt(0)
+}
+
+// ComputeFeeForProcessing returns 0
+func (dfc *disabledFeeCalculator) ComputeFeeForProcessing(_ data.TransactionWithFeeHandler, _ uint64) *big.Int {
+	return big.NewInt(0)
+}
+
+// ComputeTxFee returns 0
+func (dfc *disabledFeeCalculator) ComputeTxFee(_ data.TransactionWithFeeHandler) *big.Int {
+	return big.NewInt(0)
+}
+
+// ComputeGasUsedAndFeeBasedOnRefundValue returns 0 gas used and 0 fee
+func (dfc *disabledFeeCalculator) ComputeGasUsedAndFeeBasedOnRefundValue(_ data.TransactionWithFeeHandler, _ *big.Int) (uint64, *big.Int) {
+	return 0, big.NewInt(0)
+}
+
+// IsEnabled returns false
+func (dfc *disabledFeeCalculator) IsEnabled() bool {
+	return false
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (dfc *disabledFeeCalculator) IsInterfaceNil() bool {
+	return dfc == nil
+}
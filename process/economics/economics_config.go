@@ -0,0 +1,27 @@
+package economics
+
+import "github.com/ElrondNetwork/elrond-go-core/core"
+
+// FeeSettings holds the gas and fee parameters from the node's economics configuration
+type FeeSettings struct {
+	MinGasLimit      string
+	GasPerDataByte   string
+	GasPriceModifier float64
+}
+
+// EconomicsConfig holds the sections of the node's economics configuration which are needed
+// in order to compute the transactions fees. All other sections of the file are ignored
+type EconomicsConfig struct {
+	FeeSettings FeeSettings
+}
+
+// LoadEconomicsConfig loads the economics configuration from the node's economics toml file
+func LoadEconomicsConfig(filePath string) (*EconomicsConfig, error) {
+	config := &EconomicsConfig{}
+	err := core.LoadTomlFile(config, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
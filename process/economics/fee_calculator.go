@@ -0,0 +1,109 @@
+package economics
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+)
+
+type feeCalculator struct {
+	minGasLimit      uint64
+	gasPerDataByte   uint64
+	gasPriceModifier float64
+}
+
+// NewFeeCalculator creates a new instance of fee calculator, which computes the transactions
+// gas and fees the same way the node does, based on the economics configuration
+func NewFeeCalculator(config *EconomicsConfig) (*feeCalculator, error) {
+	if config == nil {
+		return nil, covalent.ErrNilEconomicsConfig
+	}
+
+	minGasLimit, err := strconv.ParseUint(config.FeeSettings.MinGasLimit, 10, 64)
+	if err != nil {
+		return nil, covalent.ErrInvalidMinGasLimit
+	}
+
+	gasPerDataByte, err := strconv.ParseUint(config.FeeSettings.GasPerDataByte, 10, 64)
+	if err != nil {
+		return nil, covalent.ErrInvalidGasPerDataByte
+	}
+
+	gasPriceModifier := config.FeeSettings.GasPriceModifier
+	if gasPriceModifier <= 0 || gasPriceModifier > 1 {
+		return nil, covalent.ErrInvalidGasPriceModifier
+	}
+
+	return &feeCalculator{
+		minGasLimit:      minGasLimit,
+		gasPerDataByte:   gasPerDataByte,
+		gasPriceModifier: gasPriceModifier,
+	}, nil
+}
+
+// ComputeGasLimit returns the gas needed by the transaction as a move balance, which depends only on its data length
+func (fc *feeCalculator) ComputeGasLimit(tx data.TransactionWithFeeHandler) uint64 {
+	return fc.minGasLimit + uint64(len(tx.GetData()))*fc.gasPerDataByte
+}
+
+// ComputeMoveBalanceFee returns the fee paid for the move balance gas, which is paid at full gas price
+func (fc *feeCalculator) ComputeMoveBalanceFee(tx data.TransactionWithFeeHandler) *big.Int {
+	return core.SafeMul(tx.GetGasPrice(), fc.ComputeGasLimit(tx))
+}
+
+// ComputeFeeForProcessing returns the fee paid for the given gas used beyond the move balance gas,
+// which is paid at the gas price adjusted by the gas price modifier
+func (fc *feeCalculator) ComputeFeeForProcessing(tx data.TransactionWithFeeHandler, gasToUse uint64) *big.Int {
+	return core.SafeMul(fc.gasPriceForProcessing(tx), gasToUse)
+}
+
+// ComputeTxFee returns the fee initially paid by the sender of the transaction, for its whole gas limit
+func (fc *feeCalculator) ComputeTxFee(tx data.TransactionWithFeeHandler) *big.Int {
+	moveBalanceGas := fc.ComputeGasLimit(tx)
+	moveBalanceFee := fc.ComputeMoveBalanceFee(tx)
+	if tx.GetGasLimit() <= moveBalanceGas {
+		return moveBalanceFee
+	}
+
+	processingFee := fc.ComputeFeeForProcessing(tx, tx.GetGasLimit()-moveBalanceGas)
+	return moveBalanceFee.Add(moveBalanceFee, processingFee)
+}
+
+// ComputeGasUsedAndFeeBasedOnRefundValue returns the gas used and the fee actually paid by a transaction,
+// after the unused gas was refunded to its sender with the given value
+func (fc *feeCalculator) ComputeGasUsedAndFeeBasedOnRefundValue(tx data.TransactionWithFeeHandler, refundValue *big.Int) (uint64, *big.Int) {
+	txFee := fc.ComputeTxFee(tx)
+	if refundValue == nil || refundValue.Sign() == 0 {
+		return tx.GetGasLimit(), txFee
+	}
+
+	txFee.Sub(txFee, refundValue)
+	moveBalanceGas := fc.ComputeGasLimit(tx)
+	moveBalanceFee := fc.ComputeMoveBalanceFee(tx)
+	gasPriceForProcessing := fc.gasPriceForProcessing(tx)
+	if txFee.Cmp(moveBalanceFee) <= 0 || gasPriceForProcessing == 0 {
+		return moveBalanceGas, txFee
+	}
+
+	processingFee := big.NewInt(0).Sub(txFee, moveBalanceFee)
+	gasUsedForProcessing := processingFee.Div(processingFee, big.NewInt(0).SetUint64(gasPriceForProcessing))
+
+	return moveBalanceGas + gasUsedForProcessing.Uint64(), txFee
+}
+
+func (fc *feeCalculator) gasPriceForProcessing(tx data.TransactionWithFeeHandler) uint64 {
+	return uint64(fc.gasPriceModifier * float64(tx.GetGasPrice()))
+}
+
+// IsEnabled returns true
+func (fc *feeCalculator) IsEnabled() bool {
+	return true
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (fc *feeCalculator) IsInterfaceNil() bool {
+	return fc == nil
+}
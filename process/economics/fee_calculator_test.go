@@ -0,0 +1,150 @@
+package economics_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/economics"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func createEconomicsConfig() *economics.EconomicsConfig {
+	return &economics.EconomicsConfig{
+		FeeSettings: economics.FeeSettings{
+			MinGasLimit:      "50000",
+			GasPerDataByte:   "1500",
+			GasPriceModifier: 0.01,
+		},
+	}
+}
+
+func TestNewFeeCalculator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		config      func() *economics.EconomicsConfig
+		expectedErr error
+	}{
+		{
+			config: func() *economics.EconomicsConfig {
+				return nil
+			},
+			expectedErr: covalent.ErrNilEconomicsConfig,
+		},
+		{
+			config: func() *economics.EconomicsConfig {
+				cfg := createEconomicsConfig()
+				cfg.FeeSettings.MinGasLimit = "-1"
+				return cfg
+			},
+			expectedErr: covalent.ErrInvalidMinGasLimit,
+		},
+		{
+			config: func() *economics.EconomicsConfig {
+				cfg := createEconomicsConfig()
+				cfg.FeeSettings.GasPerDataByte = ""
+				return cfg
+			},
+			expectedErr: covalent.ErrInvalidGasPerDataByte,
+		},
+		{
+			config: func() *economics.EconomicsConfig {
+				cfg := createEconomicsConfig()
+				cfg.FeeSettings.GasPriceModifier = 0
+				return cfg
+			},
+			expectedErr: covalent.ErrInvalidGasPriceModifier,
+		},
+		{
+			config: func() *economics.EconomicsConfig {
+				cfg := createEconomicsConfig()
+				cfg.FeeSettings.GasPriceModifier = 1.1
+				return cfg
+			},
+			expectedErr: covalent.ErrInvalidGasPriceModifier,
+		},
+		{
+			config:      createEconomicsConfig,
+			expectedErr: nil,
+		},
+	}
+
+	for _, currTest := range tests {
+		_, err := economics.NewFeeCalculator(currTest.config())
+		require.Equal(t, currTest.expectedErr, err)
+	}
+}
+
+func TestLoadEconomicsConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := economics.LoadEconomicsConfig("inexistent.toml")
+	require.NotNil(t, err)
+
+	cfg, err := economics.LoadEconomicsConfig("../../cmd/covalent-indexer/config/economics.toml")
+	require.Nil(t, err)
+	require.Equal(t, createEconomicsConfig(), cfg)
+}
+
+func TestFeeCalculator_ComputeFees(t *testing.T) {
+	t.Parallel()
+
+	fc, _ := economics.NewFeeCalculator(createEconomicsConfig())
+
+	tx := &transaction.Transaction{
+		GasLimit: 1_000_000,
+		GasPrice: 1_000_000_000,
+		Data:     []byte("function@01"),
+	}
+	moveBalanceGas := uint64(50000 + 11*1500)
+	moveBalanceFee := big.NewInt(int64(moveBalanceGas) * 1_000_000_000)
+	initialProcessingFee := big.NewInt(int64(1_000_000-moveBalanceGas) * 10_000_000)
+	initialFee := big.NewInt(0).Add(moveBalanceFee, initialProcessingFee)
+
+	require.Equal(t, moveBalanceGas, fc.ComputeGasLimit(tx))
+	require.Equal(t, moveBalanceFee, fc.ComputeMoveBalanceFee(tx))
+	require.Equal(t, big.NewInt(1000*10_000_000), fc.ComputeFeeForProcessing(tx, 1000))
+	require.Equal(t, initialFee, fc.ComputeTxFee(tx))
+
+	gasUsed, fee := fc.ComputeGasUsedAndFeeBasedOnRefundValue(tx, nil)
+	require.Equal(t, tx.GasLimit, gasUsed)
+	require.Equal(t, initialFee, fee)
+
+	refund := big.NewInt(400_000 * 10_000_000)
+	gasUsed, fee = fc.ComputeGasUsedAndFeeBasedOnRefundValue(tx, refund)
+	require.Equal(t, tx.GasLimit-400_000, gasUsed)
+	require.Equal(t, big.NewInt(0).Sub(initialFee, refund), fee)
+
+	gasUsed, fee = fc.ComputeGasUsedAndFeeBasedOnRefundValue(tx, initialProcessingFee)
+	require.Equal(t, moveBalanceGas, gasUsed)
+	require.Equal(t, moveBalanceFee, fee)
+}
+
+func TestFeeCalculator_ComputeTxFee_GasLimitBelowMoveBalanceGas(t *testing.T) {
+	t.Parallel()
+
+	fc, _ := economics.NewFeeCalculator(createEconomicsConfig())
+
+	tx := &transaction.Transaction{GasLimit: 1000, GasPrice: 10}
+	require.Equal(t, big.NewInt(500000), fc.ComputeTxFee(tx))
+}
+
+func TestDisabledFeeCalculator_ExpectEmptyGasAndFees(t *testing.T) {
+	t.Parallel()
+
+	fc := economics.NewDisabledFeeCalculator()
+	tx := &transaction.Transaction{GasLimit: 100000, GasPrice: 1000000000, Data: []byte("data")}
+
+	require.False(t, fc.IsInterfaceNil())
+	require.False(t, fc.IsEnabled())
+	require.Equal(t, uint64(0), fc.ComputeGasLimit(tx))
+	require.Equal(t, big.NewInt(0), fc.ComputeMoveBalanceFee(tx))
+	require.Equal(t, big.NewInt(0), fc.ComputeFeeForProcessing(tx, 1000))
+	require.Equal(t, big.NewInt(0), fc.ComputeTxFee(tx))
+
+	gasUsed, fee := fc.ComputeGasUsedAndFeeBasedOnRefundValue(tx, big.NewInt(10))
+	require.Equal(t, uint64(0), gasUsed)
+	require.Equal(t, big.NewInt(0), fee)
+}
@@ -0,0 +1,42 @@
+package process
+
+import "errors"
+
+// ErrNilBlockHandler signals that a nil block handler has been provided
+var ErrNilBlockHandler = errors.New("received nil input value: block handler")
+
+// ErrNilTransactionHandler signals that a nil transaction handler has been provided
+var ErrNilTransactionHandler = errors.New("received nil input value: transaction handler")
+
+// ErrNilSCResultsHandler signals that a nil smart contract results handler has been provided
+var ErrNilSCResultsHandler = errors.New("received nil input value: smart contract results handler")
+
+// ErrNilReceiptHandler signals that a nil receipt handler has been provided
+var ErrNilReceiptHandler = errors.New("received nil input value: receipt handler")
+
+// ErrNilLogHandler signals that a nil log handler has been provided
+var ErrNilLogHandler = errors.New("received nil input value: log handler")
+
+// ErrNilAccountsHandler signals that a nil accounts handler has been provided
+var ErrNilAccountsHandler = errors.New("received nil input value: accounts handler")
+
+// ErrNilRatingsHandler signals that a nil ratings handler has been provided
+var ErrNilRatingsHandler = errors.New("received nil input value: ratings handler")
+
+// ErrNilTokenTransfersHandler signals that a nil token transfers handler has been provided
+var ErrNilTokenTransfersHandler = errors.New("received nil input value: token transfers handler")
+
+// ErrNilPeerChangesHandler signals that a nil peer changes handler has been provided
+var ErrNilPeerChangesHandler = errors.New("received nil input value: peer changes handler")
+
+// ErrNilContractEventsHandler signals that a nil contract events handler has been provided
+var ErrNilContractEventsHandler = errors.New("received nil input value: contract events handler")
+
+// ErrNilCallTreesHandler signals that a nil call trees handler has been provided
+var ErrNilCallTreesHandler = errors.New("received nil input value: call trees handler")
+
+// ErrNilStakingEventsHandler signals that a nil staking events handler has been provided
+var ErrNilStakingEventsHandler = errors.New("received nil input value: staking events handler")
+
+// ErrNilTokenRegistryHandler signals that a nil token registry handler has been provided
+var ErrNilTokenRegistryHandler = errors.New("received nil input value: token registry handler")
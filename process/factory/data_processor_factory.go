@@ -6,8 +6,15 @@ import (
 	"github.com/ElrondNetwork/covalent-indexer-go/process/accounts"
 	blockCovalent "github.com/ElrondNetwork/covalent-indexer-go/process/block"
 	"github.com/ElrondNetwork/covalent-indexer-go/process/block/miniblocks"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/calltree"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/contracts"
 	"github.com/ElrondNetwork/covalent-indexer-go/process/logs"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/peers"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/ratings"
 	"github.com/ElrondNetwork/covalent-indexer-go/process/receipts"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/registry"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/staking"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/tokens"
 	"github.com/ElrondNetwork/covalent-indexer-go/process/transactions"
 	"github.com/ElrondNetwork/elrond-go-core/core"
 	"github.com/ElrondNetwork/elrond-go-core/hashing"
@@ -22,6 +29,7 @@ type ArgsDataProcessor struct {
 	Hasher           hashing.Hasher
 	Marshaller       marshal.Marshalizer
 	ShardCoordinator process.ShardCoordinator
+	FeeCalculator    process.FeeCalculator
 }
 
 // CreateDataProcessor creates a new data handler instance of type data processor
@@ -36,36 +44,73 @@ func CreateDataProcessor(args *ArgsDataProcessor) (covalent.DataHandler, error)
 		return nil, err
 	}
 
-	transactionsHandler, err := transactions.NewTransactionProcessor(args.PubKeyConvertor, args.Hasher, args.Marshaller)
+	transactionsHandler, err := transactions.NewTransactionProcessor(args.PubKeyConvertor, args.Hasher, args.Marshaller, args.FeeCalculator)
 	if err != nil {
 		return nil, err
 	}
 
-	receiptsHandler, err := receipts.NewReceiptsProcessor(args.PubKeyConvertor)
+	receiptsHandler, err := receipts.NewReceiptsProcessor(args.PubKeyConvertor, args.Hasher, args.Marshaller)
 	if err != nil {
 		return nil, err
 	}
 
-	scResultsHandler, err := transactions.NewSCResultsProcessor(args.PubKeyConvertor)
+	scResultsHandler, err := transactions.NewSCResultsProcessor(args.PubKeyConvertor, args.Hasher, args.Marshaller)
 	if err != nil {
 		return nil, err
 	}
 
-	logHandler, err := logs.NewLogsProcessor(args.PubKeyConvertor)
+	logHandler, err := logs.NewLogsProcessor(args.PubKeyConvertor, args.Marshaller)
 	if err != nil {
 		return nil, err
 	}
 
-	accountsHandler, err := accounts.NewAccountsProcessor(args.ShardCoordinator, args.Accounts, args.PubKeyConvertor)
+	accountsHandler, err := accounts.NewAccountsProcessor(args.ShardCoordinator, args.Accounts, args.PubKeyConvertor, args.Marshaller)
 	if err != nil {
 		return nil, err
 	}
 
-	return process.NewDataProcessor(
-		blockHandler,
-		transactionsHandler,
-		scResultsHandler,
-		receiptsHandler,
-		logHandler,
-		accountsHandler)
+	ratingsHandler := ratings.NewRatingsProcessor()
+
+	tokensHandler, err := tokens.NewTokenTransfersProcessor(args.PubKeyConvertor, args.Marshaller)
+	if err != nil {
+		return nil, err
+	}
+
+	peersHandler, err := peers.NewPeerChangesProcessor(args.Hasher, args.Marshaller)
+	if err != nil {
+		return nil, err
+	}
+
+	contractsHandler, err := contracts.NewContractsProcessor(args.ShardCoordinator, args.PubKeyConvertor, args.Hasher)
+	if err != nil {
+		return nil, err
+	}
+
+	callTreesHandler := calltree.NewCallTreeProcessor()
+
+	stakingHandler, err := staking.NewStakingProcessor(args.PubKeyConvertor)
+	if err != nil {
+		return nil, err
+	}
+
+	registryHandler, err := registry.NewTokenRegistryProcessor(args.PubKeyConvertor)
+	if err != nil {
+		return nil, err
+	}
+
+	return process.NewDataProcessor(&process.ArgsDataProcessor{
+		BlockHandler:       blockHandler,
+		TransactionHandler: transactionsHandler,
+		SCResultsHandler:   scResultsHandler,
+		ReceiptHandler:     receiptsHandler,
+		LogHandler:         logHandler,
+		AccountsHandler:    accountsHandler,
+		RatingsHandler:     ratingsHandler,
+		TokensHandler:      tokensHandler,
+		PeersHandler:       peersHandler,
+		ContractsHandler:   contractsHandler,
+		CallTreesHandler:   callTreesHandler,
+		StakingHandler:     stakingHandler,
+		RegistryHandler:    registryHandler,
+	})
 }
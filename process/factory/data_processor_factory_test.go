@@ -0,0 +1,174 @@
+package factory_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/covalent-indexer-go/process/economics"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/factory"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/utility"
+	"github.com/ElrondNetwork/covalent-indexer-go/testscommon/mock"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/core/pubkeyConverter"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/block"
+	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
+	"github.com/ElrondNetwork/elrond-go-core/data/receipt"
+	"github.com/ElrondNetwork/elrond-go-core/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/ElrondNetwork/elrond-go-core/hashing/blake2b"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/stretchr/testify/require"
+)
+
+const numTxsPerMiniBlock = 20
+
+func createArgsDataProcessor(t *testing.T) *factory.ArgsDataProcessor {
+	pubKeyConverter, err := pubkeyConverter.NewBech32PubkeyConverter(32, logger.GetOrCreate("test"))
+	require.Nil(t, err)
+
+	feeCalculator, err := economics.NewFeeCalculator(&economics.EconomicsConfig{
+		FeeSettings: economics.FeeSettings{MinGasLimit: "50000", GasPerDataByte: "1500", GasPriceModifier: 0.01},
+	})
+	require.Nil(t, err)
+
+	return &factory.ArgsDataProcessor{
+		PubKeyConvertor: pubKeyConverter,
+		Accounts: &mock.AccountsAdapterStub{
+			LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+				return &mock.UserAccountMock{Address: address}, nil
+			},
+		},
+		Hasher:           blake2b.NewBlake2b(),
+		Marshaller:       &marshal.GogoProtoMarshalizer{},
+		ShardCoordinator: &mock.ShardCoordinatorMock{},
+		FeeCalculator:    feeCalculator,
+	}
+}
+
+func address(idx int) []byte {
+	return bytes.Repeat([]byte{byte(idx)}, 32)
+}
+
+func hash(prefix string, idx int) []byte {
+	return []byte(fmt.Sprintf("%s%0*d", prefix, 32-len(prefix), idx))
+}
+
+func createArgsSaveBlockData() *indexer.ArgsSaveBlockData {
+	pool := &indexer.Pool{
+		Txs:      make(map[string]data.TransactionHandler),
+		Scrs:     make(map[string]data.TransactionHandler),
+		Rewards:  make(map[string]data.TransactionHandler),
+		Invalid:  make(map[string]data.TransactionHandler),
+		Receipts: make(map[string]data.TransactionHandler),
+		Logs:     make([]*data.LogData, 0),
+	}
+	txsMiniBlock := &block.MiniBlock{Type: block.TxBlock}
+	scrsMiniBlock := &block.MiniBlock{Type: block.SmartContractResultBlock}
+	receiptsMiniBlock := &block.MiniBlock{Type: block.ReceiptBlock}
+
+	for i := 0; i < numTxsPerMiniBlock; i++ {
+		txHash := hash("tx", i)
+		pool.Txs[string(txHash)] = &transaction.Transaction{
+			Nonce:    uint64(i),
+			Value:    big.NewInt(int64(i)),
+			SndAddr:  address(i),
+			RcvAddr:  address(i + 1),
+			GasPrice: 1000000000,
+			GasLimit: 500000,
+			Data:     []byte("ESDTTransfer@" + hex.EncodeToString([]byte("TKN-abcdef")) + "@0a"),
+		}
+		txsMiniBlock.TxHashes = append(txsMiniBlock.TxHashes, txHash)
+
+		scrHash := hash("scr", i)
+		pool.Scrs[string(scrHash)] = &smartContractResult.SmartContractResult{
+			Nonce:          uint64(i + 1),
+			Value:          big.NewInt(int64(i)),
+			SndAddr:        address(i + 1),
+			RcvAddr:        address(i),
+			Data:           []byte("@6f6b"),
+			PrevTxHash:     txHash,
+			OriginalTxHash: txHash,
+		}
+		scrsMiniBlock.TxHashes = append(scrsMiniBlock.TxHashes, scrHash)
+
+		notNotarizedSCRHash := hash("intra scr", i)
+		pool.Scrs[string(notNotarizedSCRHash)] = &smartContractResult.SmartContractResult{
+			SndAddr:        address(i + 1),
+			RcvAddr:        address(i + 2),
+			Value:          big.NewInt(0),
+			PrevTxHash:     txHash,
+			OriginalTxHash: txHash,
+		}
+
+		receiptHash := hash("receipt", i)
+		pool.Receipts[string(receiptHash)] = &receipt.Receipt{
+			Value:   big.NewInt(int64(i)),
+			SndAddr: address(i),
+			Data:    []byte("refundedGas"),
+			TxHash:  txHash,
+		}
+		receiptsMiniBlock.TxHashes = append(receiptsMiniBlock.TxHashes, receiptHash)
+
+		pool.Logs = append(pool.Logs, &data.LogData{
+			TxHash: string(txHash),
+			LogHandler: &transaction.Log{
+				Address: address(i),
+				Events: []*transaction.Event{
+					{
+						Address:    address(i),
+						Identifier: []byte(core.BuiltInFunctionESDTLocalMint),
+						Topics:     [][]byte{[]byte("TKN-abcdef"), {}, big.NewInt(100).Bytes()},
+					},
+				},
+			},
+		})
+	}
+
+	return &indexer.ArgsSaveBlockData{
+		HeaderHash: hash("header", 0),
+		Body: &block.Body{
+			MiniBlocks: []*block.MiniBlock{txsMiniBlock, scrsMiniBlock, receiptsMiniBlock},
+		},
+		Header: &block.Header{
+			Nonce:     1,
+			Round:     2,
+			TimeStamp: 3,
+			RootHash:  hash("root hash", 0),
+			PrevHash:  hash("prev hash", 0),
+		},
+		SignersIndexes:   []uint64{0, 1, 2},
+		TransactionsPool: pool,
+	}
+}
+
+func TestCreateDataProcessor_ProcessData_SameInput_ExpectByteIdenticalEncoding(t *testing.T) {
+	t.Parallel()
+
+	var expectedEncoding []byte
+	for i := 0; i < 10; i++ {
+		dataProcessor, err := factory.CreateDataProcessor(createArgsDataProcessor(t))
+		require.Nil(t, err)
+
+		blockResult, err := dataProcessor.ProcessData(createArgsSaveBlockData())
+		require.Nil(t, err)
+		require.Len(t, blockResult.SCResults, 2*numTxsPerMiniBlock)
+		require.Len(t, blockResult.Receipts, numTxsPerMiniBlock)
+		require.NotEmpty(t, blockResult.StateChanges)
+		require.NotEmpty(t, blockResult.TokenTransfers)
+
+		encoding, err := utility.Encode(blockResult)
+		require.Nil(t, err)
+
+		if expectedEncoding == nil {
+			expectedEncoding = encoding
+			continue
+		}
+		require.Equal(t, expectedEncoding, encoding)
+	}
+}
@@ -2,6 +2,7 @@ package process
 
 import (
 	"io"
+	"math/big"
 
 	"github.com/ElrondNetwork/covalent-indexer-go/schema"
 	"github.com/ElrondNetwork/elrond-go-core/data"
@@ -11,6 +12,7 @@ import (
 // BlockHandler defines what a block processor shall do
 type BlockHandler interface {
 	ProcessBlock(args *indexer.ArgsSaveBlockData) (*schema.Block, error)
+	IsInterfaceNil() bool
 }
 
 // MiniBlockHandler defines what a mini blocks processor shall do
@@ -25,29 +27,119 @@ type TransactionHandler interface {
 		headerHash []byte,
 		bodyHandler data.BodyHandler,
 		pool *indexer.Pool) ([]*schema.Transaction, error)
+	IsInterfaceNil() bool
 }
 
 // SCResultsHandler defines what a smart contract processor shall do
 type SCResultsHandler interface {
-	ProcessSCRs(transactions map[string]data.TransactionHandler, timeStamp uint64) []*schema.SCResult
+	ProcessSCRs(
+		header data.HeaderHandler,
+		headerHash []byte,
+		bodyHandler data.BodyHandler,
+		pool *indexer.Pool) ([]*schema.SCResult, error)
+	IsInterfaceNil() bool
 }
 
 // ReceiptHandler defines what a receipt processor shall do
 type ReceiptHandler interface {
-	ProcessReceipts(receipts map[string]data.TransactionHandler, timeStamp uint64) []*schema.Receipt
+	ProcessReceipts(
+		header data.HeaderHandler,
+		headerHash []byte,
+		bodyHandler data.BodyHandler,
+		receipts map[string]data.TransactionHandler) ([]*schema.Receipt, error)
+	IsInterfaceNil() bool
 }
 
 // LogHandler defines what a log processor shall do
 type LogHandler interface {
-	ProcessLogs(logs []*data.LogData) []*schema.Log
+	ProcessLogs(logs []*data.LogData, blockHash []byte) []*schema.Log
+	IsInterfaceNil() bool
+}
+
+// TokenTransfersHandler defines what a token transfers processor shall do
+type TokenTransfersHandler interface {
+	ProcessTokenTransfers(
+		txs map[string]data.TransactionHandler,
+		scrs map[string]data.TransactionHandler,
+		logs []*data.LogData) []*schema.TokenTransfer
+	IsInterfaceNil() bool
+}
+
+// PeerChangesHandler defines what a peer changes processor shall do
+type PeerChangesHandler interface {
+	ProcessPeerChanges(
+		header data.HeaderHandler,
+		headerHash []byte,
+		bodyHandler data.BodyHandler) ([]*schema.PeerChange, error)
+	IsInterfaceNil() bool
+}
+
+// ContractEventsHandler defines what a smart contract events processor shall do
+type ContractEventsHandler interface {
+	ProcessContractEvents(
+		txs map[string]data.TransactionHandler,
+		scrs map[string]data.TransactionHandler,
+		logs []*data.LogData) []*schema.ContractEvent
+	IsInterfaceNil() bool
+}
+
+// CallTreesHandler defines what a smart contract call trees processor shall do
+type CallTreesHandler interface {
+	ProcessCallTrees(scrs []*schema.SCResult) []*schema.CallTree
+	IsInterfaceNil() bool
+}
+
+// StakingEventsHandler defines what a staking and delegation events processor shall do
+type StakingEventsHandler interface {
+	ProcessStakingEvents(
+		header data.HeaderHandler,
+		txs map[string]data.TransactionHandler,
+		scrs map[string]data.TransactionHandler,
+		logs []*data.LogData) []*schema.StakingEvent
+	IsInterfaceNil() bool
+}
+
+// TokenRegistryHandler defines what a token registry processor shall do
+type TokenRegistryHandler interface {
+	ProcessTokenRegistryEvents(
+		header data.HeaderHandler,
+		txs map[string]data.TransactionHandler,
+		scrs map[string]data.TransactionHandler,
+		logs []*data.LogData) []*schema.TokenRegistryEvent
+	EnrichTokenTransfers(transfers []*schema.TokenTransfer)
+	RevertTokenRegistry(blockTimestamp uint64)
+	IsInterfaceNil() bool
+}
+
+// RatingsHandler defines what a validators rating processor shall do
+type RatingsHandler interface {
+	ProcessRatings(indexID string, ratings []*indexer.ValidatorRatingInfo) ([]*schema.ValidatorRating, error)
+	IsInterfaceNil() bool
 }
 
 // AccountsHandler defines what an account processor shall do
 type AccountsHandler interface {
+	SaveAccountsSnapshot(blockTimestamp uint64, accounts []data.UserAccountHandler)
 	ProcessAccounts(
 		processedTxs []*schema.Transaction,
 		processedSCRs []*schema.SCResult,
-		processedReceipts []*schema.Receipt) []*schema.AccountBalanceUpdate
+		processedReceipts []*schema.Receipt,
+		processedLogs []*schema.Log,
+		tokenTransfers []*schema.TokenTransfer,
+		blockTimestamp uint64) []*schema.AccountBalanceUpdate
+	RevertAccounts(blockTimestamp uint64)
+	IsInterfaceNil() bool
+}
+
+// UserAccountDetailsHandler defines the getters of the user account details which are not part of
+// data.UserAccountHandler, but are implemented by the user accounts of the node
+type UserAccountDetailsHandler interface {
+	GetUserName() []byte
+	GetOwnerAddress() []byte
+	GetCodeHash() []byte
+	GetRootHash() []byte
+	GetCodeMetadata() []byte
+	GetDeveloperReward() *big.Int
 }
 
 // ShardCoordinator defines what a shard coordinator shall do
@@ -63,3 +155,30 @@ type WSConn interface {
 	ReadMessage() (messageType int, p []byte, err error)
 	WriteMessage(messageType int, data []byte) error
 }
+
+// ScheduledDataHandler defines the getters of the scheduled miniblocks execution data, which are only
+// available starting with header version 2
+type ScheduledDataHandler interface {
+	GetScheduledRootHash() []byte
+	GetScheduledAccumulatedFees() *big.Int
+	GetScheduledDeveloperFees() *big.Int
+	GetScheduledGasProvided() uint64
+	GetScheduledGasPenalized() uint64
+	GetScheduledGasRefunded() uint64
+}
+
+// FeeCalculator defines what a transactions fee calculator shall do
+type FeeCalculator interface {
+	ComputeGasLimit(tx data.TransactionWithFeeHandler) uint64
+	ComputeMoveBalanceFee(tx data.TransactionWithFeeHandler) *big.Int
+	ComputeFeeForProcessing(tx data.TransactionWithFeeHandler, gasToUse uint64) *big.Int
+	ComputeTxFee(tx data.TransactionWithFeeHandler) *big.Int
+	ComputeGasUsedAndFeeBasedOnRefundValue(tx data.TransactionWithFeeHandler, refundValue *big.Int) (uint64, *big.Int)
+	IsInterfaceNil() bool
+}
+
+// FeeCalculatorStatusHandler defines a fee calculator which can be disabled. Fee calculators which do not implement
+// it, such as the one provided by the node, are considered enabled
+type FeeCalculatorStatusHandler interface {
+	IsEnabled() bool
+}
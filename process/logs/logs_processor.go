@@ -7,29 +7,39 @@ import (
 	"github.com/ElrondNetwork/elrond-go-core/core"
 	"github.com/ElrondNetwork/elrond-go-core/core/check"
 	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
 )
 
+var log = logger.GetOrCreate("covalent/process/logs")
+
 type logsProcessor struct {
 	pubKeyConverter core.PubkeyConverter
+	marshaller      marshal.Marshalizer
 }
 
 // NewLogsProcessor creates a new instance of logs processor
-func NewLogsProcessor(pubKeyConverter core.PubkeyConverter) (*logsProcessor, error) {
+func NewLogsProcessor(pubKeyConverter core.PubkeyConverter, marshaller marshal.Marshalizer) (*logsProcessor, error) {
 	if check.IfNil(pubKeyConverter) {
 		return nil, covalent.ErrNilPubKeyConverter
 	}
+	if check.IfNil(marshaller) {
+		return nil, covalent.ErrNilMarshaller
+	}
 
 	return &logsProcessor{
 		pubKeyConverter: pubKeyConverter,
+		marshaller:      marshaller,
 	}, nil
 }
 
-// ProcessLogs converts logs data to a specific structure defined by avro schema
-func (lp *logsProcessor) ProcessLogs(logs []*data.LogData) []*schema.Log {
+// ProcessLogs converts logs data of the block with the given hash to a specific structure defined by avro schema.
+// The events of non/semi fungible tokens creation, quantity changes and attributes updates are also decoded
+func (lp *logsProcessor) ProcessLogs(logs []*data.LogData, blockHash []byte) []*schema.Log {
 	allLogs := make([]*schema.Log, 0, len(logs))
 
-	for _, currLog := range logs {
-		processedLog := lp.processLog(currLog)
+	for idx, currLog := range logs {
+		processedLog := lp.processLog(currLog, idx, blockHash)
 		if processedLog != nil {
 			allLogs = append(allLogs, processedLog)
 		}
@@ -38,23 +48,25 @@ func (lp *logsProcessor) ProcessLogs(logs []*data.LogData) []*schema.Log {
 	return allLogs
 }
 
-func (lp *logsProcessor) processLog(logData *data.LogData) *schema.Log {
+func (lp *logsProcessor) processLog(logData *data.LogData, index int, blockHash []byte) *schema.Log {
 	if logData == nil || check.IfNil(logData.LogHandler) {
 		return nil
 	}
 
 	return &schema.Log{
-		ID:      []byte(logData.TxHash),
-		Address: utility.EncodePubKey(lp.pubKeyConverter, logData.LogHandler.GetAddress()),
-		Events:  lp.processEvents(logData.LogHandler.GetLogEvents()),
+		ID:        []byte(logData.TxHash),
+		BlockHash: blockHash,
+		Index:     int32(index),
+		Address:   utility.EncodePubKey(lp.pubKeyConverter, logData.LogHandler.GetAddress()),
+		Events:    lp.processEvents(logData.LogHandler.GetLogEvents()),
 	}
 }
 
 func (lp *logsProcessor) processEvents(events []data.EventHandler) []*schema.Event {
 	allEvents := make([]*schema.Event, 0, len(events))
 
-	for _, currEvent := range events {
-		processedEvent := lp.processEvent(currEvent)
+	for idx, currEvent := range events {
+		processedEvent := lp.processEvent(currEvent, idx)
 
 		if processedEvent != nil {
 			allEvents = append(allEvents, processedEvent)
@@ -64,7 +76,7 @@ func (lp *logsProcessor) processEvents(events []data.EventHandler) []*schema.Eve
 	return allEvents
 }
 
-func (lp *logsProcessor) processEvent(event data.EventHandler) *schema.Event {
+func (lp *logsProcessor) processEvent(event data.EventHandler, index int) *schema.Event {
 	if check.IfNil(event) {
 		return nil
 	}
@@ -74,5 +86,12 @@ func (lp *logsProcessor) processEvent(event data.EventHandler) *schema.Event {
 		Identifier: event.GetIdentifier(),
 		Topics:     event.GetTopics(),
 		Data:       event.GetData(),
+		Index:      int32(index),
+		NFTEvent:   lp.processNFTEvent(event),
 	}
 }
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (lp *logsProcessor) IsInterfaceNil() bool {
+	return lp == nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go-core/core/check"
 	"github.com/ElrondNetwork/elrond-go-core/data"
 	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
 	"github.com/stretchr/testify/require"
 )
 
@@ -20,18 +21,24 @@ func TestNewLogsProcessor(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		args        func() core.PubkeyConverter
+		args        func() (core.PubkeyConverter, marshal.Marshalizer)
 		expectedErr error
 	}{
 		{
-			args: func() core.PubkeyConverter {
-				return nil
+			args: func() (core.PubkeyConverter, marshal.Marshalizer) {
+				return nil, &mock.MarshallerStub{}
 			},
 			expectedErr: covalent.ErrNilPubKeyConverter,
 		},
 		{
-			args: func() core.PubkeyConverter {
-				return &mock.PubKeyConverterStub{}
+			args: func() (core.PubkeyConverter, marshal.Marshalizer) {
+				return &mock.PubKeyConverterStub{}, nil
+			},
+			expectedErr: covalent.ErrNilMarshaller,
+		},
+		{
+			args: func() (core.PubkeyConverter, marshal.Marshalizer) {
+				return &mock.PubKeyConverterStub{}, &mock.MarshallerStub{}
 			},
 			expectedErr: nil,
 		},
@@ -44,7 +51,7 @@ func TestNewLogsProcessor(t *testing.T) {
 }
 
 func TestLogsProcessor_ProcessLogs_OneNilLog_ExpectZeroProcessedLogs(t *testing.T) {
-	lp, _ := logs.NewLogsProcessor(&mock.PubKeyConverterStub{})
+	lp, _ := logs.NewLogsProcessor(&mock.PubKeyConverterStub{}, &mock.MarshallerStub{})
 
 	logsAndEvents := []*data.LogData{
 		{
@@ -53,13 +60,13 @@ func TestLogsProcessor_ProcessLogs_OneNilLog_ExpectZeroProcessedLogs(t *testing.
 		},
 	}
 
-	ret := lp.ProcessLogs(logsAndEvents)
+	ret := lp.ProcessLogs(logsAndEvents, []byte("block hash"))
 	require.Len(t, ret, 0)
 
 }
 
 func TestLogsProcessor_ProcessLogs_OneLog_NoEvent_ExpectOneProcessedLogsAndZeroEvents(t *testing.T) {
-	lp, _ := logs.NewLogsProcessor(&mock.PubKeyConverterStub{})
+	lp, _ := logs.NewLogsProcessor(&mock.PubKeyConverterStub{}, &mock.MarshallerStub{})
 
 	log := &transaction.Log{
 		Address: testscommon.GenerateRandomBytes(),
@@ -72,14 +79,14 @@ func TestLogsProcessor_ProcessLogs_OneLog_NoEvent_ExpectOneProcessedLogsAndZeroE
 		},
 	}
 
-	ret := lp.ProcessLogs(logsAndEvents)
+	ret := lp.ProcessLogs(logsAndEvents, []byte("block hash"))
 
 	require.Len(t, ret, 1)
 	require.Len(t, ret[0].Events, 0)
 }
 
 func TestLogsProcessor_ProcessLogs_OneLog_OneEvent_ExpectOneProcessedLogAndOneEvent(t *testing.T) {
-	lp, _ := logs.NewLogsProcessor(&mock.PubKeyConverterStub{})
+	lp, _ := logs.NewLogsProcessor(&mock.PubKeyConverterStub{}, &mock.MarshallerStub{})
 
 	event := generateRandomEvent()
 	log := &transaction.Log{
@@ -94,7 +101,7 @@ func TestLogsProcessor_ProcessLogs_OneLog_OneEvent_ExpectOneProcessedLogAndOneEv
 		},
 	}
 
-	ret := lp.ProcessLogs(logsAndEvents)
+	ret := lp.ProcessLogs(logsAndEvents, []byte("block hash"))
 	require.Len(t, ret, 1)
 	require.Len(t, ret[0].Events, 1)
 
@@ -102,7 +109,7 @@ func TestLogsProcessor_ProcessLogs_OneLog_OneEvent_ExpectOneProcessedLogAndOneEv
 }
 
 func TestLogsProcessor_ProcessLogs_ThreeLogs_FourEvents_ExpectTwoProcessedLogsAndThreeEvents(t *testing.T) {
-	lp, _ := logs.NewLogsProcessor(&mock.PubKeyConverterStub{})
+	lp, _ := logs.NewLogsProcessor(&mock.PubKeyConverterStub{}, &mock.MarshallerStub{})
 
 	event1 := generateRandomEvent()
 	event2 := generateRandomEvent()
@@ -132,11 +139,17 @@ func TestLogsProcessor_ProcessLogs_ThreeLogs_FourEvents_ExpectTwoProcessedLogsAn
 		},
 	}
 
-	ret := lp.ProcessLogs(logsAndEvents)
+	ret := lp.ProcessLogs(logsAndEvents, []byte("block hash"))
 	require.Len(t, ret, 2)
 	require.Len(t, ret[0].Events, 2)
 	require.Len(t, ret[1].Events, 1)
 
+	require.Equal(t, int32(0), ret[0].Index)
+	require.Equal(t, int32(2), ret[1].Index)
+	require.Equal(t, int32(0), ret[0].Events[0].Index)
+	require.Equal(t, int32(2), ret[0].Events[1].Index)
+	require.Equal(t, int32(0), ret[1].Events[0].Index)
+
 	requireProcessedLogEqual(t, ret[0], log1, "hash1", &mock.PubKeyConverterStub{})
 	requireProcessedLogEqual(t, ret[1], log2, "hash3", &mock.PubKeyConverterStub{})
 }
@@ -158,6 +171,7 @@ func requireProcessedLogEqual(
 	pubKeyConverter core.PubkeyConverter) {
 
 	require.Equal(t, []byte(hash), processedLog.ID)
+	require.Equal(t, []byte("block hash"), processedLog.BlockHash)
 	require.Equal(t, utility.EncodePubKey(pubKeyConverter, log.GetAddress()), processedLog.Address)
 
 	notNilEvents := getNotNilEvents(log.GetEvents())
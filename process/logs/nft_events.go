@@ -0,0 +1,94 @@
+package logs
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/covalent-indexer-go/process/utility"
+	"github.com/ElrondNetwork/covalent-indexer-go/schema"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/esdt"
+)
+
+const (
+	// NFTCreate defines the type of the event emitted when a non/semi fungible token is created
+	NFTCreate = "create"
+	// NFTAddQuantity defines the type of the event emitted when the quantity of a semi fungible token is increased
+	NFTAddQuantity = "addQuantity"
+	// NFTBurn defines the type of the event emitted when a quantity of a non/semi fungible token is burned
+	NFTBurn = "burn"
+	// NFTUpdateAttributes defines the type of the event emitted when the attributes of a non/semi fungible token
+	// are updated
+	NFTUpdateAttributes = "updateAttributes"
+)
+
+const (
+	tokenIdentifierTopicIndex = 0
+	tokenNonceTopicIndex      = 1
+	quantityTopicIndex        = 2
+	extraDataTopicIndex       = 3
+	minNFTEventTopics         = 3
+)
+
+// nftEventTypes maps the identifiers of the esdt log events emitted by the node to nft event types
+var nftEventTypes = map[string]string{
+	core.BuiltInFunctionESDTNFTCreate:           NFTCreate,
+	core.BuiltInFunctionESDTNFTAddQuantity:      NFTAddQuantity,
+	core.BuiltInFunctionESDTNFTBurn:             NFTBurn,
+	core.BuiltInFunctionESDTNFTUpdateAttributes: NFTUpdateAttributes,
+}
+
+// processNFTEvent decodes the nft log events, having the topics tokenIdentifier@nonce@quantity[@extraData]. The extra
+// data of a creation is the marshalled token, holding its metadata, while the one of an attributes update, if sent by
+// the node, holds the new attributes
+func (lp *logsProcessor) processNFTEvent(event data.EventHandler) *schema.NFTEvent {
+	eventType, isNFTEvent := nftEventTypes[string(event.GetIdentifier())]
+	topics := event.GetTopics()
+	if !isNFTEvent || len(topics) < minNFTEventTopics {
+		return nil
+	}
+
+	nftEvent := &schema.NFTEvent{
+		Type:     eventType,
+		Token:    topics[tokenIdentifierTopicIndex],
+		Nonce:    big.NewInt(0).SetBytes(topics[tokenNonceTopicIndex]).Int64(),
+		Quantity: utility.GetBytes(big.NewInt(0).SetBytes(topics[quantityTopicIndex])),
+	}
+	if len(topics) <= extraDataTopicIndex {
+		return nftEvent
+	}
+
+	switch eventType {
+	case NFTCreate:
+		nftEvent.Metadata = lp.processNFTMetadata(topics[extraDataTopicIndex])
+	case NFTUpdateAttributes:
+		nftEvent.Attributes = topics[extraDataTopicIndex]
+	}
+
+	return nftEvent
+}
+
+func (lp *logsProcessor) processNFTMetadata(marshalledToken []byte) *schema.NFTMetadata {
+	token := &esdt.ESDigitalToken{}
+	err := lp.marshaller.Unmarshal(token, marshalledToken)
+	if err != nil || token.TokenMetaData == nil {
+		log.Warn("cannot decode nft metadata", "error", err)
+		return nil
+	}
+
+	metadata := &schema.NFTMetadata{
+		Name:       token.TokenMetaData.Name,
+		Royalties:  int32(token.TokenMetaData.Royalties),
+		Hash:       token.TokenMetaData.Hash,
+		Attributes: token.TokenMetaData.Attributes,
+		URIs:       token.TokenMetaData.URIs,
+	}
+	if metadata.URIs == nil {
+		metadata.URIs = make([][]byte, 0)
+	}
+	if len(token.TokenMetaData.Creator) > 0 {
+		metadata.Creator = utility.EncodePubKey(lp.pubKeyConverter, token.TokenMetaData.Creator)
+	}
+
+	return metadata
+}
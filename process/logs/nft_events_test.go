@@ -0,0 +1,149 @@
+package logs_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/covalent-indexer-go/process/logs"
+	"github.com/ElrondNetwork/covalent-indexer-go/schema"
+	"github.com/ElrondNetwork/covalent-indexer-go/testscommon/mock"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/esdt"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogsProcessor_ProcessLogs_NFTEvents(t *testing.T) {
+	t.Parallel()
+
+	token := []byte("NFT-abcdef")
+	marshalledToken, _ := json.Marshal(&esdt.ESDigitalToken{
+		Value: big.NewInt(1),
+		TokenMetaData: &esdt.MetaData{
+			Nonce:      5,
+			Name:       []byte("name"),
+			Creator:    []byte("creator"),
+			Royalties:  750,
+			Hash:       []byte("hash"),
+			URIs:       [][]byte{[]byte("uri1"), []byte("uri2")},
+			Attributes: []byte("attributes"),
+		},
+	})
+
+	tests := []struct {
+		name             string
+		identifier       string
+		topics           [][]byte
+		expectedNFTEvent *schema.NFTEvent
+	}{
+		{
+			name:       "create",
+			identifier: core.BuiltInFunctionESDTNFTCreate,
+			topics:     [][]byte{token, {5}, {1}, marshalledToken},
+			expectedNFTEvent: &schema.NFTEvent{
+				Type:     logs.NFTCreate,
+				Token:    token,
+				Nonce:    5,
+				Quantity: []byte{1},
+				Metadata: &schema.NFTMetadata{
+					Name:       []byte("name"),
+					Creator:    []byte("erd1creator"),
+					Royalties:  750,
+					Hash:       []byte("hash"),
+					Attributes: []byte("attributes"),
+					URIs:       [][]byte{[]byte("uri1"), []byte("uri2")},
+				},
+			},
+		},
+		{
+			name:       "create with invalid metadata",
+			identifier: core.BuiltInFunctionESDTNFTCreate,
+			topics:     [][]byte{token, {5}, {1}, []byte("invalid")},
+			expectedNFTEvent: &schema.NFTEvent{
+				Type:     logs.NFTCreate,
+				Token:    token,
+				Nonce:    5,
+				Quantity: []byte{1},
+			},
+		},
+		{
+			name:       "add quantity",
+			identifier: core.BuiltInFunctionESDTNFTAddQuantity,
+			topics:     [][]byte{token, {5}, {10}},
+			expectedNFTEvent: &schema.NFTEvent{
+				Type:     logs.NFTAddQuantity,
+				Token:    token,
+				Nonce:    5,
+				Quantity: []byte{10},
+			},
+		},
+		{
+			name:       "burn",
+			identifier: core.BuiltInFunctionESDTNFTBurn,
+			topics:     [][]byte{token, {5}, {2}},
+			expectedNFTEvent: &schema.NFTEvent{
+				Type:     logs.NFTBurn,
+				Token:    token,
+				Nonce:    5,
+				Quantity: []byte{2},
+			},
+		},
+		{
+			name:       "update attributes, without new attributes",
+			identifier: core.BuiltInFunctionESDTNFTUpdateAttributes,
+			topics:     [][]byte{token, {5}, {}},
+			expectedNFTEvent: &schema.NFTEvent{
+				Type:     logs.NFTUpdateAttributes,
+				Token:    token,
+				Nonce:    5,
+				Quantity: []byte{},
+			},
+		},
+		{
+			name:       "update attributes, with new attributes",
+			identifier: core.BuiltInFunctionESDTNFTUpdateAttributes,
+			topics:     [][]byte{token, {5}, {}, []byte("new attributes")},
+			expectedNFTEvent: &schema.NFTEvent{
+				Type:       logs.NFTUpdateAttributes,
+				Token:      token,
+				Nonce:      5,
+				Quantity:   []byte{},
+				Attributes: []byte("new attributes"),
+			},
+		},
+		{
+			name:             "not enough topics",
+			identifier:       core.BuiltInFunctionESDTNFTBurn,
+			topics:           [][]byte{token, {5}},
+			expectedNFTEvent: nil,
+		},
+		{
+			name:             "fungible token event",
+			identifier:       core.BuiltInFunctionESDTLocalMint,
+			topics:           [][]byte{token, {}, {2}},
+			expectedNFTEvent: nil,
+		},
+	}
+
+	for _, currTest := range tests {
+		lp, _ := logs.NewLogsProcessor(&mock.PubKeyConverterStub{}, &mock.MarshallerStub{})
+
+		logsAndEvents := []*data.LogData{
+			{
+				TxHash: "hash",
+				LogHandler: &transaction.Log{
+					Events: []*transaction.Event{
+						{Address: []byte("creator"), Identifier: []byte(currTest.identifier), Topics: currTest.topics},
+					},
+				},
+			},
+		}
+
+		ret := lp.ProcessLogs(logsAndEvents, []byte("block hash"))
+		require.Len(t, ret, 1, currTest.name)
+		require.Len(t, ret[0].Events, 1, currTest.name)
+		require.Equal(t, currTest.expectedNFTEvent, ret[0].Events[0].NFTEvent, currTest.name)
+	}
+}
@@ -0,0 +1,108 @@
+package peers
+
+import (
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/covalent-indexer-go/schema"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/block"
+	"github.com/ElrondNetwork/elrond-go-core/hashing"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+)
+
+var log = logger.GetOrCreate("covalent/process/peers")
+
+type peerChangesProcessor struct {
+	hasher     hashing.Hasher
+	marshaller marshal.Marshalizer
+}
+
+// NewPeerChangesProcessor creates a new instance of peer changes processor
+func NewPeerChangesProcessor(hasher hashing.Hasher, marshaller marshal.Marshalizer) (*peerChangesProcessor, error) {
+	if check.IfNil(hasher) {
+		return nil, covalent.ErrNilHasher
+	}
+	if check.IfNil(marshaller) {
+		return nil, covalent.ErrNilMarshaller
+	}
+
+	return &peerChangesProcessor{
+		hasher:     hasher,
+		marshaller: marshaller,
+	}, nil
+}
+
+// ProcessPeerChanges converts the validators state changes from the peer miniblocks of the body to a specific
+// structure defined by avro schema. Peer miniblocks are created by the metachain at the start of each epoch and,
+// instead of transactions hashes, they hold the marshalled validator info of each validator
+func (pcp *peerChangesProcessor) ProcessPeerChanges(
+	header data.HeaderHandler,
+	headerHash []byte,
+	bodyHandler data.BodyHandler,
+) ([]*schema.PeerChange, error) {
+	body, ok := bodyHandler.(*block.Body)
+	if !ok {
+		return nil, covalent.ErrBlockBodyAssertion
+	}
+
+	peerChanges := make([]*schema.PeerChange, 0)
+	for mbIndex, currMiniBlock := range body.MiniBlocks {
+		if currMiniBlock.Type != block.PeerBlock {
+			continue
+		}
+
+		peerChangesInCurrMB, err := pcp.processPeerChangesFromMiniBlock(currMiniBlock, mbIndex, header, headerHash)
+		if err != nil {
+			log.Warn("peerChangesProcessor.processPeerChangesFromMiniBlock", "error", err)
+			continue
+		}
+		peerChanges = append(peerChanges, peerChangesInCurrMB...)
+	}
+
+	return peerChanges, nil
+}
+
+func (pcp *peerChangesProcessor) processPeerChangesFromMiniBlock(
+	miniBlock *block.MiniBlock,
+	miniBlockIndex int,
+	header data.HeaderHandler,
+	blockHash []byte,
+) ([]*schema.PeerChange, error) {
+	miniBlockHash, err := core.CalculateHash(pcp.marshaller, pcp.hasher, miniBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	peerChanges := make([]*schema.PeerChange, 0, len(miniBlock.TxHashes))
+	for idx, validatorInfoBytes := range miniBlock.TxHashes {
+		validatorInfo := &ShardValidatorInfo{}
+		err = pcp.marshaller.Unmarshal(validatorInfo, validatorInfoBytes)
+		if err != nil {
+			log.Warn("peerChangesProcessor.processPeerChangesFromMiniBlock cannot unmarshal validator info",
+				"miniblock hash", miniBlockHash, "index", idx, "error", err)
+			continue
+		}
+
+		peerChanges = append(peerChanges, &schema.PeerChange{
+			BlockHash:        blockHash,
+			MiniBlockHash:    miniBlockHash,
+			MiniBlockIndex:   int32(miniBlockIndex),
+			IndexInMiniBlock: int32(idx),
+			Epoch:            int32(header.GetEpoch()),
+			PublicKey:        validatorInfo.PublicKey,
+			ShardID:          int32(validatorInfo.ShardId),
+			List:             validatorInfo.List,
+			Index:            int32(validatorInfo.Index),
+			TempRating:       int32(validatorInfo.TempRating),
+		})
+	}
+
+	return peerChanges, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (pcp *peerChangesProcessor) IsInterfaceNil() bool {
+	return pcp == nil
+}
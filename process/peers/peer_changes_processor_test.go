@@ -0,0 +1,109 @@
+package peers_test
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/peers"
+	"github.com/ElrondNetwork/covalent-indexer-go/schema"
+	"github.com/ElrondNetwork/covalent-indexer-go/testscommon/mock"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/data/block"
+	"github.com/ElrondNetwork/elrond-go-core/hashing"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPeerChangesProcessor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		hasher      hashing.Hasher
+		marshaller  marshal.Marshalizer
+		expectedErr error
+	}{
+		{
+			hasher:      nil,
+			marshaller:  &mock.MarshallerStub{},
+			expectedErr: covalent.ErrNilHasher,
+		},
+		{
+			hasher:      &mock.HasherMock{},
+			marshaller:  nil,
+			expectedErr: covalent.ErrNilMarshaller,
+		},
+		{
+			hasher:      &mock.HasherMock{},
+			marshaller:  &mock.MarshallerStub{},
+			expectedErr: nil,
+		},
+	}
+
+	for _, currTest := range tests {
+		_, err := peers.NewPeerChangesProcessor(currTest.hasher, currTest.marshaller)
+		require.Equal(t, currTest.expectedErr, err)
+	}
+}
+
+func TestPeerChangesProcessor_ProcessPeerChanges_InvalidBody_ExpectError(t *testing.T) {
+	t.Parallel()
+
+	pcp, _ := peers.NewPeerChangesProcessor(&mock.HasherMock{}, &mock.MarshallerStub{})
+
+	ret, err := pcp.ProcessPeerChanges(&block.MetaBlock{}, []byte("header hash"), nil)
+	require.Nil(t, ret)
+	require.Equal(t, covalent.ErrBlockBodyAssertion, err)
+}
+
+func TestPeerChangesProcessor_ProcessPeerChanges(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &marshal.GogoProtoMarshalizer{}
+	pcp, _ := peers.NewPeerChangesProcessor(&mock.HasherMock{}, marshaller)
+
+	validatorInfo1, _ := marshaller.Marshal(&peers.ShardValidatorInfo{PublicKey: []byte("pk1"), ShardId: 1, List: "eligible", Index: 7, TempRating: 100})
+	validatorInfo2, _ := marshaller.Marshal(&peers.ShardValidatorInfo{PublicKey: []byte("pk2"), ShardId: core.MetachainShardId, List: "jailed"})
+
+	peerMiniBlock := &block.MiniBlock{
+		TxHashes:        [][]byte{validatorInfo1, {0x0a, 0x05}, validatorInfo2},
+		SenderShardID:   core.MetachainShardId,
+		ReceiverShardID: core.AllShardId,
+		Type:            block.PeerBlock,
+	}
+	body := &block.Body{MiniBlocks: []*block.MiniBlock{
+		{TxHashes: [][]byte{[]byte("tx hash")}, Type: block.TxBlock},
+		peerMiniBlock,
+	}}
+	header := &block.MetaBlock{Epoch: 4}
+
+	metaShardID := core.MetachainShardId
+
+	ret, err := pcp.ProcessPeerChanges(header, []byte("header hash"), body)
+	require.Nil(t, err)
+
+	miniBlockHash, _ := core.CalculateHash(marshaller, &mock.HasherMock{}, peerMiniBlock)
+	require.Equal(t, []*schema.PeerChange{
+		{
+			BlockHash:        []byte("header hash"),
+			MiniBlockHash:    miniBlockHash,
+			MiniBlockIndex:   1,
+			IndexInMiniBlock: 0,
+			Epoch:            4,
+			PublicKey:        []byte("pk1"),
+			ShardID:          1,
+			List:             "eligible",
+			Index:            7,
+			TempRating:       100,
+		},
+		{
+			BlockHash:        []byte("header hash"),
+			MiniBlockHash:    miniBlockHash,
+			MiniBlockIndex:   1,
+			IndexInMiniBlock: 2,
+			Epoch:            4,
+			PublicKey:        []byte("pk2"),
+			ShardID:          int32(metaShardID),
+			List:             "jailed",
+		},
+	}, ret)
+}
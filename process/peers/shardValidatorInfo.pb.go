@@ -0,0 +1,560 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: shardValidatorInfo.proto
+
+package peers
+
+import (
+	bytes "bytes"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	reflect "reflect"
+	strings "strings"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type ShardValidatorInfo struct {
+	PublicKey  []byte `protobuf:"bytes,1,opt,name=PublicKey,proto3" json:"publicKey"`
+	ShardId    uint32 `protobuf:"varint,2,opt,name=ShardId,proto3" json:"shardId"`
+	List       string `protobuf:"bytes,3,opt,name=List,proto3" json:"list,omitempty"`
+	Index      uint32 `protobuf:"varint,4,opt,name=Index,proto3" json:"index"`
+	TempRating uint32 `protobuf:"varint,5,opt,name=TempRating,proto3" json:"tempRating"`
+}
+
+func (m *ShardValidatorInfo) Reset()      { *m = ShardValidatorInfo{} }
+func (*ShardValidatorInfo) ProtoMessage() {}
+func (*ShardValidatorInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_11e61c82fdd6ef22, []int{0}
+}
+func (m *ShardValidatorInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ShardValidatorInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *ShardValidatorInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardValidatorInfo.Merge(m, src)
+}
+func (m *ShardValidatorInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *ShardValidatorInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardValidatorInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShardValidatorInfo proto.InternalMessageInfo
+
+func (m *ShardValidatorInfo) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *ShardValidatorInfo) GetShardId() uint32 {
+	if m != nil {
+		return m.ShardId
+	}
+	return 0
+}
+
+func (m *ShardValidatorInfo) GetList() string {
+	if m != nil {
+		return m.List
+	}
+	return ""
+}
+
+func (m *ShardValidatorInfo) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ShardValidatorInfo) GetTempRating() uint32 {
+	if m != nil {
+		return m.TempRating
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*ShardValidatorInfo)(nil), "proto.ShardValidatorInfo")
+}
+
+func init() { proto.RegisterFile("shardValidatorInfo.proto", fileDescriptor_11e61c82fdd6ef22) }
+
+var fileDescriptor_11e61c82fdd6ef22 = []byte{
+	// 304 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x90, 0xb1, 0x4e, 0xc3, 0x30,
+	0x10, 0x86, 0x73, 0xd0, 0x50, 0xc5, 0xd0, 0x0e, 0x9e, 0x22, 0x86, 0x73, 0x85, 0x04, 0xaa, 0x04,
+	0xb4, 0x03, 0x0f, 0x80, 0x94, 0xad, 0x82, 0x01, 0x19, 0xc4, 0xc0, 0x96, 0x34, 0x6e, 0x6a, 0xa9,
+	0xa9, 0xa3, 0xc4, 0x95, 0xe8, 0xc6, 0x23, 0xf0, 0x18, 0x3c, 0x0a, 0x63, 0xc7, 0x4e, 0x11, 0x71,
+	0x17, 0x94, 0xa9, 0x8f, 0x80, 0x70, 0x54, 0x40, 0x62, 0xba, 0xfb, 0xbf, 0xff, 0xbf, 0x7f, 0x38,
+	0xe2, 0x17, 0xd3, 0x30, 0x8f, 0x1f, 0xc3, 0x99, 0x8c, 0x43, 0xad, 0xf2, 0xd1, 0x7c, 0xa2, 0x06,
+	0x59, 0xae, 0xb4, 0xa2, 0xae, 0x1d, 0xc7, 0x97, 0x89, 0xd4, 0xd3, 0x45, 0x34, 0x18, 0xab, 0x74,
+	0x98, 0xa8, 0x44, 0x0d, 0x2d, 0x8e, 0x16, 0x13, 0xab, 0xac, 0xb0, 0x5b, 0x73, 0x75, 0x52, 0x01,
+	0xa1, 0xf7, 0xff, 0x2a, 0xe9, 0x39, 0xf1, 0xee, 0x16, 0xd1, 0x4c, 0x8e, 0x6f, 0xc4, 0xd2, 0x87,
+	0x1e, 0xf4, 0x8f, 0x82, 0x4e, 0x5d, 0x32, 0x2f, 0xdb, 0x41, 0xfe, 0xeb, 0xd3, 0x53, 0xd2, 0xb6,
+	0x15, 0xa3, 0xd8, 0xdf, 0xeb, 0x41, 0xbf, 0x13, 0x1c, 0xd6, 0x25, 0x6b, 0x17, 0x0d, 0xe2, 0x3b,
+	0x8f, 0x9e, 0x91, 0xd6, 0xad, 0x2c, 0xb4, 0xbf, 0xdf, 0x83, 0xbe, 0x17, 0xd0, 0xba, 0x64, 0xdd,
+	0x99, 0x2c, 0xf4, 0x85, 0x4a, 0xa5, 0x16, 0x69, 0xa6, 0x97, 0xdc, 0xfa, 0x94, 0x11, 0x77, 0x34,
+	0x8f, 0xc5, 0xb3, 0xdf, 0xb2, 0x65, 0x5e, 0x5d, 0x32, 0x57, 0x7e, 0x03, 0xde, 0x70, 0x3a, 0x20,
+	0xe4, 0x41, 0xa4, 0x19, 0x0f, 0xb5, 0x9c, 0x27, 0xbe, 0x6b, 0x53, 0xdd, 0xba, 0x64, 0x44, 0xff,
+	0x50, 0xfe, 0x27, 0x11, 0x5c, 0xaf, 0x2a, 0x74, 0xd6, 0x15, 0x3a, 0xdb, 0x0a, 0xe1, 0xc5, 0x20,
+	0xbc, 0x19, 0x84, 0x77, 0x83, 0xb0, 0x32, 0x08, 0x6b, 0x83, 0xf0, 0x61, 0x10, 0x3e, 0x0d, 0x3a,
+	0x5b, 0x83, 0xf0, 0xba, 0x41, 0x67, 0xb5, 0x41, 0x67, 0xbd, 0x41, 0xe7, 0xc9, 0xcd, 0x84, 0xc8,
+	0x8b, 0xe8, 0xc0, 0xfe, 0xea, 0xea, 0x6b, 0x00, 0xa3, 0x9f, 0x9e, 0x60, 0x7d, 0x01, 0x00, 0x00,
+}
+
+func (this *ShardValidatorInfo) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ShardValidatorInfo)
+	if !ok {
+		that2, ok := that.(ShardValidatorInfo)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.PublicKey, that1.PublicKey) {
+		return false
+	}
+	if this.ShardId != that1.ShardId {
+		return false
+	}
+	if this.List != that1.List {
+		return false
+	}
+	if this.Index != that1.Index {
+		return false
+	}
+	if this.TempRating != that1.TempRating {
+		return false
+	}
+	return true
+}
+func (this *ShardValidatorInfo) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&peers.ShardValidatorInfo{")
+	s = append(s, "PublicKey: "+fmt.Sprintf("%#v", this.PublicKey)+",\n")
+	s = append(s, "ShardId: "+fmt.Sprintf("%#v", this.ShardId)+",\n")
+	s = append(s, "List: "+fmt.Sprintf("%#v", this.List)+",\n")
+	s = append(s, "Index: "+fmt.Sprintf("%#v", this.Index)+",\n")
+	s = append(s, "TempRating: "+fmt.Sprintf("%#v", this.TempRating)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringShardValidatorInfo(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *ShardValidatorInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ShardValidatorInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ShardValidatorInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TempRating != 0 {
+		i = encodeVarintShardValidatorInfo(dAtA, i, uint64(m.TempRating))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Index != 0 {
+		i = encodeVarintShardValidatorInfo(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.List) > 0 {
+		i -= len(m.List)
+		copy(dAtA[i:], m.List)
+		i = encodeVarintShardValidatorInfo(dAtA, i, uint64(len(m.List)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ShardId != 0 {
+		i = encodeVarintShardValidatorInfo(dAtA, i, uint64(m.ShardId))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.PublicKey) > 0 {
+		i -= len(m.PublicKey)
+		copy(dAtA[i:], m.PublicKey)
+		i = encodeVarintShardValidatorInfo(dAtA, i, uint64(len(m.PublicKey)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintShardValidatorInfo(dAtA []byte, offset int, v uint64) int {
+	offset -= sovShardValidatorInfo(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ShardValidatorInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PublicKey)
+	if l > 0 {
+		n += 1 + l + sovShardValidatorInfo(uint64(l))
+	}
+	if m.ShardId != 0 {
+		n += 1 + sovShardValidatorInfo(uint64(m.ShardId))
+	}
+	l = len(m.List)
+	if l > 0 {
+		n += 1 + l + sovShardValidatorInfo(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovShardValidatorInfo(uint64(m.Index))
+	}
+	if m.TempRating != 0 {
+		n += 1 + sovShardValidatorInfo(uint64(m.TempRating))
+	}
+	return n
+}
+
+func sovShardValidatorInfo(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozShardValidatorInfo(x uint64) (n int) {
+	return sovShardValidatorInfo(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *ShardValidatorInfo) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ShardValidatorInfo{`,
+		`PublicKey:` + fmt.Sprintf("%v", this.PublicKey) + `,`,
+		`ShardId:` + fmt.Sprintf("%v", this.ShardId) + `,`,
+		`List:` + fmt.Sprintf("%v", this.List) + `,`,
+		`Index:` + fmt.Sprintf("%v", this.Index) + `,`,
+		`TempRating:` + fmt.Sprintf("%v", this.TempRating) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringShardValidatorInfo(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *ShardValidatorInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShardValidatorInfo
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardValidatorInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardValidatorInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardValidatorInfo
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthShardValidatorInfo
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardValidatorInfo
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PublicKey == nil {
+				m.PublicKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardId", wireType)
+			}
+			m.ShardId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardValidatorInfo
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardId |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field List", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardValidatorInfo
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthShardValidatorInfo
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShardValidatorInfo
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.List = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardValidatorInfo
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TempRating", wireType)
+			}
+			m.TempRating = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShardValidatorInfo
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TempRating |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShardValidatorInfo(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShardValidatorInfo
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipShardValidatorInfo(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowShardValidatorInfo
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowShardValidatorInfo
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowShardValidatorInfo
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthShardValidatorInfo
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupShardValidatorInfo
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthShardValidatorInfo
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthShardValidatorInfo        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowShardValidatorInfo          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupShardValidatorInfo = fmt.Errorf("proto: unexpected end of group")
+)
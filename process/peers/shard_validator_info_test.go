@@ -0,0 +1,89 @@
+package peers_test
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/covalent-indexer-go/process/peers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardValidatorInfo_MarshalUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	validatorInfo := &peers.ShardValidatorInfo{
+		PublicKey:  []byte("public key"),
+		ShardId:    4294967295,
+		List:       "eligible",
+		Index:      300,
+		TempRating: 5000001,
+	}
+
+	buff, err := validatorInfo.Marshal()
+	require.Nil(t, err)
+
+	unmarshalledValidatorInfo := &peers.ShardValidatorInfo{}
+	err = unmarshalledValidatorInfo.Unmarshal(buff)
+	require.Nil(t, err)
+	require.Equal(t, validatorInfo, unmarshalledValidatorInfo)
+}
+
+func TestShardValidatorInfo_Unmarshal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		buff                  []byte
+		expectedValidatorInfo *peers.ShardValidatorInfo
+		expectedErr           bool
+	}{
+		{
+			// PublicKey = "pk", ShardId = 1, List = "waiting", Index = 2, TempRating = 3
+			buff: []byte{0x0a, 0x02, 'p', 'k', 0x10, 0x01, 0x1a, 0x07, 'w', 'a', 'i', 't', 'i', 'n', 'g', 0x20, 0x02, 0x28, 0x03},
+			expectedValidatorInfo: &peers.ShardValidatorInfo{
+				PublicKey: []byte("pk"), ShardId: 1, List: "waiting", Index: 2, TempRating: 3},
+			expectedErr: false,
+		},
+		{
+			// unknown fields of all wire types, followed by ShardId = 1
+			buff: []byte{
+				0x30, 0x05,
+				0x39, 1, 2, 3, 4, 5, 6, 7, 8,
+				0x42, 0x01, 'x',
+				0x4d, 1, 2, 3, 4,
+				0x10, 0x01},
+			expectedValidatorInfo: &peers.ShardValidatorInfo{ShardId: 1},
+			expectedErr:           false,
+		},
+		{
+			buff:                  []byte{},
+			expectedValidatorInfo: &peers.ShardValidatorInfo{},
+			expectedErr:           false,
+		},
+		{
+			// length of PublicKey exceeds the buffer
+			buff:        []byte{0x0a, 0x05, 'p', 'k'},
+			expectedErr: true,
+		},
+		{
+			// truncated varint
+			buff:        []byte{0x10, 0x80},
+			expectedErr: true,
+		},
+		{
+			// wrong wire type for PublicKey
+			buff:        []byte{0x08, 0x01},
+			expectedErr: true,
+		},
+	}
+
+	for _, currTest := range tests {
+		validatorInfo := &peers.ShardValidatorInfo{}
+		err := validatorInfo.Unmarshal(currTest.buff)
+		if currTest.expectedErr {
+			require.NotNil(t, err)
+			continue
+		}
+
+		require.Nil(t, err)
+		require.Equal(t, currTest.expectedValidatorInfo, validatorInfo)
+	}
+}
@@ -0,0 +1,74 @@
+package ratings
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/covalent-indexer-go/schema"
+	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
+)
+
+const indexIDSeparator = "_"
+
+type ratingsProcessor struct{}
+
+// NewRatingsProcessor creates a new instance of validators rating processor
+func NewRatingsProcessor() *ratingsProcessor {
+	return &ratingsProcessor{}
+}
+
+// ProcessRatings converts validators rating data to a specific structure defined by avro schema.
+// The index ID is expected to be formatted as shardID_epoch, the same way the node builds it
+func (rp *ratingsProcessor) ProcessRatings(indexID string, ratings []*indexer.ValidatorRatingInfo) ([]*schema.ValidatorRating, error) {
+	shardID, epoch, err := parseIndexID(indexID)
+	if err != nil {
+		return nil, err
+	}
+
+	allRatings := make([]*schema.ValidatorRating, 0, len(ratings))
+	for _, currRating := range ratings {
+		if currRating == nil {
+			continue
+		}
+
+		publicKey, errDecode := hex.DecodeString(currRating.PublicKey)
+		if errDecode != nil {
+			return nil, errDecode
+		}
+
+		allRatings = append(allRatings, &schema.ValidatorRating{
+			PublicKey: publicKey,
+			Rating:    currRating.Rating,
+			Epoch:     int32(epoch),
+			ShardID:   int32(shardID),
+		})
+	}
+
+	return allRatings, nil
+}
+
+func parseIndexID(indexID string) (uint32, uint32, error) {
+	tokens := strings.Split(indexID, indexIDSeparator)
+	if len(tokens) != 2 {
+		return 0, 0, covalent.ErrInvalidRatingsIndexID
+	}
+
+	shardID, err := strconv.ParseUint(tokens[0], 10, 32)
+	if err != nil {
+		return 0, 0, covalent.ErrInvalidRatingsIndexID
+	}
+
+	epoch, err := strconv.ParseUint(tokens[1], 10, 32)
+	if err != nil {
+		return 0, 0, covalent.ErrInvalidRatingsIndexID
+	}
+
+	return uint32(shardID), uint32(epoch), nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rp *ratingsProcessor) IsInterfaceNil() bool {
+	return rp == nil
+}
@@ -0,0 +1,63 @@
+package ratings_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/ratings"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRatingsProcessor_ProcessRatings_InvalidIndexID_ExpectError(t *testing.T) {
+	t.Parallel()
+
+	rp := ratings.NewRatingsProcessor()
+	invalidIndexIDs := []string{"", "1", "1_2_3", "a_2", "1_b", "-1_2"}
+
+	for _, indexID := range invalidIndexIDs {
+		ret, err := rp.ProcessRatings(indexID, []*indexer.ValidatorRatingInfo{})
+		require.Equal(t, covalent.ErrInvalidRatingsIndexID, err)
+		require.Nil(t, ret)
+	}
+}
+
+func TestRatingsProcessor_ProcessRatings_InvalidPublicKey_ExpectError(t *testing.T) {
+	t.Parallel()
+
+	rp := ratings.NewRatingsProcessor()
+	ret, err := rp.ProcessRatings("1_2", []*indexer.ValidatorRatingInfo{{PublicKey: "xz", Rating: 50}})
+
+	require.NotNil(t, err)
+	require.Nil(t, ret)
+}
+
+func TestRatingsProcessor_ProcessRatings_TwoRatings_ExpectTwoProcessedRatings(t *testing.T) {
+	t.Parallel()
+
+	rp := ratings.NewRatingsProcessor()
+	pubKey1 := []byte("validator pub key 1")
+	pubKey2 := []byte("validator pub key 2")
+	metaShardID := core.MetachainShardId
+	indexID := "4294967295_7"
+
+	ret, err := rp.ProcessRatings(indexID, []*indexer.ValidatorRatingInfo{
+		{PublicKey: hex.EncodeToString(pubKey1), Rating: 50.5},
+		nil,
+		{PublicKey: hex.EncodeToString(pubKey2), Rating: 100},
+	})
+	require.Nil(t, err)
+	require.Len(t, ret, 2)
+
+	require.Equal(t, pubKey1, ret[0].PublicKey)
+	require.Equal(t, float32(50.5), ret[0].Rating)
+	require.Equal(t, int32(7), ret[0].Epoch)
+	require.Equal(t, int32(metaShardID), ret[0].ShardID)
+
+	require.Equal(t, pubKey2, ret[1].PublicKey)
+	require.Equal(t, float32(100), ret[1].Rating)
+	require.Equal(t, int32(7), ret[1].Epoch)
+	require.Equal(t, int32(metaShardID), ret[1].ShardID)
+}
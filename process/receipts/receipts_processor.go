@@ -7,55 +7,147 @@ import (
 	"github.com/ElrondNetwork/elrond-go-core/core"
 	"github.com/ElrondNetwork/elrond-go-core/core/check"
 	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/block"
 	"github.com/ElrondNetwork/elrond-go-core/data/receipt"
+	"github.com/ElrondNetwork/elrond-go-core/hashing"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
 )
 
+var log = logger.GetOrCreate("covalent/process/receipts")
+
 type receiptsProcessor struct {
 	pubKeyConverter core.PubkeyConverter
+	hasher          hashing.Hasher
+	marshaller      marshal.Marshalizer
 }
 
 // NewReceiptsProcessor creates a new instance of receipts processor
-func NewReceiptsProcessor(pubKeyConverter core.PubkeyConverter) (*receiptsProcessor, error) {
+func NewReceiptsProcessor(
+	pubKeyConverter core.PubkeyConverter,
+	hasher hashing.Hasher,
+	marshaller marshal.Marshalizer,
+) (*receiptsProcessor, error) {
 	if check.IfNil(pubKeyConverter) {
 		return nil, covalent.ErrNilPubKeyConverter
 	}
+	if check.IfNil(hasher) {
+		return nil, covalent.ErrNilHasher
+	}
+	if check.IfNil(marshaller) {
+		return nil, covalent.ErrNilMarshaller
+	}
 
 	return &receiptsProcessor{
 		pubKeyConverter: pubKeyConverter,
+		hasher:          hasher,
+		marshaller:      marshaller,
 	}, nil
 }
 
-// ProcessReceipts converts receipts data to a specific structure defined by avro schema
-func (rp *receiptsProcessor) ProcessReceipts(receipts map[string]data.TransactionHandler, timeStamp uint64) []*schema.Receipt {
+// ProcessReceipts converts receipts data to a specific structure defined by avro schema. Receipts are taken from
+// the receipts miniblocks of the body. The ones which are not included in any miniblock are processed last,
+// sorted by hash, without a miniblock hash
+func (rp *receiptsProcessor) ProcessReceipts(
+	header data.HeaderHandler,
+	headerHash []byte,
+	bodyHandler data.BodyHandler,
+	receipts map[string]data.TransactionHandler,
+) ([]*schema.Receipt, error) {
+	body, ok := bodyHandler.(*block.Body)
+	if !ok {
+		return nil, covalent.ErrBlockBodyAssertion
+	}
+
 	allReceipts := make([]*schema.Receipt, 0, len(receipts))
+	processedReceipts := make(map[string]struct{}, len(receipts))
+	for _, currMiniBlock := range body.MiniBlocks {
+		if currMiniBlock.Type != block.ReceiptBlock {
+			continue
+		}
 
-	for currHash, currReceipt := range receipts {
-		rec := rp.processReceipt(currReceipt, currHash, timeStamp)
+		receiptsInCurrMB, err := rp.processReceiptsFromMiniBlock(receipts, currMiniBlock, header, headerHash, processedReceipts)
+		if err != nil {
+			log.Warn("receiptsProcessor.processReceiptsFromMiniBlock", "error", err)
+			continue
+		}
+		allReceipts = append(allReceipts, receiptsInCurrMB...)
+	}
+
+	selfShardMiniBlock := &block.MiniBlock{SenderShardID: header.GetShardID(), ReceiverShardID: header.GetShardID()}
+	for _, currHash := range utility.SortedHashes(receipts) {
+		_, processed := processedReceipts[currHash]
+		if processed {
+			continue
+		}
+
+		rec := rp.processReceipt(receipts[currHash], []byte(currHash), nil, headerHash, selfShardMiniBlock, header)
 		if rec != nil {
 			allReceipts = append(allReceipts, rec)
 		}
 	}
 
-	return allReceipts
+	return allReceipts, nil
+}
+
+func (rp *receiptsProcessor) processReceiptsFromMiniBlock(
+	receipts map[string]data.TransactionHandler,
+	miniBlock *block.MiniBlock,
+	header data.HeaderHandler,
+	blockHash []byte,
+	processedReceipts map[string]struct{},
+) ([]*schema.Receipt, error) {
+	miniBlockHash, err := core.CalculateHash(rp.marshaller, rp.hasher, miniBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	receiptsInMiniBlock := make([]*schema.Receipt, 0, len(miniBlock.TxHashes))
+	for _, receiptHash := range miniBlock.TxHashes {
+		currReceipt, isInPool := receipts[string(receiptHash)]
+		if !isInPool {
+			log.Warn("receiptsProcessor.processReceiptsFromMiniBlock receipt hash not found in pool", "hash", receiptHash)
+			continue
+		}
+
+		processedReceipts[string(receiptHash)] = struct{}{}
+		rec := rp.processReceipt(currReceipt, receiptHash, miniBlockHash, blockHash, miniBlock, header)
+		if rec != nil {
+			receiptsInMiniBlock = append(receiptsInMiniBlock, rec)
+		}
+	}
+
+	return receiptsInMiniBlock, nil
 }
 
 func (rp *receiptsProcessor) processReceipt(
 	tx data.TransactionHandler,
-	receiptHash string,
-	timeStamp uint64,
+	receiptHash []byte,
+	miniBlockHash []byte,
+	blockHash []byte,
+	miniBlock *block.MiniBlock,
+	header data.HeaderHandler,
 ) *schema.Receipt {
-
 	rec, castOk := tx.(*receipt.Receipt)
 	if !castOk {
 		return nil
 	}
 
 	return &schema.Receipt{
-		Hash:      []byte(receiptHash),
-		Value:     utility.GetBytes(rec.GetValue()),
-		Sender:    utility.EncodePubKey(rp.pubKeyConverter, rec.GetSndAddr()),
-		Data:      rec.GetData(),
-		TxHash:    rec.GetTxHash(),
-		Timestamp: int64(timeStamp),
+		Hash:          receiptHash,
+		MiniBlockHash: miniBlockHash,
+		BlockHash:     blockHash,
+		Value:         utility.GetBytes(rec.GetValue()),
+		Sender:        utility.EncodePubKey(rp.pubKeyConverter, rec.GetSndAddr()),
+		ReceiverShard: int32(miniBlock.ReceiverShardID),
+		SenderShard:   int32(miniBlock.SenderShardID),
+		Data:          rec.GetData(),
+		TxHash:        rec.GetTxHash(),
+		Timestamp:     int64(header.GetTimeStamp()),
 	}
 }
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rp *receiptsProcessor) IsInterfaceNil() bool {
+	return rp == nil
+}
@@ -11,8 +11,11 @@ import (
 	"github.com/ElrondNetwork/covalent-indexer-go/testscommon/mock"
 	"github.com/ElrondNetwork/elrond-go-core/core"
 	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/block"
 	"github.com/ElrondNetwork/elrond-go-core/data/receipt"
 	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/ElrondNetwork/elrond-go-core/hashing"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
 	"github.com/stretchr/testify/require"
 )
 
@@ -20,18 +23,30 @@ func TestNewReceiptsProcessor(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		args        func() core.PubkeyConverter
+		args        func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer)
 		expectedErr error
 	}{
 		{
-			args: func() core.PubkeyConverter {
-				return nil
+			args: func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer) {
+				return nil, &mock.HasherMock{}, &mock.MarshallerStub{}
 			},
 			expectedErr: covalent.ErrNilPubKeyConverter,
 		},
 		{
-			args: func() core.PubkeyConverter {
-				return &mock.PubKeyConverterStub{}
+			args: func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer) {
+				return &mock.PubKeyConverterStub{}, nil, &mock.MarshallerStub{}
+			},
+			expectedErr: covalent.ErrNilHasher,
+		},
+		{
+			args: func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer) {
+				return &mock.PubKeyConverterStub{}, &mock.HasherMock{}, nil
+			},
+			expectedErr: covalent.ErrNilMarshaller,
+		},
+		{
+			args: func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer) {
+				return &mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}
 			},
 			expectedErr: nil,
 		},
@@ -52,9 +67,20 @@ func generateRandomReceipt() *receipt.Receipt {
 	}
 }
 
-// TODO: fix this test as it fails randomly
+func TestReceiptsProcessor_ProcessReceipts_InvalidBody_ExpectError(t *testing.T) {
+	t.Parallel()
+
+	rp, _ := receipts.NewReceiptsProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+
+	ret, err := rp.ProcessReceipts(&block.Header{}, []byte("header hash"), nil, map[string]data.TransactionHandler{})
+	require.Nil(t, ret)
+	require.Equal(t, covalent.ErrBlockBodyAssertion, err)
+}
+
 func TestReceiptsProcessor_ProcessReceipts_TwoReceipts_OneNormalTx_ExpectTwoProcessedReceipts(t *testing.T) {
-	rp, _ := receipts.NewReceiptsProcessor(&mock.PubKeyConverterStub{})
+	t.Parallel()
+
+	rp, _ := receipts.NewReceiptsProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
 
 	receipt1 := generateRandomReceipt()
 	receipt2 := generateRandomReceipt()
@@ -64,13 +90,48 @@ func TestReceiptsProcessor_ProcessReceipts_TwoReceipts_OneNormalTx_ExpectTwoProc
 		"hash2": receipt2,
 		"hash3": &transaction.Transaction{},
 	}
+	miniBlock := &block.MiniBlock{
+		TxHashes:        [][]byte{[]byte("hash1"), []byte("hash2"), []byte("hash3")},
+		SenderShardID:   1,
+		ReceiverShardID: 1,
+		Type:            block.ReceiptBlock,
+	}
+	body := &block.Body{MiniBlocks: []*block.MiniBlock{miniBlock}}
+	header := &block.Header{ShardID: 1, TimeStamp: 123}
 
-	ret := rp.ProcessReceipts(txPool, 123)
-
+	ret, err := rp.ProcessReceipts(header, []byte("header hash"), body, txPool)
+	require.Nil(t, err)
 	require.Len(t, ret, 2)
 
 	requireProcessedReceiptEqual(t, ret[0], receipt1, "hash1", 123, &mock.PubKeyConverterStub{})
 	requireProcessedReceiptEqual(t, ret[1], receipt2, "hash2", 123, &mock.PubKeyConverterStub{})
+
+	miniBlockHash, _ := core.CalculateHash(&mock.MarshallerStub{}, &mock.HasherMock{}, miniBlock)
+	for _, rec := range ret {
+		require.Equal(t, miniBlockHash, rec.MiniBlockHash)
+		require.Equal(t, []byte("header hash"), rec.BlockHash)
+		require.Equal(t, int32(1), rec.SenderShard)
+		require.Equal(t, int32(1), rec.ReceiverShard)
+	}
+}
+
+func TestReceiptsProcessor_ProcessReceipts_ReceiptNotInMiniBlock_ExpectNoMiniBlockHash(t *testing.T) {
+	t.Parallel()
+
+	rp, _ := receipts.NewReceiptsProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+
+	txPool := map[string]data.TransactionHandler{
+		"hash1": generateRandomReceipt(),
+	}
+	header := &block.Header{ShardID: 2}
+
+	ret, err := rp.ProcessReceipts(header, []byte("header hash"), &block.Body{}, txPool)
+	require.Nil(t, err)
+	require.Len(t, ret, 1)
+	require.Nil(t, ret[0].MiniBlockHash)
+	require.Equal(t, []byte("header hash"), ret[0].BlockHash)
+	require.Equal(t, int32(2), ret[0].SenderShard)
+	require.Equal(t, int32(2), ret[0].ReceiverShard)
 }
 
 func requireProcessedReceiptEqual(
@@ -0,0 +1,232 @@
+package registry
+
+import (
+	"sort"
+	"sync"
+)
+
+// MaxPendingIssues defines how many token issuances, whose identifiers were not yet returned by the ESDT system
+// smart contract, are kept until their results are processed
+const MaxPendingIssues = 1000
+
+// MaxRevertibleBlocks defines for how many of the last processed blocks the previous tokens and pending issuances
+// are kept, so that the registry can be restored when these blocks are reverted
+const MaxRevertibleBlocks = 100
+
+// TokenInfo holds the metadata of a token, as known from the interactions with the ESDT system smart contract.
+// The owner and the addresses having special roles are encoded
+type TokenInfo struct {
+	Identifier string
+	Name       string
+	Ticker     string
+	TokenType  string
+	Decimals   int32
+	Owner      []byte
+	Paused     bool
+	Roles      map[string][]string
+}
+
+// pendingIssue holds a token issuance waiting for the token identifier to be returned by the ESDT system
+// smart contract, which might happen in a later block for issuances sent from shards
+type pendingIssue struct {
+	index         uint64
+	function      string
+	caller        []byte
+	info          *TokenInfo
+	initialSupply []byte
+}
+
+// journalEntry holds either the metadata of a token or a pending issuance before being changed in a block,
+// a nil previous value meaning that it was not known
+type journalEntry struct {
+	identifier    string
+	previousInfo  *TokenInfo
+	txHash        string
+	previousIssue *pendingIssue
+}
+
+// tokenRegistry keeps the metadata of the known tokens and the pending issuances. For each block, identified by its
+// timestamp, the previous values of the changed tokens and pending issuances are journaled, so that they can be
+// restored when the block is reverted
+type tokenRegistry struct {
+	tokens        map[string]*TokenInfo
+	pendingIssues map[string]*pendingIssue
+	issuesCounter uint64
+	journals      map[uint64][]*journalEntry
+	mut           sync.RWMutex
+}
+
+func newTokenRegistry() *tokenRegistry {
+	return &tokenRegistry{
+		tokens:        make(map[string]*TokenInfo),
+		pendingIssues: make(map[string]*pendingIssue),
+		journals:      make(map[uint64][]*journalEntry),
+	}
+}
+
+func (tr *tokenRegistry) get(identifier string) (*TokenInfo, bool) {
+	tr.mut.RLock()
+	defer tr.mut.RUnlock()
+
+	info, found := tr.tokens[identifier]
+	if !found {
+		return nil, false
+	}
+
+	return copyTokenInfo(info), true
+}
+
+func (tr *tokenRegistry) update(blockTimestamp uint64, identifier string, handler func(info *TokenInfo)) {
+	tr.mut.Lock()
+	defer tr.mut.Unlock()
+
+	info, found := tr.tokens[identifier]
+	if !found {
+		return
+	}
+
+	tr.journalToken(blockTimestamp, identifier)
+	handler(info)
+}
+
+func (tr *tokenRegistry) addPendingIssue(blockTimestamp uint64, txHash string, issue *pendingIssue) {
+	tr.mut.Lock()
+	defer tr.mut.Unlock()
+
+	tr.journalPendingIssue(blockTimestamp, txHash)
+	tr.issuesCounter++
+	issue.index = tr.issuesCounter
+	tr.pendingIssues[txHash] = issue
+
+	tr.removeOldestPendingIssuesIfNeeded()
+}
+
+func (tr *tokenRegistry) removePendingIssue(blockTimestamp uint64, txHash string) {
+	tr.mut.Lock()
+	defer tr.mut.Unlock()
+
+	tr.journalPendingIssue(blockTimestamp, txHash)
+	delete(tr.pendingIssues, txHash)
+}
+
+// resolvePendingIssue registers the token issued by the given transaction, returning the pending issuance, if any
+func (tr *tokenRegistry) resolvePendingIssue(blockTimestamp uint64, txHash string, identifier string) *pendingIssue {
+	tr.mut.Lock()
+	defer tr.mut.Unlock()
+
+	issue, found := tr.pendingIssues[txHash]
+	if !found {
+		return nil
+	}
+
+	tr.journalPendingIssue(blockTimestamp, txHash)
+	tr.journalToken(blockTimestamp, identifier)
+	delete(tr.pendingIssues, txHash)
+	issue.info.Identifier = identifier
+	tr.tokens[identifier] = issue.info
+
+	return issue
+}
+
+// revert restores the tokens and pending issuances as they were before processing the block with the given timestamp
+func (tr *tokenRegistry) revert(blockTimestamp uint64) {
+	tr.mut.Lock()
+	defer tr.mut.Unlock()
+
+	journal := tr.journals[blockTimestamp]
+	for idx := len(journal) - 1; idx >= 0; idx-- {
+		entry := journal[idx]
+		switch {
+		case len(entry.txHash) != 0 && entry.previousIssue == nil:
+			delete(tr.pendingIssues, entry.txHash)
+		case len(entry.txHash) != 0:
+			tr.pendingIssues[entry.txHash] = entry.previousIssue
+		case entry.previousInfo == nil:
+			delete(tr.tokens, entry.identifier)
+		default:
+			tr.tokens[entry.identifier] = entry.previousInfo
+		}
+	}
+
+	delete(tr.journals, blockTimestamp)
+}
+
+func (tr *tokenRegistry) getPendingIssue(txHash string) *pendingIssue {
+	tr.mut.RLock()
+	defer tr.mut.RUnlock()
+
+	return tr.pendingIssues[txHash]
+}
+
+func (tr *tokenRegistry) journalToken(blockTimestamp uint64, identifier string) {
+	entry := &journalEntry{identifier: identifier}
+	if info, found := tr.tokens[identifier]; found {
+		entry.previousInfo = copyTokenInfo(info)
+	}
+
+	tr.addJournalEntry(blockTimestamp, entry)
+}
+
+func (tr *tokenRegistry) journalPendingIssue(blockTimestamp uint64, txHash string) {
+	entry := &journalEntry{txHash: txHash}
+	if issue, found := tr.pendingIssues[txHash]; found {
+		entry.previousIssue = copyPendingIssue(issue)
+	}
+
+	tr.addJournalEntry(blockTimestamp, entry)
+}
+
+func (tr *tokenRegistry) addJournalEntry(blockTimestamp uint64, entry *journalEntry) {
+	tr.journals[blockTimestamp] = append(tr.journals[blockTimestamp], entry)
+	if len(tr.journals) <= MaxRevertibleBlocks {
+		return
+	}
+
+	timestamps := make([]uint64, 0, len(tr.journals))
+	for timestamp := range tr.journals {
+		timestamps = append(timestamps, timestamp)
+	}
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i] < timestamps[j]
+	})
+
+	for _, timestamp := range timestamps[:len(timestamps)-MaxRevertibleBlocks] {
+		delete(tr.journals, timestamp)
+	}
+}
+
+func (tr *tokenRegistry) removeOldestPendingIssuesIfNeeded() {
+	if len(tr.pendingIssues) <= MaxPendingIssues {
+		return
+	}
+
+	txHashes := make([]string, 0, len(tr.pendingIssues))
+	for txHash := range tr.pendingIssues {
+		txHashes = append(txHashes, txHash)
+	}
+	sort.Slice(txHashes, func(i, j int) bool {
+		return tr.pendingIssues[txHashes[i]].index < tr.pendingIssues[txHashes[j]].index
+	})
+
+	for _, txHash := range txHashes[:len(txHashes)-MaxPendingIssues] {
+		delete(tr.pendingIssues, txHash)
+	}
+}
+
+func copyPendingIssue(issue *pendingIssue) *pendingIssue {
+	issueCopy := *issue
+	issueCopy.info = copyTokenInfo(issue.info)
+
+	return &issueCopy
+}
+
+func copyTokenInfo(info *TokenInfo) *TokenInfo {
+	infoCopy := *info
+	infoCopy.Owner = append([]byte{}, info.Owner...)
+	infoCopy.Roles = make(map[string][]string, len(info.Roles))
+	for address, roles := range info.Roles {
+		infoCopy.Roles[address] = append([]string{}, roles...)
+	}
+
+	return &infoCopy
+}
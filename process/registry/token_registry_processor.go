@@ -0,0 +1,359 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/utility"
+	"github.com/ElrondNetwork/covalent-indexer-go/schema"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/ElrondNetwork/elrond-vm-common/parsers"
+)
+
+const (
+	// Issue defines the type of the event emitted when a fungible token is issued
+	Issue = "issue"
+	// IssueSemiFungible defines the type of the event emitted when a semi fungible token is issued
+	IssueSemiFungible = "issueSemiFungible"
+	// IssueNonFungible defines the type of the event emitted when a non fungible token is issued
+	IssueNonFungible = "issueNonFungible"
+	// RegisterMetaESDT defines the type of the event emitted when a meta token is registered
+	RegisterMetaESDT = "registerMetaESDT"
+	// SetSpecialRole defines the type of the event emitted when special roles of a token are set for an address
+	SetSpecialRole = "setSpecialRole"
+	// TransferOwnership defines the type of the event emitted when the ownership of a token is transferred
+	TransferOwnership = "transferOwnership"
+	// Pause defines the type of the event emitted when the transfers of a token are paused
+	Pause = "pause"
+	// UnPause defines the type of the event emitted when the transfers of a token are resumed
+	UnPause = "unPause"
+	// Freeze defines the type of the event emitted when the token balance of an address is frozen
+	Freeze = "freeze"
+)
+
+// MetaESDT defines the string for the token type of meta ESDT
+const MetaESDT = "MetaESDT"
+
+const (
+	returnDataSeparator = "@"
+	tokenSeparator      = "-"
+)
+
+// esdtSCAddress is the address of the ESDT system smart contract
+var esdtSCAddress = []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 255, 255}
+
+// issueTokenTypes maps the issuing functions of the ESDT system smart contract to the types of the issued tokens
+var issueTokenTypes = map[string]string{
+	Issue:             core.FungibleESDT,
+	IssueSemiFungible: core.SemiFungibleESDT,
+	IssueNonFungible:  core.NonFungibleESDT,
+	RegisterMetaESDT:  MetaESDT,
+}
+
+// minArguments holds the minimum number of arguments of the ESDT system smart contract functions
+var minArguments = map[string]int{
+	Issue:             4,
+	IssueSemiFungible: 2,
+	IssueNonFungible:  2,
+	RegisterMetaESDT:  3,
+	SetSpecialRole:    3,
+	TransferOwnership: 2,
+	Pause:             1,
+	UnPause:           1,
+	Freeze:            2,
+}
+
+type tokenRegistryProcessor struct {
+	pubKeyConverter core.PubkeyConverter
+	callArgsParser  vmcommon.CallArgsParser
+	registry        *tokenRegistry
+}
+
+// NewTokenRegistryProcessor creates a new instance of token registry processor
+func NewTokenRegistryProcessor(pubKeyConverter core.PubkeyConverter) (*tokenRegistryProcessor, error) {
+	if check.IfNil(pubKeyConverter) {
+		return nil, covalent.ErrNilPubKeyConverter
+	}
+
+	return &tokenRegistryProcessor{
+		pubKeyConverter: pubKeyConverter,
+		callArgsParser:  parsers.NewCallArgsParser(),
+		registry:        newTokenRegistry(),
+	}, nil
+}
+
+// ProcessTokenRegistryEvents detects the interactions with the ESDT system smart contract from the call data of
+// transactions and smart contract results and keeps the resulting token metadata in the local registry. Issuances
+// are only resolved once the token identifier is returned by the ESDT system smart contract, which might happen in a
+// later block, while operations of transactions which failed, as signaled by their logs, are ignored. Since the ESDT
+// system smart contract is only executed in metachain, events are only emitted for metachain blocks: token management
+// operations, which have no result to confirm them, are ignored in shard blocks, where they are only sent cross shard,
+// while issuances are still resolved in the local registry of the issuer shard, so that its transfers are enriched.
+// The changes of each block are journaled, so that they can be reverted by RevertTokenRegistry
+func (trp *tokenRegistryProcessor) ProcessTokenRegistryEvents(
+	header data.HeaderHandler,
+	txs map[string]data.TransactionHandler,
+	scrs map[string]data.TransactionHandler,
+	logs []*data.LogData,
+) []*schema.TokenRegistryEvent {
+	blockTimestamp := uint64(0)
+	isMetachain := false
+	if !check.IfNil(header) {
+		blockTimestamp = header.GetTimeStamp()
+		isMetachain = header.GetShardID() == core.MetachainShardId
+	}
+
+	failedTxs := getFailedTxs(logs, scrs)
+	events := make([]*schema.TokenRegistryEvent, 0)
+
+	for _, hash := range utility.SortedHashes(txs) {
+		events = trp.processCallData(txs[hash], hash, blockTimestamp, isMetachain, failedTxs, events)
+	}
+	for _, hash := range utility.SortedHashes(scrs) {
+		originalTxHash := utility.GetOriginalTxHash(scrs[hash], hash)
+		events = trp.processCallData(scrs[hash], originalTxHash, blockTimestamp, isMetachain, failedTxs, events)
+	}
+	for _, hash := range utility.SortedHashes(scrs) {
+		issueEvent := trp.processIssueResult(scrs[hash], utility.GetOriginalTxHash(scrs[hash], hash), blockTimestamp)
+		if issueEvent != nil && isMetachain {
+			events = append(events, issueEvent)
+		}
+	}
+
+	return events
+}
+
+// RevertTokenRegistry restores the tokens metadata and pending issuances as they were before processing the block
+// with the given timestamp
+func (trp *tokenRegistryProcessor) RevertTokenRegistry(blockTimestamp uint64) {
+	trp.registry.revert(blockTimestamp)
+}
+
+// EnrichTokenTransfers sets the decimals of the transferred tokens which are known by the registry
+func (trp *tokenRegistryProcessor) EnrichTokenTransfers(transfers []*schema.TokenTransfer) {
+	for _, transfer := range transfers {
+		info, found := trp.registry.get(string(transfer.Identifier))
+		if found {
+			transfer.Decimals = info.Decimals
+		}
+	}
+}
+
+// GetTokenInfo returns the metadata of the token with the given identifier, if known by the registry
+func (trp *tokenRegistryProcessor) GetTokenInfo(identifier string) (*TokenInfo, bool) {
+	return trp.registry.get(identifier)
+}
+
+func (trp *tokenRegistryProcessor) processCallData(
+	tx data.TransactionHandler,
+	txHash string,
+	blockTimestamp uint64,
+	isMetachain bool,
+	failedTxs map[string]struct{},
+	events []*schema.TokenRegistryEvent,
+) []*schema.TokenRegistryEvent {
+	if check.IfNil(tx) || !bytes.Equal(tx.GetRcvAddr(), esdtSCAddress) {
+		return events
+	}
+	if _, isFailed := failedTxs[txHash]; isFailed {
+		return events
+	}
+
+	function, args, err := trp.callArgsParser.ParseData(string(tx.GetData()))
+	minArgs, isRegistryFunction := minArguments[function]
+	if err != nil || !isRegistryFunction || len(args) < minArgs {
+		return events
+	}
+
+	if tokenType, isIssue := issueTokenTypes[function]; isIssue {
+		issue := newPendingIssue(function, tokenType, tx.GetSndAddr(), args)
+		issue.info.Owner = utility.EncodePubKey(trp.pubKeyConverter, tx.GetSndAddr())
+		trp.registry.addPendingIssue(blockTimestamp, txHash, issue)
+		return events
+	}
+	if !isMetachain {
+		return events
+	}
+
+	identifier := string(args[0])
+	event := &schema.TokenRegistryEvent{
+		TxHash:     []byte(txHash),
+		Type:       function,
+		Caller:     utility.EncodePubKey(trp.pubKeyConverter, tx.GetSndAddr()),
+		Identifier: args[0],
+		Roles:      make([]string, 0),
+	}
+
+	switch function {
+	case SetSpecialRole:
+		event.Address = utility.EncodePubKey(trp.pubKeyConverter, args[1])
+		for _, role := range args[2:] {
+			event.Roles = append(event.Roles, string(role))
+		}
+		trp.registry.update(blockTimestamp, identifier, func(info *TokenInfo) {
+			info.Roles[string(event.Address)] = appendMissingRoles(info.Roles[string(event.Address)], event.Roles)
+		})
+	case TransferOwnership:
+		event.Owner = utility.EncodePubKey(trp.pubKeyConverter, args[1])
+		trp.registry.update(blockTimestamp, identifier, func(info *TokenInfo) {
+			info.Owner = event.Owner
+		})
+	case Pause:
+		trp.registry.update(blockTimestamp, identifier, func(info *TokenInfo) {
+			info.Paused = true
+		})
+	case UnPause:
+		trp.registry.update(blockTimestamp, identifier, func(info *TokenInfo) {
+			info.Paused = false
+		})
+	case Freeze:
+		event.Address = utility.EncodePubKey(trp.pubKeyConverter, args[1])
+	}
+
+	return append(events, event)
+}
+
+// processIssueResult resolves the pending issuance of the original transaction from the result sent by the ESDT
+// system smart contract, either the initial supply transfer (ESDTTransfer@token@supply) or the returned token
+// identifier (@6f6b@token), and returns the issuance event. Failed issuances, for which an error is returned,
+// are discarded
+func (trp *tokenRegistryProcessor) processIssueResult(
+	scr data.TransactionHandler,
+	txHash string,
+	blockTimestamp uint64,
+) *schema.TokenRegistryEvent {
+	if check.IfNil(scr) || !bytes.Equal(scr.GetSndAddr(), esdtSCAddress) {
+		return nil
+	}
+
+	issue := trp.registry.getPendingIssue(txHash)
+	if issue == nil {
+		return nil
+	}
+
+	identifier, isError := parseIssueResult(scr.GetData())
+	if isError {
+		trp.registry.removePendingIssue(blockTimestamp, txHash)
+		return nil
+	}
+	if !strings.HasPrefix(identifier, issue.info.Ticker+tokenSeparator) {
+		return nil
+	}
+
+	issue = trp.registry.resolvePendingIssue(blockTimestamp, txHash, identifier)
+	if issue == nil {
+		return nil
+	}
+
+	return &schema.TokenRegistryEvent{
+		TxHash:     []byte(txHash),
+		Type:       issue.function,
+		Caller:     utility.EncodePubKey(trp.pubKeyConverter, issue.caller),
+		Identifier: []byte(identifier),
+		Issue: &schema.TokenIssue{
+			Name:          []byte(issue.info.Name),
+			Ticker:        []byte(issue.info.Ticker),
+			TokenType:     issue.info.TokenType,
+			Decimals:      issue.info.Decimals,
+			InitialSupply: utility.GetBytes(big.NewInt(0).SetBytes(issue.initialSupply)),
+		},
+		Owner: issue.info.Owner,
+		Roles: make([]string, 0),
+	}
+}
+
+func newPendingIssue(function string, tokenType string, caller []byte, args [][]byte) *pendingIssue {
+	issue := &pendingIssue{
+		function: function,
+		caller:   caller,
+		info: &TokenInfo{
+			Name:      string(args[0]),
+			Ticker:    string(args[1]),
+			TokenType: tokenType,
+			Roles:     make(map[string][]string),
+		},
+	}
+
+	switch function {
+	case Issue:
+		issue.initialSupply = args[2]
+		issue.info.Decimals = int32(big.NewInt(0).SetBytes(args[3]).Int64())
+	case RegisterMetaESDT:
+		issue.info.Decimals = int32(big.NewInt(0).SetBytes(args[2]).Int64())
+	}
+
+	return issue
+}
+
+// parseIssueResult returns the token identifier found in the data of a smart contract result sent by the ESDT
+// system smart contract, if any, and whether the result signals an error
+func parseIssueResult(scrData []byte) (string, bool) {
+	tokens := strings.Split(string(scrData), returnDataSeparator)
+	if len(tokens) < 2 {
+		return "", false
+	}
+
+	switch {
+	case tokens[0] == core.BuiltInFunctionESDTTransfer:
+		return decodeHex(tokens[1]), false
+	case len(tokens[0]) == 0 && decodeHex(tokens[1]) != vmcommon.Ok.String():
+		return "", true
+	case len(tokens[0]) == 0 && len(tokens) > 2:
+		return decodeHex(tokens[2]), false
+	default:
+		return "", false
+	}
+}
+
+func decodeHex(token string) string {
+	decoded, err := hex.DecodeString(token)
+	if err != nil {
+		return ""
+	}
+
+	return string(decoded)
+}
+
+// getFailedTxs returns the original transactions which signaled an error in their logs
+func getFailedTxs(logs []*data.LogData, scrs map[string]data.TransactionHandler) map[string]struct{} {
+	failedTxs := make(map[string]struct{})
+
+	for _, logData := range logs {
+		if logData == nil || check.IfNil(logData.LogHandler) {
+			continue
+		}
+
+		for _, event := range logData.LogHandler.GetLogEvents() {
+			if !check.IfNil(event) && string(event.GetIdentifier()) == utility.SignalErrorOperation {
+				failedTxs[utility.GetOriginalTxHash(scrs[logData.TxHash], logData.TxHash)] = struct{}{}
+			}
+		}
+	}
+
+	return failedTxs
+}
+
+func appendMissingRoles(roles []string, newRoles []string) []string {
+	for _, newRole := range newRoles {
+		found := false
+		for _, role := range roles {
+			found = found || role == newRole
+		}
+		if !found {
+			roles = append(roles, newRole)
+		}
+	}
+
+	return roles
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (trp *tokenRegistryProcessor) IsInterfaceNil() bool {
+	return trp == nil
+}
@@ -0,0 +1,363 @@
+package registry_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/registry"
+	"github.com/ElrondNetwork/covalent-indexer-go/schema"
+	"github.com/ElrondNetwork/covalent-indexer-go/testscommon/mock"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/block"
+	"github.com/ElrondNetwork/elrond-go-core/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	esdtSC = []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 255, 255}
+	issuer = []byte("issuer")
+	user   = []byte("user")
+
+	metaHeader = &block.MetaBlock{}
+)
+
+func hexArgs(args ...string) string {
+	ret := ""
+	for _, arg := range args {
+		ret += "@" + hex.EncodeToString([]byte(arg))
+	}
+
+	return ret
+}
+
+func issueTx(function string, args string) map[string]data.TransactionHandler {
+	return map[string]data.TransactionHandler{
+		"txHash": &transaction.Transaction{SndAddr: issuer, RcvAddr: esdtSC, Data: []byte(function + args)},
+	}
+}
+
+func issueResult(scrData string) map[string]data.TransactionHandler {
+	return map[string]data.TransactionHandler{
+		"scrHash": &smartContractResult.SmartContractResult{
+			SndAddr:        esdtSC,
+			RcvAddr:        issuer,
+			Data:           []byte(scrData),
+			OriginalTxHash: []byte("txHash"),
+		},
+	}
+}
+
+func TestNewTokenRegistryProcessor(t *testing.T) {
+	t.Parallel()
+
+	trp, err := registry.NewTokenRegistryProcessor(nil)
+	require.Nil(t, trp)
+	require.Equal(t, covalent.ErrNilPubKeyConverter, err)
+
+	trp, err = registry.NewTokenRegistryProcessor(&mock.PubKeyConverterStub{})
+	require.NotNil(t, trp)
+	require.Nil(t, err)
+}
+
+func TestTokenRegistryProcessor_ProcessTokenRegistryEvents_IssueFungible(t *testing.T) {
+	t.Parallel()
+
+	trp, _ := registry.NewTokenRegistryProcessor(&mock.PubKeyConverterStub{})
+
+	txs := issueTx(registry.Issue, hexArgs("Token", "TKN")+"@03e8@06")
+	scrs := issueResult(core.BuiltInFunctionESDTTransfer + hexArgs("TKN-abcdef") + "@03e8")
+
+	ret := trp.ProcessTokenRegistryEvents(metaHeader, txs, scrs, nil)
+	require.Equal(t, []*schema.TokenRegistryEvent{
+		{
+			TxHash:     []byte("txHash"),
+			Type:       registry.Issue,
+			Caller:     []byte("erd1issuer"),
+			Identifier: []byte("TKN-abcdef"),
+			Issue: &schema.TokenIssue{
+				Name:          []byte("Token"),
+				Ticker:        []byte("TKN"),
+				TokenType:     core.FungibleESDT,
+				Decimals:      6,
+				InitialSupply: big.NewInt(1000).Bytes(),
+			},
+			Owner: []byte("erd1issuer"),
+			Roles: []string{},
+		},
+	}, ret)
+
+	info, found := trp.GetTokenInfo("TKN-abcdef")
+	require.True(t, found)
+	require.Equal(t, int32(6), info.Decimals)
+	require.Equal(t, core.FungibleESDT, info.TokenType)
+	require.Equal(t, []byte("erd1issuer"), info.Owner)
+
+	transfers := []*schema.TokenTransfer{
+		{Identifier: []byte("TKN-abcdef")},
+		{Identifier: []byte("OTHER-abcdef")},
+	}
+	trp.EnrichTokenTransfers(transfers)
+	require.Equal(t, int32(6), transfers[0].Decimals)
+	require.Nil(t, transfers[1].Decimals)
+}
+
+func TestTokenRegistryProcessor_ProcessTokenRegistryEvents_IssueResolvedInLaterBlock(t *testing.T) {
+	t.Parallel()
+
+	trp, _ := registry.NewTokenRegistryProcessor(&mock.PubKeyConverterStub{})
+
+	ret := trp.ProcessTokenRegistryEvents(metaHeader, issueTx(registry.IssueNonFungible, hexArgs("Collection", "NFT")), nil, nil)
+	require.Len(t, ret, 0)
+
+	ret = trp.ProcessTokenRegistryEvents(metaHeader, nil, issueResult("@6f6b"+hexArgs("NFT-abcdef")), nil)
+	require.Len(t, ret, 1)
+	require.Equal(t, []byte("NFT-abcdef"), ret[0].Identifier)
+	require.Equal(t, core.NonFungibleESDT, ret[0].Issue.TokenType)
+	require.Equal(t, []byte{}, ret[0].Issue.InitialSupply)
+
+	// the pending issuance is resolved only once
+	ret = trp.ProcessTokenRegistryEvents(metaHeader, nil, issueResult("@6f6b"+hexArgs("NFT-abcdef")), nil)
+	require.Len(t, ret, 0)
+}
+
+func TestTokenRegistryProcessor_ProcessTokenRegistryEvents_FailedIssue_ExpectNoEvent(t *testing.T) {
+	t.Parallel()
+
+	trp, _ := registry.NewTokenRegistryProcessor(&mock.PubKeyConverterStub{})
+
+	ret := trp.ProcessTokenRegistryEvents(metaHeader, issueTx(registry.RegisterMetaESDT, hexArgs("Meta", "META")+"@12"), nil, nil)
+	require.Len(t, ret, 0)
+
+	ret = trp.ProcessTokenRegistryEvents(metaHeader, nil, issueResult("@"+hex.EncodeToString([]byte("user error"))), nil)
+	require.Len(t, ret, 0)
+
+	ret = trp.ProcessTokenRegistryEvents(metaHeader, nil, issueResult("@6f6b"+hexArgs("META-abcdef")), nil)
+	require.Len(t, ret, 0)
+
+	_, found := trp.GetTokenInfo("META-abcdef")
+	require.False(t, found)
+}
+
+func TestTokenRegistryProcessor_ProcessTokenRegistryEvents_TokenManagement(t *testing.T) {
+	t.Parallel()
+
+	trp, _ := registry.NewTokenRegistryProcessor(&mock.PubKeyConverterStub{})
+	_ = trp.ProcessTokenRegistryEvents(
+		metaHeader,
+		issueTx(registry.IssueSemiFungible, hexArgs("Semi", "SFT")),
+		issueResult("@6f6b"+hexArgs("SFT-abcdef")),
+		nil)
+
+	txs := map[string]data.TransactionHandler{
+		"txHash1": &transaction.Transaction{
+			SndAddr: issuer,
+			RcvAddr: esdtSC,
+			Data:    []byte(registry.SetSpecialRole + hexArgs("SFT-abcdef", "user", core.ESDTRoleNFTCreate, core.ESDTRoleNFTBurn)),
+		},
+		"txHash2": &transaction.Transaction{
+			SndAddr: issuer,
+			RcvAddr: esdtSC,
+			Data:    []byte(registry.TransferOwnership + hexArgs("SFT-abcdef", "new owner")),
+		},
+		"txHash3": &transaction.Transaction{
+			SndAddr: issuer,
+			RcvAddr: esdtSC,
+			Data:    []byte(registry.Pause + hexArgs("SFT-abcdef")),
+		},
+		"txHash4": &transaction.Transaction{
+			SndAddr: issuer,
+			RcvAddr: esdtSC,
+			Data:    []byte(registry.Freeze + hexArgs("SFT-abcdef", "user")),
+		},
+		"txHash5": &transaction.Transaction{
+			SndAddr: issuer,
+			RcvAddr: esdtSC,
+			Data:    []byte(registry.Freeze + hexArgs("SFT-abcdef", "other user")),
+		},
+		"txHash6": &transaction.Transaction{
+			SndAddr: issuer,
+			RcvAddr: user,
+			Data:    []byte(registry.Pause + hexArgs("SFT-abcdef")),
+		},
+	}
+	logs := []*data.LogData{
+		{
+			TxHash:     "txHash5",
+			LogHandler: &transaction.Log{Events: []*transaction.Event{{Identifier: []byte("signalError")}}},
+		},
+	}
+
+	ret := trp.ProcessTokenRegistryEvents(metaHeader, txs, nil, logs)
+	require.Equal(t, []*schema.TokenRegistryEvent{
+		{
+			TxHash:     []byte("txHash1"),
+			Type:       registry.SetSpecialRole,
+			Caller:     []byte("erd1issuer"),
+			Identifier: []byte("SFT-abcdef"),
+			Address:    []byte("erd1user"),
+			Roles:      []string{core.ESDTRoleNFTCreate, core.ESDTRoleNFTBurn},
+		},
+		{
+			TxHash:     []byte("txHash2"),
+			Type:       registry.TransferOwnership,
+			Caller:     []byte("erd1issuer"),
+			Identifier: []byte("SFT-abcdef"),
+			Owner:      []byte("erd1new owner"),
+			Roles:      []string{},
+		},
+		{
+			TxHash:     []byte("txHash3"),
+			Type:       registry.Pause,
+			Caller:     []byte("erd1issuer"),
+			Identifier: []byte("SFT-abcdef"),
+			Roles:      []string{},
+		},
+		{
+			TxHash:     []byte("txHash4"),
+			Type:       registry.Freeze,
+			Caller:     []byte("erd1issuer"),
+			Identifier: []byte("SFT-abcdef"),
+			Address:    []byte("erd1user"),
+			Roles:      []string{},
+		},
+	}, ret)
+
+	info, found := trp.GetTokenInfo("SFT-abcdef")
+	require.True(t, found)
+	require.Equal(t, []byte("erd1new owner"), info.Owner)
+	require.True(t, info.Paused)
+	require.Equal(t, map[string][]string{"erd1user": {core.ESDTRoleNFTCreate, core.ESDTRoleNFTBurn}}, info.Roles)
+
+	ret = trp.ProcessTokenRegistryEvents(metaHeader, map[string]data.TransactionHandler{
+		"txHash7": &transaction.Transaction{
+			SndAddr: issuer,
+			RcvAddr: esdtSC,
+			Data:    []byte(registry.UnPause + hexArgs("SFT-abcdef")),
+		},
+	}, nil, nil)
+	require.Len(t, ret, 1)
+	require.Equal(t, registry.UnPause, ret[0].Type)
+
+	info, _ = trp.GetTokenInfo("SFT-abcdef")
+	require.False(t, info.Paused)
+}
+
+func TestTokenRegistryProcessor_ProcessTokenRegistryEvents_ShardBlock(t *testing.T) {
+	t.Parallel()
+
+	shardHeader := &block.Header{ShardID: 1}
+	trp, _ := registry.NewTokenRegistryProcessor(&mock.PubKeyConverterStub{})
+
+	// issuances sent from shards are resolved in the local registry by the result of the ESDT system smart contract,
+	// but only emitted by metachain
+	ret := trp.ProcessTokenRegistryEvents(shardHeader, issueTx(registry.IssueNonFungible, hexArgs("Collection", "NFT")), nil, nil)
+	require.Len(t, ret, 0)
+	ret = trp.ProcessTokenRegistryEvents(shardHeader, nil, issueResult("@6f6b"+hexArgs("NFT-abcdef")), nil)
+	require.Len(t, ret, 0)
+
+	// token management operations are not yet executed in shards
+	txs := map[string]data.TransactionHandler{
+		"txHash1": &transaction.Transaction{
+			SndAddr: issuer,
+			RcvAddr: esdtSC,
+			Data:    []byte(registry.TransferOwnership + hexArgs("NFT-abcdef", "new owner")),
+		},
+		"txHash2": &transaction.Transaction{
+			SndAddr: issuer,
+			RcvAddr: esdtSC,
+			Data:    []byte(registry.Pause + hexArgs("NFT-abcdef")),
+		},
+	}
+	ret = trp.ProcessTokenRegistryEvents(shardHeader, txs, nil, nil)
+	require.Len(t, ret, 0)
+
+	info, found := trp.GetTokenInfo("NFT-abcdef")
+	require.True(t, found)
+	require.Equal(t, []byte("erd1issuer"), info.Owner)
+	require.False(t, info.Paused)
+
+	transfers := []*schema.TokenTransfer{{Identifier: []byte("NFT-abcdef")}}
+	trp.EnrichTokenTransfers(transfers)
+	require.Equal(t, int32(0), transfers[0].Decimals)
+}
+
+func TestTokenRegistryProcessor_ProcessTokenRegistryEvents_IssuanceEmittedOnlyByMetachain(t *testing.T) {
+	t.Parallel()
+
+	txs := issueTx(registry.Issue, hexArgs("Token", "TKN")+"@03e8@06")
+	scrs := issueResult(core.BuiltInFunctionESDTTransfer + hexArgs("TKN-abcdef") + "@03e8")
+
+	// the issuance transaction and the result of the ESDT system smart contract are found in both the metachain
+	// block and the blocks of the issuer shard
+	metaProcessor, _ := registry.NewTokenRegistryProcessor(&mock.PubKeyConverterStub{})
+	ret := metaProcessor.ProcessTokenRegistryEvents(metaHeader, txs, scrs, nil)
+	require.Len(t, ret, 1)
+	require.Equal(t, registry.Issue, ret[0].Type)
+	require.Equal(t, []byte("TKN-abcdef"), ret[0].Identifier)
+
+	shardProcessor, _ := registry.NewTokenRegistryProcessor(&mock.PubKeyConverterStub{})
+	ret = shardProcessor.ProcessTokenRegistryEvents(&block.Header{ShardID: 0}, txs, nil, nil)
+	require.Len(t, ret, 0)
+	ret = shardProcessor.ProcessTokenRegistryEvents(&block.Header{ShardID: 0}, nil, scrs, nil)
+	require.Len(t, ret, 0)
+
+	info, found := shardProcessor.GetTokenInfo("TKN-abcdef")
+	require.True(t, found)
+	require.Equal(t, int32(6), info.Decimals)
+}
+
+func TestTokenRegistryProcessor_RevertTokenRegistry(t *testing.T) {
+	t.Parallel()
+
+	trp, _ := registry.NewTokenRegistryProcessor(&mock.PubKeyConverterStub{})
+
+	ret := trp.ProcessTokenRegistryEvents(&block.MetaBlock{TimeStamp: 100}, issueTx(registry.IssueSemiFungible, hexArgs("Semi", "SFT")), nil, nil)
+	require.Len(t, ret, 0)
+	ret = trp.ProcessTokenRegistryEvents(&block.MetaBlock{TimeStamp: 200}, nil, issueResult("@6f6b"+hexArgs("SFT-abcdef")), nil)
+	require.Len(t, ret, 1)
+	ret = trp.ProcessTokenRegistryEvents(&block.MetaBlock{TimeStamp: 300}, map[string]data.TransactionHandler{
+		"txHash1": &transaction.Transaction{
+			SndAddr: issuer,
+			RcvAddr: esdtSC,
+			Data:    []byte(registry.SetSpecialRole + hexArgs("SFT-abcdef", "user", core.ESDTRoleNFTCreate)),
+		},
+		"txHash2": &transaction.Transaction{
+			SndAddr: issuer,
+			RcvAddr: esdtSC,
+			Data:    []byte(registry.TransferOwnership + hexArgs("SFT-abcdef", "new owner")),
+		},
+		"txHash3": &transaction.Transaction{
+			SndAddr: issuer,
+			RcvAddr: esdtSC,
+			Data:    []byte(registry.Pause + hexArgs("SFT-abcdef")),
+		},
+	}, nil, nil)
+	require.Len(t, ret, 3)
+
+	trp.RevertTokenRegistry(300)
+	info, found := trp.GetTokenInfo("SFT-abcdef")
+	require.True(t, found)
+	require.Equal(t, []byte("erd1issuer"), info.Owner)
+	require.False(t, info.Paused)
+	require.Empty(t, info.Roles)
+
+	// reverting the block which resolved the issuance makes it pending again, so that it is resolved once re-processed
+	trp.RevertTokenRegistry(200)
+	_, found = trp.GetTokenInfo("SFT-abcdef")
+	require.False(t, found)
+	ret = trp.ProcessTokenRegistryEvents(&block.MetaBlock{TimeStamp: 200}, nil, issueResult("@6f6b"+hexArgs("SFT-abcdef")), nil)
+	require.Len(t, ret, 1)
+	require.Equal(t, []byte("SFT-abcdef"), ret[0].Identifier)
+
+	// reverting the blocks which issued the token drops the issuance
+	trp.RevertTokenRegistry(200)
+	trp.RevertTokenRegistry(100)
+	ret = trp.ProcessTokenRegistryEvents(&block.MetaBlock{TimeStamp: 200}, nil, issueResult("@6f6b"+hexArgs("SFT-abcdef")), nil)
+	require.Len(t, ret, 0)
+	_, found = trp.GetTokenInfo("SFT-abcdef")
+	require.False(t, found)
+}
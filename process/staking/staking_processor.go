@@ -0,0 +1,330 @@
+package staking
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/utility"
+	"github.com/ElrondNetwork/covalent-indexer-go/schema"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/smartContractResult"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/ElrondNetwork/elrond-vm-common/parsers"
+)
+
+const (
+	// Stake defines the type of the event emitted when nodes are staked on the validator system smart contract
+	Stake = "stake"
+	// UnStake defines the type of the event emitted when nodes are unstaked on the validator system smart contract
+	UnStake = "unStake"
+	// UnBond defines the type of the event emitted when nodes are unbonded on the validator system smart contract
+	UnBond = "unBond"
+	// Delegate defines the type of the event emitted when funds are delegated to a delegation contract
+	Delegate = "delegate"
+	// UnDelegate defines the type of the event emitted when funds are undelegated from a delegation contract
+	UnDelegate = "unDelegate"
+	// Withdraw defines the type of the event emitted when undelegated funds are withdrawn from a delegation contract
+	Withdraw = "withdraw"
+	// ClaimRewards defines the type of the event emitted when rewards are claimed from a delegation contract
+	ClaimRewards = "claimRewards"
+	// ReDelegateRewards defines the type of the event emitted when rewards are redelegated to a delegation contract
+	ReDelegateRewards = "reDelegateRewards"
+	// CreateNewDelegationContract defines the type of the event emitted when a delegation contract is created
+	// through the delegation manager system smart contract
+	CreateNewDelegationContract = "createNewDelegationContract"
+)
+
+const (
+	amountTopicIndex = 0
+	addressLength    = 32
+)
+
+var (
+	// validatorSCAddress is the address of the validator system smart contract, on which nodes are staked
+	validatorSCAddress = []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 255, 255}
+	// delegationManagerSCAddress is the address of the delegation manager system smart contract
+	delegationManagerSCAddress = []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 4, 255, 255}
+	// wellKnownSystemSCs holds the system smart contracts deployed at genesis, all other system smart contracts
+	// being delegation contracts created by the delegation manager
+	wellKnownSystemSCs = [][]byte{
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 255, 255},
+		validatorSCAddress,
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 255, 255},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 255, 255},
+		delegationManagerSCAddress,
+	}
+	systemVMType = []byte{0, 1}
+)
+
+// validatorFunctions holds the staking functions of the validator system smart contract
+var validatorFunctions = map[string]struct{}{
+	Stake:   {},
+	UnStake: {},
+	UnBond:  {},
+}
+
+// delegationFunctions holds the staking functions of the delegation contracts, which also emit log events
+// having the amount as first topic
+var delegationFunctions = map[string]struct{}{
+	Delegate:          {},
+	UnDelegate:        {},
+	Withdraw:          {},
+	ClaimRewards:      {},
+	ReDelegateRewards: {},
+}
+
+type stakingProcessor struct {
+	pubKeyConverter core.PubkeyConverter
+	callArgsParser  vmcommon.CallArgsParser
+}
+
+// NewStakingProcessor creates a new instance of staking processor
+func NewStakingProcessor(pubKeyConverter core.PubkeyConverter) (*stakingProcessor, error) {
+	if check.IfNil(pubKeyConverter) {
+		return nil, covalent.ErrNilPubKeyConverter
+	}
+
+	return &stakingProcessor{
+		pubKeyConverter: pubKeyConverter,
+		callArgsParser:  parsers.NewCallArgsParser(),
+	}, nil
+}
+
+// ProcessStakingEvents detects the interactions with the validator, delegation manager and delegation system smart
+// contracts from the log events emitted by delegation contracts, as well as from the call data of transactions and
+// smart contract results. Events found in logs are enriched with the details found in call data, while call data
+// operations without a corresponding log event are only kept if their originating transaction did not fail. Since
+// system smart contracts are only executed in metachain, call data is ignored in shard blocks, where such calls
+// are only sent cross shard
+func (sp *stakingProcessor) ProcessStakingEvents(
+	header data.HeaderHandler,
+	txs map[string]data.TransactionHandler,
+	scrs map[string]data.TransactionHandler,
+	logs []*data.LogData,
+) []*schema.StakingEvent {
+	events := utility.NewEventsMerger()
+
+	for _, logData := range logs {
+		if logData == nil || check.IfNil(logData.LogHandler) {
+			continue
+		}
+
+		txHash := utility.GetOriginalTxHash(scrs[logData.TxHash], logData.TxHash)
+		for _, event := range logData.LogHandler.GetLogEvents() {
+			sp.processLogEvent(event, logData.LogHandler.GetAddress(), txHash, events)
+		}
+	}
+
+	if !check.IfNil(header) && header.GetShardID() == core.MetachainShardId {
+		sp.processAllCallData(txs, scrs, events)
+	}
+
+	stakingEvents := make([]*schema.StakingEvent, 0, len(events.Events()))
+	for _, event := range events.Events() {
+		stakingEvents = append(stakingEvents, event.(*schema.StakingEvent))
+	}
+
+	return stakingEvents
+}
+
+func (sp *stakingProcessor) processAllCallData(
+	txs map[string]data.TransactionHandler,
+	scrs map[string]data.TransactionHandler,
+	events *utility.EventsMerger,
+) {
+	createdContracts := getCreatedDelegationContracts(scrs)
+	for _, hash := range utility.SortedHashes(txs) {
+		sp.processCallData(txs[hash], hash, createdContracts, events)
+	}
+	for _, hash := range utility.SortedHashes(scrs) {
+		sp.processCallData(scrs[hash], utility.GetOriginalTxHash(scrs[hash], hash), createdContracts, events)
+	}
+}
+
+func (sp *stakingProcessor) processLogEvent(event data.EventHandler, logAddress []byte, txHash string, events *utility.EventsMerger) {
+	if check.IfNil(event) {
+		return
+	}
+
+	identifier := string(event.GetIdentifier())
+	if identifier == utility.SignalErrorOperation {
+		events.MarkFailed(txHash)
+		return
+	}
+
+	_, isDelegationFunction := delegationFunctions[identifier]
+	if !isDelegationFunction || !isDelegationContract(logAddress) || len(event.GetAddress()) == 0 {
+		return
+	}
+
+	stakingEvent := sp.newStakingEvent(txHash, identifier, event.GetAddress(), logAddress)
+	if amount := utility.GetTopic(event.GetTopics(), amountTopicIndex); amount != nil {
+		stakingEvent.Amount = utility.GetBytes(big.NewInt(0).SetBytes(amount))
+	}
+
+	events.AddFromLog(txHash, stakingEvent.Type, eventKey(stakingEvent), stakingEvent)
+}
+
+func (sp *stakingProcessor) processCallData(
+	tx data.TransactionHandler,
+	txHash string,
+	createdContracts map[string][]byte,
+	events *utility.EventsMerger,
+) {
+	if check.IfNil(tx) || len(tx.GetData()) == 0 || !isSystemSCAddress(tx.GetRcvAddr()) {
+		return
+	}
+
+	function, args, err := sp.callArgsParser.ParseData(string(tx.GetData()))
+	if err != nil || !isStakingFunction(function, tx.GetRcvAddr()) {
+		return
+	}
+
+	stakingEvent := sp.newStakingEvent(txHash, function, tx.GetSndAddr(), tx.GetRcvAddr())
+	stakingEvent.Amount = getCallDataAmount(function, tx.GetValue(), args)
+	if function == CreateNewDelegationContract {
+		if contract, found := createdContracts[txHash]; found {
+			stakingEvent.DelegationContract = utility.EncodePubKey(sp.pubKeyConverter, contract)
+		}
+	}
+
+	existingEvent := events.AddFromCallData(txHash, stakingEvent.Type, eventKey(stakingEvent), stakingEvent)
+	if fromLog, ok := existingEvent.(*schema.StakingEvent); ok {
+		enrichEvent(fromLog, stakingEvent)
+	}
+}
+
+// newStakingEvent creates a staking event, setting the delegation contract involved, which is either the called
+// contract or, for nodes staked by delegation contracts on the validator system smart contract, the caller
+func (sp *stakingProcessor) newStakingEvent(txHash string, eventType string, caller []byte, contract []byte) *schema.StakingEvent {
+	stakingEvent := &schema.StakingEvent{
+		TxHash:   []byte(txHash),
+		Type:     eventType,
+		Caller:   utility.EncodePubKey(sp.pubKeyConverter, caller),
+		Contract: utility.EncodePubKey(sp.pubKeyConverter, contract),
+	}
+
+	switch {
+	case isDelegationContract(contract):
+		stakingEvent.DelegationContract = stakingEvent.Contract
+	case isDelegationContract(caller):
+		stakingEvent.DelegationContract = stakingEvent.Caller
+	}
+
+	return stakingEvent
+}
+
+func isStakingFunction(function string, receiver []byte) bool {
+	_, isValidatorFunction := validatorFunctions[function]
+	_, isDelegationFunction := delegationFunctions[function]
+
+	switch {
+	case isValidatorFunction:
+		return bytes.Equal(receiver, validatorSCAddress)
+	case isDelegationFunction:
+		return isDelegationContract(receiver)
+	case function == CreateNewDelegationContract:
+		return bytes.Equal(receiver, delegationManagerSCAddress)
+	default:
+		return false
+	}
+}
+
+// getCallDataAmount returns the amount of a staking operation, as found in call data: the call value for operations
+// which lock funds, the first argument when undelegating, or nil when the amount is only known by the contract
+func getCallDataAmount(function string, value *big.Int, args [][]byte) []byte {
+	switch function {
+	case Stake, Delegate, CreateNewDelegationContract:
+		return utility.GetBytes(value)
+	case UnDelegate:
+		if amount := utility.GetTopic(args, 0); amount != nil {
+			return utility.GetBytes(big.NewInt(0).SetBytes(amount))
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// getCreatedDelegationContracts returns, for each original transaction creating a delegation contract, the address
+// of the new contract, returned by the delegation manager as a smart contract result of the form @6f6b@address
+func getCreatedDelegationContracts(scrs map[string]data.TransactionHandler) map[string][]byte {
+	createdContracts := make(map[string][]byte)
+
+	for _, hash := range utility.SortedHashes(scrs) {
+		scr, isSCR := scrs[hash].(*smartContractResult.SmartContractResult)
+		if !isSCR || !bytes.Equal(scr.GetSndAddr(), delegationManagerSCAddress) {
+			continue
+		}
+
+		contract := getReturnedAddress(scr.GetData())
+		if isDelegationContract(contract) {
+			createdContracts[utility.GetOriginalTxHash(scr, hash)] = contract
+		}
+	}
+
+	return createdContracts
+}
+
+// getReturnedAddress returns the first return data of a successful smart contract result of the form @6f6b@address
+func getReturnedAddress(scrData []byte) []byte {
+	tokens := strings.Split(string(scrData), "@")
+	if len(tokens) < 3 || len(tokens[0]) != 0 || tokens[1] != hex.EncodeToString([]byte(vmcommon.Ok.String())) {
+		return nil
+	}
+
+	address, err := hex.DecodeString(tokens[2])
+	if err != nil {
+		return nil
+	}
+
+	return address
+}
+
+func isSystemSCAddress(address []byte) bool {
+	if len(address) != addressLength || !core.IsSmartContractAddress(address) {
+		return false
+	}
+
+	vmType := address[core.NumInitCharactersForScAddress-core.VMTypeLen : core.NumInitCharactersForScAddress]
+	return bytes.Equal(vmType, systemVMType) && bytes.Equal(address[addressLength-core.ShardIdentiferLen:], []byte{255, 255})
+}
+
+// isDelegationContract returns true if the address is a system smart contract other than the ones deployed at genesis
+func isDelegationContract(address []byte) bool {
+	if !isSystemSCAddress(address) {
+		return false
+	}
+
+	for _, systemSC := range wellKnownSystemSCs {
+		if bytes.Equal(address, systemSC) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// enrichEvent adds to an event found in logs the details which are only found in call data
+func enrichEvent(event *schema.StakingEvent, fromCallData *schema.StakingEvent) {
+	if event.Amount == nil {
+		event.Amount = fromCallData.Amount
+	}
+	if event.DelegationContract == nil {
+		event.DelegationContract = fromCallData.DelegationContract
+	}
+}
+
+func eventKey(event *schema.StakingEvent) string {
+	return string(event.TxHash) + "|" + event.Type + "|" + string(event.Caller) + "|" + string(event.Contract)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (sp *stakingProcessor) IsInterfaceNil() bool {
+	return sp == nil
+}
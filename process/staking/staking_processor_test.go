@@ -0,0 +1,287 @@
+package staking_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/staking"
+	"github.com/ElrondNetwork/covalent-indexer-go/schema"
+	"github.com/ElrondNetwork/covalent-indexer-go/testscommon/mock"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/block"
+	"github.com/ElrondNetwork/elrond-go-core/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	validatorSC         = systemSCAddress(0, 1)
+	delegationManagerSC = systemSCAddress(0, 4)
+	delegationSC        = systemSCAddress(1, 0)
+	user                = []byte("user address with thirty two len")
+	metaHeader          = &block.MetaBlock{}
+)
+
+func systemSCAddress(id1 byte, id2 byte) []byte {
+	address := make([]byte, 32)
+	address[9] = 1
+	address[28] = id1
+	address[29] = id2
+	address[30] = 255
+	address[31] = 255
+
+	return address
+}
+
+func encoded(address []byte) []byte {
+	return []byte("erd1" + string(address))
+}
+
+func TestNewStakingProcessor(t *testing.T) {
+	t.Parallel()
+
+	sp, err := staking.NewStakingProcessor(nil)
+	require.Nil(t, sp)
+	require.Equal(t, covalent.ErrNilPubKeyConverter, err)
+
+	sp, err = staking.NewStakingProcessor(&mock.PubKeyConverterStub{})
+	require.NotNil(t, sp)
+	require.Nil(t, err)
+}
+
+func TestStakingProcessor_ProcessStakingEvents_CallData(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		tx            *transaction.Transaction
+		expectedEvent *schema.StakingEvent
+	}{
+		{
+			name: "stake",
+			tx:   &transaction.Transaction{SndAddr: user, RcvAddr: validatorSC, Value: big.NewInt(2500), Data: []byte("stake@01@0a@0b")},
+			expectedEvent: &schema.StakingEvent{
+				TxHash:   []byte("txHash"),
+				Type:     staking.Stake,
+				Caller:   encoded(user),
+				Contract: encoded(validatorSC),
+				Amount:   big.NewInt(2500).Bytes(),
+			},
+		},
+		{
+			name: "unBond, unknown amount",
+			tx:   &transaction.Transaction{SndAddr: user, RcvAddr: validatorSC, Value: big.NewInt(0), Data: []byte("unBond@0a")},
+			expectedEvent: &schema.StakingEvent{
+				TxHash:   []byte("txHash"),
+				Type:     staking.UnBond,
+				Caller:   encoded(user),
+				Contract: encoded(validatorSC),
+			},
+		},
+		{
+			name: "undelegate",
+			tx:   &transaction.Transaction{SndAddr: user, RcvAddr: delegationSC, Value: big.NewInt(0), Data: []byte("unDelegate@64")},
+			expectedEvent: &schema.StakingEvent{
+				TxHash:             []byte("txHash"),
+				Type:               staking.UnDelegate,
+				Caller:             encoded(user),
+				Contract:           encoded(delegationSC),
+				DelegationContract: encoded(delegationSC),
+				Amount:             big.NewInt(100).Bytes(),
+			},
+		},
+		{
+			name:          "delegate to the validator system smart contract",
+			tx:            &transaction.Transaction{SndAddr: user, RcvAddr: validatorSC, Value: big.NewInt(10), Data: []byte("delegate")},
+			expectedEvent: nil,
+		},
+		{
+			name:          "stake on a user smart contract",
+			tx:            &transaction.Transaction{SndAddr: user, RcvAddr: append(make([]byte, 10), []byte("user contract with 22")...), Data: []byte("stake")},
+			expectedEvent: nil,
+		},
+	}
+
+	for _, currTest := range tests {
+		sp, _ := staking.NewStakingProcessor(&mock.PubKeyConverterStub{})
+
+		ret := sp.ProcessStakingEvents(metaHeader, map[string]data.TransactionHandler{"txHash": currTest.tx}, nil, nil)
+		if currTest.expectedEvent == nil {
+			require.Len(t, ret, 0, currTest.name)
+			continue
+		}
+
+		require.Len(t, ret, 1, currTest.name)
+		require.Equal(t, currTest.expectedEvent, ret[0], currTest.name)
+	}
+}
+
+func TestStakingProcessor_ProcessStakingEvents_DelegateFromLogAndCallData(t *testing.T) {
+	t.Parallel()
+
+	sp, _ := staking.NewStakingProcessor(&mock.PubKeyConverterStub{})
+
+	txs := map[string]data.TransactionHandler{
+		"txHash": &transaction.Transaction{SndAddr: user, RcvAddr: delegationSC, Value: big.NewInt(1000), Data: []byte("delegate")},
+	}
+	scrs := map[string]data.TransactionHandler{
+		// nodes staked on the validator system smart contract by the delegation contract
+		"scrHash": &smartContractResult.SmartContractResult{
+			SndAddr:        delegationSC,
+			RcvAddr:        validatorSC,
+			Value:          big.NewInt(1000),
+			Data:           []byte("stake"),
+			OriginalTxHash: []byte("txHash"),
+		},
+	}
+	logs := []*data.LogData{
+		{
+			TxHash: "txHash",
+			LogHandler: &transaction.Log{
+				Address: delegationSC,
+				Events: []*transaction.Event{
+					{Address: user, Identifier: []byte("delegate"), Topics: [][]byte{big.NewInt(1000).Bytes(), big.NewInt(5000).Bytes()}},
+				},
+			},
+		},
+	}
+
+	ret := sp.ProcessStakingEvents(metaHeader, txs, scrs, logs)
+	require.Equal(t, []*schema.StakingEvent{
+		{
+			TxHash:             []byte("txHash"),
+			Type:               staking.Delegate,
+			Caller:             encoded(user),
+			Contract:           encoded(delegationSC),
+			DelegationContract: encoded(delegationSC),
+			Amount:             big.NewInt(1000).Bytes(),
+		},
+		{
+			TxHash:             []byte("txHash"),
+			Type:               staking.Stake,
+			Caller:             encoded(delegationSC),
+			Contract:           encoded(validatorSC),
+			DelegationContract: encoded(delegationSC),
+			Amount:             big.NewInt(1000).Bytes(),
+		},
+	}, ret)
+}
+
+func TestStakingProcessor_ProcessStakingEvents_ClaimRewardsFromLog(t *testing.T) {
+	t.Parallel()
+
+	sp, _ := staking.NewStakingProcessor(&mock.PubKeyConverterStub{})
+
+	logs := []*data.LogData{
+		{
+			TxHash: "txHash",
+			LogHandler: &transaction.Log{
+				Address: delegationSC,
+				Events: []*transaction.Event{
+					{Address: user, Identifier: []byte("claimRewards"), Topics: [][]byte{big.NewInt(42).Bytes()}},
+				},
+			},
+		},
+		{
+			// events with the same identifiers emitted by user smart contracts are ignored
+			TxHash: "txHash2",
+			LogHandler: &transaction.Log{
+				Address: []byte("user contract"),
+				Events: []*transaction.Event{
+					{Address: user, Identifier: []byte("claimRewards"), Topics: [][]byte{big.NewInt(42).Bytes()}},
+				},
+			},
+		},
+	}
+
+	ret := sp.ProcessStakingEvents(metaHeader, nil, nil, logs)
+	require.Len(t, ret, 1)
+	require.Equal(t, staking.ClaimRewards, ret[0].Type)
+	require.Equal(t, encoded(delegationSC), ret[0].DelegationContract)
+	require.Equal(t, big.NewInt(42).Bytes(), ret[0].Amount)
+}
+
+func TestStakingProcessor_ProcessStakingEvents_CreateNewDelegationContract(t *testing.T) {
+	t.Parallel()
+
+	sp, _ := staking.NewStakingProcessor(&mock.PubKeyConverterStub{})
+
+	txs := map[string]data.TransactionHandler{
+		"txHash": &transaction.Transaction{
+			SndAddr: user,
+			RcvAddr: delegationManagerSC,
+			Value:   big.NewInt(1250),
+			Data:    []byte("createNewDelegationContract@00@0a"),
+		},
+	}
+	scrs := map[string]data.TransactionHandler{
+		"scrHash": &smartContractResult.SmartContractResult{
+			SndAddr:        delegationManagerSC,
+			RcvAddr:        user,
+			Data:           []byte("@6f6b@" + hex.EncodeToString(delegationSC)),
+			OriginalTxHash: []byte("txHash"),
+		},
+	}
+
+	ret := sp.ProcessStakingEvents(metaHeader, txs, scrs, nil)
+	require.Equal(t, []*schema.StakingEvent{
+		{
+			TxHash:             []byte("txHash"),
+			Type:               staking.CreateNewDelegationContract,
+			Caller:             encoded(user),
+			Contract:           encoded(delegationManagerSC),
+			DelegationContract: encoded(delegationSC),
+			Amount:             big.NewInt(1250).Bytes(),
+		},
+	}, ret)
+}
+
+func TestStakingProcessor_ProcessStakingEvents_FailedTx_ExpectNoEvent(t *testing.T) {
+	t.Parallel()
+
+	sp, _ := staking.NewStakingProcessor(&mock.PubKeyConverterStub{})
+
+	txs := map[string]data.TransactionHandler{
+		"txHash": &transaction.Transaction{SndAddr: user, RcvAddr: validatorSC, Value: big.NewInt(10), Data: []byte("stake")},
+	}
+	logs := []*data.LogData{
+		{
+			TxHash: "txHash",
+			LogHandler: &transaction.Log{
+				Events: []*transaction.Event{{Identifier: []byte("signalError")}},
+			},
+		},
+	}
+
+	ret := sp.ProcessStakingEvents(metaHeader, txs, nil, logs)
+	require.Len(t, ret, 0)
+}
+
+func TestStakingProcessor_ProcessStakingEvents_ShardBlock_ExpectCallDataIgnored(t *testing.T) {
+	t.Parallel()
+
+	sp, _ := staking.NewStakingProcessor(&mock.PubKeyConverterStub{})
+
+	txs := map[string]data.TransactionHandler{
+		"txHash1": &transaction.Transaction{SndAddr: user, RcvAddr: validatorSC, Value: big.NewInt(10), Data: []byte("stake")},
+		"txHash2": &transaction.Transaction{SndAddr: user, RcvAddr: delegationSC, Value: big.NewInt(10), Data: []byte("delegate")},
+	}
+	logs := []*data.LogData{
+		{
+			TxHash: "txHash3",
+			LogHandler: &transaction.Log{
+				Address: delegationSC,
+				Events: []*transaction.Event{
+					{Address: user, Identifier: []byte("claimRewards"), Topics: [][]byte{big.NewInt(42).Bytes()}},
+				},
+			},
+		},
+	}
+
+	ret := sp.ProcessStakingEvents(&block.Header{ShardID: 1}, txs, nil, logs)
+	require.Len(t, ret, 1)
+	require.Equal(t, []byte("txHash3"), ret[0].TxHash)
+	require.Equal(t, staking.ClaimRewards, ret[0].Type)
+}
@@ -0,0 +1,246 @@
+package tokens
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/utility"
+	"github.com/ElrondNetwork/covalent-indexer-go/schema"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/ElrondNetwork/elrond-vm-common/parsers"
+)
+
+var log = logger.GetOrCreate("covalent/process/tokens")
+
+const (
+	tokenIdentifierTopicIndex = 0
+	tokenNonceTopicIndex      = 1
+	tokenAmountTopicIndex     = 2
+	tokenReceiverTopicIndex   = 3
+	minTokenTransferTopics    = 4
+)
+
+var transferIdentifiers = map[string]struct{}{
+	core.BuiltInFunctionESDTTransfer:         {},
+	core.BuiltInFunctionESDTNFTTransfer:      {},
+	core.BuiltInFunctionMultiESDTNFTTransfer: {},
+}
+
+type tokenTransfersProcessor struct {
+	pubKeyConverter    core.PubkeyConverter
+	callArgsParser     vmcommon.CallArgsParser
+	esdtTransferParser vmcommon.ESDTTransferParser
+}
+
+// NewTokenTransfersProcessor creates a new instance of token transfers processor
+func NewTokenTransfersProcessor(pubKeyConverter core.PubkeyConverter, marshaller marshal.Marshalizer) (*tokenTransfersProcessor, error) {
+	if check.IfNil(pubKeyConverter) {
+		return nil, covalent.ErrNilPubKeyConverter
+	}
+	if check.IfNil(marshaller) {
+		return nil, covalent.ErrNilMarshaller
+	}
+
+	esdtTransferParser, err := parsers.NewESDTTransferParser(marshaller)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tokenTransfersProcessor{
+		pubKeyConverter:    pubKeyConverter,
+		callArgsParser:     parsers.NewCallArgsParser(),
+		esdtTransferParser: esdtTransferParser,
+	}, nil
+}
+
+// ProcessTokenTransfers extracts all ESDT, NFT and SFT transfers to a specific structure defined by avro schema.
+// Transfer events from logs are preferred. Transactions and smart contract results call data is only decoded
+// for the originating transactions which have no transfer event logged (e.g. logs are not saved by the node), in
+// ascending order of the transactions and smart contract results hashes
+func (ttp *tokenTransfersProcessor) ProcessTokenTransfers(
+	txs map[string]data.TransactionHandler,
+	scrs map[string]data.TransactionHandler,
+	logs []*data.LogData,
+) []*schema.TokenTransfer {
+	transfers := ttp.processTransferEvents(scrs, logs)
+
+	txHashesWithEvents := make(map[string]struct{})
+	for _, transfer := range transfers {
+		txHashesWithEvents[string(transfer.TxHash)] = struct{}{}
+	}
+
+	processedTransfers := make(map[string]struct{})
+	for _, txHash := range utility.SortedHashes(txs) {
+		transfers = ttp.appendCallDataTransfers(transfers, txs[txHash], txHash, txHash, txHashesWithEvents, processedTransfers)
+	}
+	for _, scrHash := range utility.SortedHashes(scrs) {
+		scr := scrs[scrHash]
+		sourceHash := getSourceHash(scr, scrHash, txs, scrs)
+		transfers = ttp.appendCallDataTransfers(transfers, scr, utility.GetOriginalTxHash(scr, scrHash), sourceHash, txHashesWithEvents, processedTransfers)
+	}
+
+	return transfers
+}
+
+func (ttp *tokenTransfersProcessor) processTransferEvents(scrs map[string]data.TransactionHandler, logs []*data.LogData) []*schema.TokenTransfer {
+	transfers := make([]*schema.TokenTransfer, 0)
+
+	for _, logData := range logs {
+		if logData == nil || check.IfNil(logData.LogHandler) {
+			continue
+		}
+
+		txHash := logData.TxHash
+		scr, found := scrs[txHash]
+		if found {
+			txHash = utility.GetOriginalTxHash(scr, txHash)
+		}
+
+		for _, event := range logData.LogHandler.GetLogEvents() {
+			transfer := ttp.processTransferEvent(event, txHash)
+			if transfer != nil {
+				transfers = append(transfers, transfer)
+			}
+		}
+	}
+
+	return transfers
+}
+
+func (ttp *tokenTransfersProcessor) processTransferEvent(event data.EventHandler, txHash string) *schema.TokenTransfer {
+	if check.IfNil(event) {
+		return nil
+	}
+
+	_, isTransfer := transferIdentifiers[string(event.GetIdentifier())]
+	topics := event.GetTopics()
+	if !isTransfer || len(topics) < minTokenTransferTopics {
+		return nil
+	}
+
+	return &schema.TokenTransfer{
+		TxHash:     []byte(txHash),
+		Identifier: topics[tokenIdentifierTopicIndex],
+		Nonce:      int64(big.NewInt(0).SetBytes(topics[tokenNonceTopicIndex]).Uint64()),
+		Amount:     big.NewInt(0).SetBytes(topics[tokenAmountTopicIndex]).Bytes(),
+		Sender:     utility.EncodePubKey(ttp.pubKeyConverter, event.GetAddress()),
+		Receiver:   utility.EncodePubKey(ttp.pubKeyConverter, topics[tokenReceiverTopicIndex]),
+	}
+}
+
+func (ttp *tokenTransfersProcessor) appendCallDataTransfers(
+	transfers []*schema.TokenTransfer,
+	tx data.TransactionHandler,
+	txHash string,
+	sourceHash string,
+	txHashesWithEvents map[string]struct{},
+	processedTransfers map[string]struct{},
+) []*schema.TokenTransfer {
+	_, hasEvents := txHashesWithEvents[txHash]
+	if hasEvents || check.IfNil(tx) {
+		return transfers
+	}
+
+	for index, transfer := range ttp.processCallData(tx, txHash) {
+		// the same transfer can be found both in the transaction and in the cross shard smart contract result it generated
+		key := getTransferKey(sourceHash, index)
+		_, processed := processedTransfers[key]
+		if processed {
+			continue
+		}
+
+		processedTransfers[key] = struct{}{}
+		transfers = append(transfers, transfer)
+	}
+
+	return transfers
+}
+
+func (ttp *tokenTransfersProcessor) processCallData(tx data.TransactionHandler, txHash string) []*schema.TokenTransfer {
+	function, args, err := ttp.callArgsParser.ParseData(string(tx.GetData()))
+	if err != nil {
+		return nil
+	}
+
+	_, isTransfer := transferIdentifiers[function]
+	if !isTransfer {
+		return nil
+	}
+
+	parsedTransfers, err := ttp.esdtTransferParser.ParseESDTTransfers(tx.GetSndAddr(), tx.GetRcvAddr(), function, args)
+	if err != nil {
+		log.Debug("tokenTransfersProcessor.processCallData could not parse token transfer", "tx hash", []byte(txHash), "error", err)
+		return nil
+	}
+
+	sender := utility.EncodePubKey(ttp.pubKeyConverter, tx.GetSndAddr())
+	receiver := utility.EncodePubKey(ttp.pubKeyConverter, parsedTransfers.RcvAddr)
+
+	transfers := make([]*schema.TokenTransfer, 0, len(parsedTransfers.ESDTTransfers))
+	for _, esdtTransfer := range parsedTransfers.ESDTTransfers {
+		transfers = append(transfers, &schema.TokenTransfer{
+			TxHash:     []byte(txHash),
+			Identifier: esdtTransfer.ESDTTokenName,
+			Nonce:      int64(esdtTransfer.ESDTTokenNonce),
+			Amount:     utility.GetBytes(esdtTransfer.ESDTValue),
+			Sender:     sender,
+			Receiver:   receiver,
+		})
+	}
+
+	return transfers
+}
+
+// getSourceHash returns the hash of the record which carried the transfers call data of a smart contract result
+// first: the parent transaction or smart contract result, when the smart contract result only relays its call
+// data cross shard, or the hash of the smart contract result itself otherwise
+func getSourceHash(
+	scr data.TransactionHandler,
+	scrHash string,
+	txs map[string]data.TransactionHandler,
+	scrs map[string]data.TransactionHandler,
+) string {
+	parentHash := getParentTxHash(scr)
+	if len(parentHash) == 0 {
+		return scrHash
+	}
+
+	parent, found := txs[parentHash]
+	if !found {
+		parent, found = scrs[parentHash]
+	}
+	if !found || check.IfNil(parent) || !bytes.Equal(parent.GetData(), scr.GetData()) {
+		return scrHash
+	}
+
+	return parentHash
+}
+
+func getParentTxHash(tx data.TransactionHandler) string {
+	scr, ok := tx.(*smartContractResult.SmartContractResult)
+	if !ok {
+		return ""
+	}
+	if len(scr.GetPrevTxHash()) != 0 {
+		return string(scr.GetPrevTxHash())
+	}
+
+	return string(scr.GetOriginalTxHash())
+}
+
+func getTransferKey(sourceHash string, index int) string {
+	return fmt.Sprintf("%x_%d", sourceHash, index)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ttp *tokenTransfersProcessor) IsInterfaceNil() bool {
+	return ttp == nil
+}
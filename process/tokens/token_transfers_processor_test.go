@@ -0,0 +1,271 @@
+package tokens_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/tokens"
+	"github.com/ElrondNetwork/covalent-indexer-go/schema"
+	"github.com/ElrondNetwork/covalent-indexer-go/testscommon/mock"
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	sender   = bytes.Repeat([]byte("s"), 32)
+	receiver = bytes.Repeat([]byte("r"), 32)
+)
+
+func TestNewTokenTransfersProcessor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pubKeyConverter core.PubkeyConverter
+		marshaller      marshal.Marshalizer
+		expectedErr     error
+	}{
+		{
+			pubKeyConverter: nil,
+			marshaller:      &mock.MarshallerStub{},
+			expectedErr:     covalent.ErrNilPubKeyConverter,
+		},
+		{
+			pubKeyConverter: &mock.PubKeyConverterStub{},
+			marshaller:      nil,
+			expectedErr:     covalent.ErrNilMarshaller,
+		},
+		{
+			pubKeyConverter: &mock.PubKeyConverterStub{},
+			marshaller:      &mock.MarshallerStub{},
+			expectedErr:     nil,
+		},
+	}
+
+	for _, currTest := range tests {
+		_, err := tokens.NewTokenTransfersProcessor(currTest.pubKeyConverter, currTest.marshaller)
+		require.Equal(t, currTest.expectedErr, err)
+	}
+}
+
+func TestTokenTransfersProcessor_ProcessTokenTransfers_TransferEvents(t *testing.T) {
+	t.Parallel()
+
+	ttp, _ := tokens.NewTokenTransfersProcessor(&mock.PubKeyConverterStub{}, &mock.MarshallerStub{})
+
+	logs := []*data.LogData{
+		{TxHash: "hash1", LogHandler: nil},
+		{
+			TxHash: "scr hash",
+			LogHandler: &transaction.Log{
+				Events: []*transaction.Event{
+					nil,
+					{
+						Address:    sender,
+						Identifier: []byte(core.BuiltInFunctionESDTTransfer),
+						Topics:     [][]byte{[]byte("TKN-abcdef"), {}, big.NewInt(100).Bytes(), receiver},
+					},
+					{
+						Address:    sender,
+						Identifier: []byte(core.BuiltInFunctionESDTNFTTransfer),
+						Topics:     [][]byte{[]byte("NFT-abcdef"), big.NewInt(4).Bytes(), big.NewInt(1).Bytes(), receiver},
+					},
+					{
+						Address:    sender,
+						Identifier: []byte(core.BuiltInFunctionMultiESDTNFTTransfer),
+						Topics:     [][]byte{[]byte("SFT-abcdef"), big.NewInt(5).Bytes(), big.NewInt(7).Bytes()},
+					},
+					{
+						Address:    sender,
+						Identifier: []byte(core.BuiltInFunctionESDTNFTCreate),
+						Topics:     [][]byte{[]byte("NFT-abcdef"), big.NewInt(5).Bytes(), big.NewInt(1).Bytes(), {}},
+					},
+				},
+			},
+		},
+	}
+	scrs := map[string]data.TransactionHandler{
+		"scr hash": &smartContractResult.SmartContractResult{OriginalTxHash: []byte("original tx hash")},
+	}
+
+	ret := ttp.ProcessTokenTransfers(nil, scrs, logs)
+
+	require.Equal(t, []*schema.TokenTransfer{
+		{
+			TxHash:     []byte("original tx hash"),
+			Identifier: []byte("TKN-abcdef"),
+			Nonce:      0,
+			Amount:     big.NewInt(100).Bytes(),
+			Sender:     []byte("erd1" + string(sender)),
+			Receiver:   []byte("erd1" + string(receiver)),
+		},
+		{
+			TxHash:     []byte("original tx hash"),
+			Identifier: []byte("NFT-abcdef"),
+			Nonce:      4,
+			Amount:     big.NewInt(1).Bytes(),
+			Sender:     []byte("erd1" + string(sender)),
+			Receiver:   []byte("erd1" + string(receiver)),
+		},
+	}, ret)
+}
+
+func TestTokenTransfersProcessor_ProcessTokenTransfers_CallData(t *testing.T) {
+	t.Parallel()
+
+	ttp, _ := tokens.NewTokenTransfersProcessor(&mock.PubKeyConverterStub{}, &mock.MarshallerStub{})
+
+	tests := []struct {
+		data              string
+		txReceiver        []byte
+		expectedTransfers []*schema.TokenTransfer
+	}{
+		{
+			data:       "ESDTTransfer@" + hex.EncodeToString([]byte("TKN-abcdef")) + "@64",
+			txReceiver: receiver,
+			expectedTransfers: []*schema.TokenTransfer{
+				{Identifier: []byte("TKN-abcdef"), Nonce: 0, Amount: big.NewInt(100).Bytes()},
+			},
+		},
+		{
+			data:       "ESDTNFTTransfer@" + hex.EncodeToString([]byte("NFT-abcdef")) + "@04@01@" + hex.EncodeToString(receiver),
+			txReceiver: sender,
+			expectedTransfers: []*schema.TokenTransfer{
+				{Identifier: []byte("NFT-abcdef"), Nonce: 4, Amount: big.NewInt(1).Bytes()},
+			},
+		},
+		{
+			data: "MultiESDTNFTTransfer@" + hex.EncodeToString(receiver) + "@02" +
+				"@" + hex.EncodeToString([]byte("TKN-abcdef")) + "@@0a" +
+				"@" + hex.EncodeToString([]byte("SFT-abcdef")) + "@05@07",
+			txReceiver: sender,
+			expectedTransfers: []*schema.TokenTransfer{
+				{Identifier: []byte("TKN-abcdef"), Nonce: 0, Amount: big.NewInt(10).Bytes()},
+				{Identifier: []byte("SFT-abcdef"), Nonce: 5, Amount: big.NewInt(7).Bytes()},
+			},
+		},
+		{
+			data:              "ESDTNFTCreate@" + hex.EncodeToString([]byte("NFT-abcdef")) + "@01",
+			txReceiver:        sender,
+			expectedTransfers: []*schema.TokenTransfer{},
+		},
+		{
+			data:              "ESDTTransfer@" + hex.EncodeToString([]byte("TKN-abcdef")),
+			txReceiver:        receiver,
+			expectedTransfers: []*schema.TokenTransfer{},
+		},
+	}
+
+	for _, currTest := range tests {
+		txs := map[string]data.TransactionHandler{
+			"tx hash": &transaction.Transaction{
+				SndAddr: sender,
+				RcvAddr: currTest.txReceiver,
+				Data:    []byte(currTest.data),
+			},
+		}
+
+		for _, transfer := range currTest.expectedTransfers {
+			transfer.TxHash = []byte("tx hash")
+			transfer.Sender = []byte("erd1" + string(sender))
+			transfer.Receiver = []byte("erd1" + string(receiver))
+		}
+
+		ret := ttp.ProcessTokenTransfers(txs, nil, nil)
+		require.Equal(t, currTest.expectedTransfers, ret)
+	}
+}
+
+func TestTokenTransfersProcessor_ProcessTokenTransfers_CrossShardTransfer_ExpectNoDuplicates(t *testing.T) {
+	t.Parallel()
+
+	ttp, _ := tokens.NewTokenTransfersProcessor(&mock.PubKeyConverterStub{}, &mock.MarshallerStub{})
+
+	transferData := []byte("ESDTTransfer@" + hex.EncodeToString([]byte("TKN-abcdef")) + "@64")
+	txs := map[string]data.TransactionHandler{
+		"tx hash": &transaction.Transaction{SndAddr: sender, RcvAddr: receiver, Data: transferData},
+	}
+	scrs := map[string]data.TransactionHandler{
+		"scr hash": &smartContractResult.SmartContractResult{
+			SndAddr:        sender,
+			RcvAddr:        receiver,
+			Data:           transferData,
+			OriginalTxHash: []byte("tx hash"),
+		},
+	}
+
+	ret := ttp.ProcessTokenTransfers(txs, scrs, nil)
+	require.Len(t, ret, 1)
+	require.Equal(t, []byte("tx hash"), ret[0].TxHash)
+}
+
+func TestTokenTransfersProcessor_ProcessTokenTransfers_IdenticalTransfersInDifferentSCRs_ExpectBothProcessed(t *testing.T) {
+	t.Parallel()
+
+	ttp, _ := tokens.NewTokenTransfersProcessor(&mock.PubKeyConverterStub{}, &mock.MarshallerStub{})
+
+	transferData := []byte("ESDTTransfer@" + hex.EncodeToString([]byte("TKN-abcdef")) + "@64")
+	txs := map[string]data.TransactionHandler{
+		"tx hash": &transaction.Transaction{SndAddr: sender, RcvAddr: receiver, Data: []byte("payTwice")},
+	}
+	scrs := map[string]data.TransactionHandler{
+		"scr hash 1": &smartContractResult.SmartContractResult{
+			SndAddr:        receiver,
+			RcvAddr:        sender,
+			Data:           transferData,
+			PrevTxHash:     []byte("tx hash"),
+			OriginalTxHash: []byte("tx hash"),
+		},
+		"scr hash 2": &smartContractResult.SmartContractResult{
+			SndAddr:        receiver,
+			RcvAddr:        sender,
+			Data:           transferData,
+			PrevTxHash:     []byte("tx hash"),
+			OriginalTxHash: []byte("tx hash"),
+		},
+	}
+
+	ret := ttp.ProcessTokenTransfers(txs, scrs, nil)
+	require.Len(t, ret, 2)
+	require.Equal(t, ret[0], ret[1])
+	require.Equal(t, []byte("tx hash"), ret[0].TxHash)
+	require.Equal(t, big.NewInt(100).Bytes(), ret[0].Amount)
+}
+
+func TestTokenTransfersProcessor_ProcessTokenTransfers_TxWithTransferEvents_ExpectCallDataIgnored(t *testing.T) {
+	t.Parallel()
+
+	ttp, _ := tokens.NewTokenTransfersProcessor(&mock.PubKeyConverterStub{}, &mock.MarshallerStub{})
+
+	txs := map[string]data.TransactionHandler{
+		"tx hash": &transaction.Transaction{
+			SndAddr: sender,
+			RcvAddr: receiver,
+			Data:    []byte("ESDTTransfer@" + hex.EncodeToString([]byte("TKN-abcdef")) + "@64"),
+		},
+	}
+	logs := []*data.LogData{
+		{
+			TxHash: "tx hash",
+			LogHandler: &transaction.Log{
+				Events: []*transaction.Event{
+					{
+						Address:    sender,
+						Identifier: []byte(core.BuiltInFunctionESDTTransfer),
+						Topics:     [][]byte{[]byte("TKN-abcdef"), {}, big.NewInt(100).Bytes(), receiver},
+					},
+				},
+			},
+		},
+	}
+
+	ret := ttp.ProcessTokenTransfers(txs, nil, logs)
+	require.Len(t, ret, 1)
+	require.Equal(t, []byte("erd1"+string(receiver)), ret[0].Receiver)
+}
@@ -0,0 +1,158 @@
+package transactions
+
+import (
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/ElrondNetwork/elrond-vm-common/parsers"
+)
+
+const (
+	// OperationTransfer defines the operation of a value transfer, which calls no function
+	OperationTransfer = "transfer"
+	// OperationSCCall defines the operation of a smart contract function call
+	OperationSCCall = "scCall"
+	// OperationSCDeploy defines the operation of a smart contract deploy
+	OperationSCDeploy = "scDeploy"
+	// OperationESDTTransfer defines the operation of a fungible token transfer
+	OperationESDTTransfer = "esdtTransfer"
+	// OperationNFTTransfer defines the operation of a non/semi fungible token transfer
+	OperationNFTTransfer = "nftTransfer"
+	// OperationMultiTransfer defines the operation of a multiple tokens transfer
+	OperationMultiTransfer = "multiTransfer"
+	// OperationBuiltInCall defines the operation of a built-in function call, other than token transfers
+	OperationBuiltInCall = "builtInCall"
+	// OperationRelayed defines the operation of a relayed transaction
+	OperationRelayed = "relayed"
+)
+
+// tokenTransferOperations maps the token transfer built-in functions to their operations
+var tokenTransferOperations = map[string]string{
+	core.BuiltInFunctionESDTTransfer:         OperationESDTTransfer,
+	core.BuiltInFunctionESDTNFTTransfer:      OperationNFTTransfer,
+	core.BuiltInFunctionMultiESDTNFTTransfer: OperationMultiTransfer,
+}
+
+// builtInFunctions holds the names of all the built-in functions known by the node
+var builtInFunctions = map[string]struct{}{
+	core.BuiltInFunctionClaimDeveloperRewards:     {},
+	core.BuiltInFunctionChangeOwnerAddress:        {},
+	core.BuiltInFunctionSetUserName:               {},
+	core.BuiltInFunctionSaveKeyValue:              {},
+	core.BuiltInFunctionESDTTransfer:              {},
+	core.BuiltInFunctionESDTBurn:                  {},
+	core.BuiltInFunctionESDTFreeze:                {},
+	core.BuiltInFunctionESDTUnFreeze:              {},
+	core.BuiltInFunctionESDTWipe:                  {},
+	core.BuiltInFunctionESDTPause:                 {},
+	core.BuiltInFunctionESDTUnPause:               {},
+	core.BuiltInFunctionSetESDTRole:               {},
+	core.BuiltInFunctionUnSetESDTRole:             {},
+	core.BuiltInFunctionESDTSetLimitedTransfer:    {},
+	core.BuiltInFunctionESDTUnSetLimitedTransfer:  {},
+	core.BuiltInFunctionESDTLocalMint:             {},
+	core.BuiltInFunctionESDTLocalBurn:             {},
+	core.BuiltInFunctionESDTNFTTransfer:           {},
+	core.BuiltInFunctionESDTNFTCreate:             {},
+	core.BuiltInFunctionESDTNFTAddQuantity:        {},
+	core.BuiltInFunctionESDTNFTCreateRoleTransfer: {},
+	core.BuiltInFunctionESDTNFTBurn:               {},
+	core.BuiltInFunctionESDTNFTAddURI:             {},
+	core.BuiltInFunctionESDTNFTUpdateAttributes:   {},
+	core.BuiltInFunctionMultiESDTNFTTransfer:      {},
+}
+
+// callData holds the function and arguments parsed from the data field of a transaction or smart contract
+// result, as well as the operation it performs
+type callData struct {
+	function  string
+	arguments [][]byte
+	operation string
+}
+
+type callDataParser struct {
+	callArgsParser   vmcommon.CallArgsParser
+	deployArgsParser deployArgsParser
+}
+
+type deployArgsParser interface {
+	ParseData(data string) (*parsers.DeployArgs, error)
+}
+
+func newCallDataParser() *callDataParser {
+	return &callDataParser{
+		callArgsParser:   parsers.NewCallArgsParser(),
+		deployArgsParser: parsers.NewDeployArgsParser(),
+	}
+}
+
+// parse parses data of the form function@hexArg1@hexArg2... into a function and its arguments and classifies
+// the operation. Deploys, having the form code@vmType@codeMetadata@hexArg1..., have no function, their code, vm
+// type and code metadata being the first arguments. Value transfers to user accounts, whose data is a note, also
+// have no function nor arguments
+func (cdp *callDataParser) parse(receiver []byte, data []byte) *callData {
+	if len(receiver) != 0 && core.IsEmptyAddress(receiver) {
+		return cdp.parseDeploy(data)
+	}
+
+	function, arguments, err := cdp.callArgsParser.ParseData(string(data))
+	if err != nil || len(function) == 0 {
+		return newTransferCallData()
+	}
+
+	operation := getOperation(function, receiver)
+	if operation == OperationTransfer {
+		return newTransferCallData()
+	}
+
+	return &callData{
+		function:  function,
+		arguments: arguments,
+		operation: operation,
+	}
+}
+
+func getOperation(function string, receiver []byte) string {
+	if function == relayedTxV1Function || function == relayedTxV2Function {
+		return OperationRelayed
+	}
+
+	tokenTransferOperation, isTokenTransfer := tokenTransferOperations[function]
+	if isTokenTransfer {
+		return tokenTransferOperation
+	}
+
+	_, isBuiltInFunction := builtInFunctions[function]
+	if isBuiltInFunction {
+		return OperationBuiltInCall
+	}
+
+	if core.IsSmartContractAddress(receiver) {
+		return OperationSCCall
+	}
+
+	return OperationTransfer
+}
+
+func (cdp *callDataParser) parseDeploy(data []byte) *callData {
+	arguments := make([][]byte, 0)
+
+	deployArgs, err := cdp.deployArgsParser.ParseData(string(data))
+	if err == nil {
+		arguments = append(arguments, deployArgs.Code, deployArgs.VMType, deployArgs.CodeMetadata.ToBytes())
+		arguments = append(arguments, deployArgs.Arguments...)
+	}
+
+	return &callData{
+		function:  "",
+		arguments: arguments,
+		operation: OperationSCDeploy,
+	}
+}
+
+func newTransferCallData() *callData {
+	return &callData{
+		function:  "",
+		arguments: make([][]byte, 0),
+		operation: OperationTransfer,
+	}
+}
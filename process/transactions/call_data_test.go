@@ -0,0 +1,156 @@
+package transactions_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ElrondNetwork/covalent-indexer-go/process/transactions"
+	"github.com/ElrondNetwork/covalent-indexer-go/testscommon/mock"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/block"
+	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
+	"github.com/ElrondNetwork/elrond-go-core/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionProcessor_ProcessTransactions_CallData(t *testing.T) {
+	t.Parallel()
+
+	userAddress := []byte("user address with thirty two len")
+	scAddress := append(make([]byte, 8), []byte("\x05\x00contract address of 22")...)
+	deployAddress := make([]byte, 32)
+
+	tests := []struct {
+		name              string
+		receiver          []byte
+		data              string
+		expectedFunction  string
+		expectedArguments [][]byte
+		expectedOperation string
+	}{
+		{
+			name:              "move balance, no data",
+			receiver:          userAddress,
+			data:              "",
+			expectedFunction:  "",
+			expectedArguments: [][]byte{},
+			expectedOperation: transactions.OperationTransfer,
+		},
+		{
+			name:              "move balance with note",
+			receiver:          userAddress,
+			data:              "thanks@for the coffee",
+			expectedFunction:  "",
+			expectedArguments: [][]byte{},
+			expectedOperation: transactions.OperationTransfer,
+		},
+		{
+			name:              "smart contract call",
+			receiver:          scAddress,
+			data:              "stake@0a@0b",
+			expectedFunction:  "stake",
+			expectedArguments: [][]byte{{0xa}, {0xb}},
+			expectedOperation: transactions.OperationSCCall,
+		},
+		{
+			name:              "smart contract deploy",
+			receiver:          deployAddress,
+			data:              "0061736d@0500@0100@0c",
+			expectedFunction:  "",
+			expectedArguments: [][]byte{{0x00, 0x61, 0x73, 0x6d}, {0x05, 0x00}, {0x01, 0x00}, {0xc}},
+			expectedOperation: transactions.OperationSCDeploy,
+		},
+		{
+			name:              "esdt transfer to smart contract",
+			receiver:          scAddress,
+			data:              "ESDTTransfer@" + hex.EncodeToString([]byte("TKN-abcdef")) + "@64@" + hex.EncodeToString([]byte("swap")),
+			expectedFunction:  "ESDTTransfer",
+			expectedArguments: [][]byte{[]byte("TKN-abcdef"), {0x64}, []byte("swap")},
+			expectedOperation: transactions.OperationESDTTransfer,
+		},
+		{
+			name:              "nft transfer",
+			receiver:          userAddress,
+			data:              "ESDTNFTTransfer@" + hex.EncodeToString([]byte("NFT-abcdef")) + "@01@01@" + hex.EncodeToString(scAddress),
+			expectedFunction:  "ESDTNFTTransfer",
+			expectedArguments: [][]byte{[]byte("NFT-abcdef"), {0x1}, {0x1}, scAddress},
+			expectedOperation: transactions.OperationNFTTransfer,
+		},
+		{
+			name:              "multi transfer",
+			receiver:          userAddress,
+			data:              "MultiESDTNFTTransfer@" + hex.EncodeToString(scAddress) + "@02",
+			expectedFunction:  "MultiESDTNFTTransfer",
+			expectedArguments: [][]byte{scAddress, {0x2}},
+			expectedOperation: transactions.OperationMultiTransfer,
+		},
+		{
+			name:              "built-in function call",
+			receiver:          userAddress,
+			data:              "SaveKeyValue@0a@0b",
+			expectedFunction:  "SaveKeyValue",
+			expectedArguments: [][]byte{{0xa}, {0xb}},
+			expectedOperation: transactions.OperationBuiltInCall,
+		},
+		{
+			name:              "relayed transaction",
+			receiver:          userAddress,
+			data:              "relayedTxV2@0a@0b@0c@0d",
+			expectedFunction:  "relayedTxV2",
+			expectedArguments: [][]byte{{0xa}, {0xb}, {0xc}, {0xd}},
+			expectedOperation: transactions.OperationRelayed,
+		},
+	}
+
+	for _, currTest := range tests {
+		txHash := []byte("tx hash")
+		tx := &transaction.Transaction{
+			SndAddr: userAddress,
+			RcvAddr: currTest.receiver,
+			Data:    []byte(currTest.data),
+		}
+		body := &block.Body{MiniBlocks: []*block.MiniBlock{
+			{TxHashes: [][]byte{txHash}, Type: block.TxBlock},
+		}}
+		pool := &indexer.Pool{
+			Txs: map[string]data.TransactionHandler{string(txHash): tx},
+		}
+
+		txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}, &mock.FeeCalculatorStub{})
+		ret, err := txp.ProcessTransactions(&block.Header{}, []byte("header hash"), body, pool)
+		require.Nil(t, err, currTest.name)
+		require.Len(t, ret, 1, currTest.name)
+		require.Equal(t, currTest.expectedFunction, ret[0].Function, currTest.name)
+		require.Equal(t, currTest.expectedArguments, ret[0].Arguments, currTest.name)
+		require.Equal(t, currTest.expectedOperation, ret[0].Operation, currTest.name)
+	}
+}
+
+func TestSCResultsProcessor_ProcessSCRs_CallData(t *testing.T) {
+	t.Parallel()
+
+	scrs := map[string]data.TransactionHandler{
+		"scr hash 1": &smartContractResult.SmartContractResult{
+			RcvAddr: []byte("user"),
+			Data:    []byte("ESDTTransfer@" + hex.EncodeToString([]byte("TKN-abcdef")) + "@64"),
+		},
+		"scr hash 2": &smartContractResult.SmartContractResult{
+			RcvAddr: []byte("user"),
+			Data:    []byte("@6f6b"),
+		},
+	}
+
+	scp, _ := transactions.NewSCResultsProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+	ret, err := scp.ProcessSCRs(&block.Header{}, []byte("header hash"), &block.Body{}, &indexer.Pool{Scrs: scrs})
+	require.Nil(t, err)
+	require.Len(t, ret, 2)
+
+	require.Equal(t, "ESDTTransfer", ret[0].Function)
+	require.Equal(t, [][]byte{[]byte("TKN-abcdef"), {0x64}}, ret[0].Arguments)
+	require.Equal(t, transactions.OperationESDTTransfer, ret[0].Operation)
+
+	require.Equal(t, "", ret[1].Function)
+	require.Equal(t, [][]byte{}, ret[1].Arguments)
+	require.Equal(t, transactions.OperationTransfer, ret[1].Operation)
+}
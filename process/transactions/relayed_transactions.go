@@ -0,0 +1,97 @@
+package transactions
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/covalent-indexer-go/process/utility"
+	"github.com/ElrondNetwork/covalent-indexer-go/schema"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+)
+
+const (
+	relayedTxV1Function = "relayedTx"
+	relayedTxV2Function = "relayedTxV2"
+
+	relayedTxV1Version = 1
+	relayedTxV2Version = 2
+
+	relayedTxV1NumArgs = 1
+	relayedTxV2NumArgs = 4
+)
+
+// processInnerTransaction decodes the user transaction wrapped by a relayed transaction, having the call data
+// either relayedTx@innerTx, where the inner transaction is json marshalled, or
+// relayedTxV2@receiver@nonce@data@signature, where the inner transaction sender is the relayed transaction receiver
+func (txp *transactionProcessor) processInnerTransaction(
+	tx *transaction.Transaction,
+	txHash []byte,
+	parsedData *callData,
+	executionInfo *txsExecutionInfo,
+) *schema.InnerTransaction {
+	if parsedData.operation != OperationRelayed {
+		return nil
+	}
+
+	var innerTx *schema.InnerTransaction
+	args := parsedData.arguments
+	switch {
+	case parsedData.function == relayedTxV1Function && len(args) == relayedTxV1NumArgs:
+		innerTx = txp.processRelayedTxV1(args)
+	case parsedData.function == relayedTxV2Function && len(args) == relayedTxV2NumArgs:
+		innerTx = txp.processRelayedTxV2(tx, args)
+	}
+
+	if innerTx == nil {
+		log.Warn("transactionProcessor.processInnerTransaction could not decode relayed transaction", "hash", txHash)
+		return nil
+	}
+
+	innerTx.SCResultHashes = executionInfo.getRelayedSCRHashes(txHash)
+	return innerTx
+}
+
+func (txp *transactionProcessor) processRelayedTxV1(args [][]byte) *schema.InnerTransaction {
+	userTx := &transaction.Transaction{}
+	err := txp.relayedTxMarshaller.Unmarshal(userTx, args[0])
+	if err != nil {
+		return nil
+	}
+
+	return &schema.InnerTransaction{
+		Version:   relayedTxV1Version,
+		Nonce:     int64(userTx.GetNonce()),
+		Sender:    utility.EncodePubKey(txp.pubKeyConverter, userTx.GetSndAddr()),
+		Receiver:  utility.EncodePubKey(txp.pubKeyConverter, userTx.GetRcvAddr()),
+		Value:     utility.GetBytes(userTx.GetValue()),
+		GasPrice:  int64(userTx.GetGasPrice()),
+		GasLimit:  int64(userTx.GetGasLimit()),
+		Data:      userTx.GetData(),
+		Signature: userTx.GetSignature(),
+	}
+}
+
+// processRelayedTxV2 decodes a relayed transaction v2, whose inner transaction has no value and is provided
+// with the gas limit left after the relayer pays for the move balance gas of the relayed transaction. The gas
+// limit is left null when no fee calculator is enabled, since the move balance gas can not be computed
+func (txp *transactionProcessor) processRelayedTxV2(tx *transaction.Transaction, args [][]byte) *schema.InnerTransaction {
+	var gasLimit interface{}
+	if txp.feesEnabled {
+		gasLimit = int64(0)
+		moveBalanceGas := txp.feeCalculator.ComputeGasLimit(tx)
+		if tx.GetGasLimit() > moveBalanceGas {
+			gasLimit = int64(tx.GetGasLimit() - moveBalanceGas)
+		}
+	}
+
+	return &schema.InnerTransaction{
+		Version:   relayedTxV2Version,
+		Nonce:     int64(big.NewInt(0).SetBytes(args[1]).Uint64()),
+		Sender:    utility.EncodePubKey(txp.pubKeyConverter, tx.GetRcvAddr()),
+		Receiver:  utility.EncodePubKey(txp.pubKeyConverter, args[0]),
+		Value:     utility.GetBytes(big.NewInt(0)),
+		GasPrice:  int64(tx.GetGasPrice()),
+		GasLimit:  gasLimit,
+		Data:      args[2],
+		Signature: args[3],
+	}
+}
@@ -0,0 +1,164 @@
+package transactions_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/covalent-indexer-go/process/economics"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/transactions"
+	"github.com/ElrondNetwork/covalent-indexer-go/schema"
+	"github.com/ElrondNetwork/covalent-indexer-go/testscommon/mock"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/block"
+	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
+	"github.com/ElrondNetwork/elrond-go-core/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionProcessor_ProcessTransactions_RelayedTransactions(t *testing.T) {
+	t.Parallel()
+
+	txHash := []byte("tx hash")
+	innerTx := &transaction.Transaction{
+		Nonce:     4,
+		Value:     big.NewInt(100),
+		SndAddr:   []byte("user"),
+		RcvAddr:   []byte("contract"),
+		GasPrice:  10,
+		GasLimit:  500,
+		Data:      []byte("claim"),
+		Signature: []byte("user signature"),
+	}
+	innerTxBytes, err := json.Marshal(innerTx)
+	require.Nil(t, err)
+
+	relayedTxV2Data := "relayedTxV2@" + hex.EncodeToString([]byte("contract")) + "@04@" +
+		hex.EncodeToString([]byte("claim")) + "@" + hex.EncodeToString([]byte("user signature"))
+
+	scrs := map[string]data.TransactionHandler{
+		"scr hash 2": &smartContractResult.SmartContractResult{
+			OriginalTxHash: txHash,
+			RelayerAddr:    []byte("relayer"),
+			RelayedValue:   big.NewInt(100),
+		},
+		"scr hash 1": &smartContractResult.SmartContractResult{
+			OriginalTxHash: txHash,
+			RelayerAddr:    []byte("relayer"),
+			RelayedValue:   big.NewInt(100),
+		},
+		"scr hash 3": &smartContractResult.SmartContractResult{
+			OriginalTxHash: txHash,
+		},
+	}
+
+	tests := []struct {
+		name            string
+		data            []byte
+		expectedInnerTx *schema.InnerTransaction
+	}{
+		{
+			name:            "not relayed",
+			data:            []byte("claim"),
+			expectedInnerTx: nil,
+		},
+		{
+			name: "relayed v1",
+			data: []byte("relayedTx@" + hex.EncodeToString(innerTxBytes)),
+			expectedInnerTx: &schema.InnerTransaction{
+				Version:        1,
+				Nonce:          4,
+				Sender:         []byte("erd1user"),
+				Receiver:       []byte("erd1contract"),
+				Value:          big.NewInt(100).Bytes(),
+				GasPrice:       10,
+				GasLimit:       int64(500),
+				Data:           []byte("claim"),
+				Signature:      []byte("user signature"),
+				SCResultHashes: [][]byte{[]byte("scr hash 1"), []byte("scr hash 2")},
+			},
+		},
+		{
+			name:            "relayed v1, invalid inner transaction",
+			data:            []byte("relayedTx@" + hex.EncodeToString([]byte("not json"))),
+			expectedInnerTx: nil,
+		},
+		{
+			name: "relayed v2",
+			data: []byte(relayedTxV2Data),
+			expectedInnerTx: &schema.InnerTransaction{
+				Version:        2,
+				Nonce:          4,
+				Sender:         []byte("erd1user"),
+				Receiver:       []byte("erd1contract"),
+				Value:          []byte{},
+				GasPrice:       10,
+				GasLimit:       int64(400),
+				Data:           []byte("claim"),
+				Signature:      []byte("user signature"),
+				SCResultHashes: [][]byte{[]byte("scr hash 1"), []byte("scr hash 2")},
+			},
+		},
+		{
+			name:            "relayed v2, missing arguments",
+			data:            []byte("relayedTxV2@" + hex.EncodeToString([]byte("contract"))),
+			expectedInnerTx: nil,
+		},
+	}
+
+	for _, currTest := range tests {
+		tx := &transaction.Transaction{
+			SndAddr:  []byte("relayer"),
+			RcvAddr:  []byte("user"),
+			GasPrice: 10,
+			GasLimit: 500,
+			Data:     currTest.data,
+		}
+		body := &block.Body{MiniBlocks: []*block.MiniBlock{
+			{TxHashes: [][]byte{txHash}, Type: block.TxBlock},
+		}}
+		pool := &indexer.Pool{
+			Txs:  map[string]data.TransactionHandler{string(txHash): tx},
+			Scrs: scrs,
+		}
+		feeCalculator := &mock.FeeCalculatorStub{
+			ComputeGasLimitCalled: func(tx data.TransactionWithFeeHandler) uint64 {
+				return 100
+			},
+		}
+
+		txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}, feeCalculator)
+		ret, err := txp.ProcessTransactions(&block.Header{}, []byte("header hash"), body, pool)
+		require.Nil(t, err, currTest.name)
+		require.Len(t, ret, 1, currTest.name)
+		require.Equal(t, currTest.expectedInnerTx, ret[0].InnerTransaction, currTest.name)
+	}
+}
+
+func TestTransactionProcessor_ProcessTransactions_RelayedTxV2DisabledFeeCalculator_ExpectNullGasLimit(t *testing.T) {
+	t.Parallel()
+
+	txHash := []byte("tx hash")
+	tx := &transaction.Transaction{
+		SndAddr:  []byte("relayer"),
+		RcvAddr:  []byte("user"),
+		GasPrice: 10,
+		GasLimit: 500,
+		Data: []byte("relayedTxV2@" + hex.EncodeToString([]byte("contract")) + "@04@" +
+			hex.EncodeToString([]byte("claim")) + "@" + hex.EncodeToString([]byte("user signature"))),
+	}
+	body := &block.Body{MiniBlocks: []*block.MiniBlock{
+		{TxHashes: [][]byte{txHash}, Type: block.TxBlock},
+	}}
+	pool := &indexer.Pool{Txs: map[string]data.TransactionHandler{string(txHash): tx}}
+
+	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}, economics.NewDisabledFeeCalculator())
+	ret, err := txp.ProcessTransactions(&block.Header{}, []byte("header hash"), body, pool)
+	require.Nil(t, err)
+	require.Len(t, ret, 1)
+	require.NotNil(t, ret[0].InnerTransaction)
+	require.Equal(t, int32(2), ret[0].InnerTransaction.Version)
+	require.Nil(t, ret[0].InnerTransaction.GasLimit)
+}
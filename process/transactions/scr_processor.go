@@ -7,37 +7,140 @@ import (
 	"github.com/ElrondNetwork/elrond-go-core/core"
 	"github.com/ElrondNetwork/elrond-go-core/core/check"
 	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/block"
+	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
 	"github.com/ElrondNetwork/elrond-go-core/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/ElrondNetwork/elrond-go-core/hashing"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
 )
 
+// NotInMiniBlockIndex is the miniblock index and the index in miniblock of the smart contract results
+// which are not included in any miniblock of the block body
+const NotInMiniBlockIndex = int32(-1)
+
 type scProcessor struct {
 	pubKeyConverter core.PubkeyConverter
+	hasher          hashing.Hasher
+	marshaller      marshal.Marshalizer
+	callDataParser  *callDataParser
 }
 
 // NewSCResultsProcessor creates a new instance of smart contracts processor
-func NewSCResultsProcessor(pubKeyConverter core.PubkeyConverter) (*scProcessor, error) {
+func NewSCResultsProcessor(
+	pubKeyConverter core.PubkeyConverter,
+	hasher hashing.Hasher,
+	marshaller marshal.Marshalizer,
+) (*scProcessor, error) {
 	if check.IfNil(pubKeyConverter) {
 		return nil, covalent.ErrNilPubKeyConverter
 	}
+	if check.IfNil(hasher) {
+		return nil, covalent.ErrNilHasher
+	}
+	if check.IfNil(marshaller) {
+		return nil, covalent.ErrNilMarshaller
+	}
+
+	return &scProcessor{
+		pubKeyConverter: pubKeyConverter,
+		hasher:          hasher,
+		marshaller:      marshaller,
+		callDataParser:  newCallDataParser(),
+	}, nil
+}
+
+// ProcessSCRs converts smart contracts data to a specific structure defined by avro schema. Smart contract results
+// are taken from the smart contract results miniblocks of the body. The ones which are not included in any miniblock
+// (e.g. intra shard results which were not notarized) are processed last, sorted by hash, without a miniblock hash.
+// The transactions of the pool are used to detect the gas refunds of the transactions from the same block
+func (scp *scProcessor) ProcessSCRs(
+	header data.HeaderHandler,
+	headerHash []byte,
+	bodyHandler data.BodyHandler,
+	pool *indexer.Pool,
+) ([]*schema.SCResult, error) {
+	body, ok := bodyHandler.(*block.Body)
+	if !ok {
+		return nil, covalent.ErrBlockBodyAssertion
+	}
+
+	scrs := pool.Scrs
+	allSCRs := make([]*schema.SCResult, 0, len(scrs))
+	processedSCRs := make(map[string]struct{}, len(scrs))
+	for mbIndex, currMiniBlock := range body.MiniBlocks {
+		if currMiniBlock.Type != block.SmartContractResultBlock {
+			continue
+		}
+
+		scrsInCurrMB, err := scp.processSCRsFromMiniBlock(pool, currMiniBlock, mbIndex, header, headerHash, processedSCRs)
+		if err != nil {
+			log.Warn("scProcessor.processSCRsFromMiniBlock", "error", err)
+			continue
+		}
+		allSCRs = append(allSCRs, scrsInCurrMB...)
+	}
+
+	selfShardMiniBlock := &block.MiniBlock{SenderShardID: header.GetShardID(), ReceiverShardID: header.GetShardID()}
+	for _, currSCRHash := range utility.SortedHashes(scrs) {
+		_, processed := processedSCRs[currSCRHash]
+		if processed {
+			continue
+		}
+
+		processedSCR := scp.processSCResult(scrs[currSCRHash], []byte(currSCRHash), nil, headerHash, selfShardMiniBlock, header, pool.Txs)
+		if processedSCR != nil {
+			processedSCR.MiniBlockIndex = NotInMiniBlockIndex
+			processedSCR.IndexInMiniBlock = NotInMiniBlockIndex
+			allSCRs = append(allSCRs, processedSCR)
+		}
+	}
 
-	return &scProcessor{pubKeyConverter: pubKeyConverter}, nil
+	return allSCRs, nil
 }
 
-// ProcessSCRs converts smart contracts data to a specific structure defined by avro schema
-func (scp *scProcessor) ProcessSCRs(transactions map[string]data.TransactionHandler, timeStamp uint64) []*schema.SCResult {
-	allSCRs := make([]*schema.SCResult, 0, len(transactions))
+func (scp *scProcessor) processSCRsFromMiniBlock(
+	pool *indexer.Pool,
+	miniBlock *block.MiniBlock,
+	miniBlockIndex int,
+	header data.HeaderHandler,
+	blockHash []byte,
+	processedSCRs map[string]struct{},
+) ([]*schema.SCResult, error) {
+	miniBlockHash, err := core.CalculateHash(scp.marshaller, scp.hasher, miniBlock)
+	if err != nil {
+		return nil, err
+	}
 
-	for currTxHash, currTx := range transactions {
-		currSCR := scp.processSCResult(currTx, currTxHash, timeStamp)
+	scrsInMiniBlock := make([]*schema.SCResult, 0, len(miniBlock.TxHashes))
+	for scrIndex, scrHash := range miniBlock.TxHashes {
+		scr, isInPool := pool.Scrs[string(scrHash)]
+		if !isInPool {
+			log.Warn("scProcessor.processSCRsFromMiniBlock scr hash not found in pool", "hash", scrHash)
+			continue
+		}
 
-		if currSCR != nil {
-			allSCRs = append(allSCRs, currSCR)
+		processedSCRs[string(scrHash)] = struct{}{}
+		processedSCR := scp.processSCResult(scr, scrHash, miniBlockHash, blockHash, miniBlock, header, pool.Txs)
+		if processedSCR != nil {
+			processedSCR.MiniBlockIndex = int32(miniBlockIndex)
+			processedSCR.IndexInMiniBlock = int32(scrIndex)
+			scrsInMiniBlock = append(scrsInMiniBlock, processedSCR)
 		}
 	}
-	return allSCRs
+
+	return scrsInMiniBlock, nil
 }
 
-func (scp *scProcessor) processSCResult(tx data.TransactionHandler, txHash string, timeStamp uint64) *schema.SCResult {
+func (scp *scProcessor) processSCResult(
+	tx data.TransactionHandler,
+	scrHash []byte,
+	miniBlockHash []byte,
+	blockHash []byte,
+	miniBlock *block.MiniBlock,
+	header data.HeaderHandler,
+	txs map[string]data.TransactionHandler,
+) *schema.SCResult {
 	scrTx, castOk := tx.(*smartContractResult.SmartContractResult)
 	if !castOk {
 		return nil
@@ -47,24 +150,42 @@ func (scp *scProcessor) processSCResult(tx data.TransactionHandler, txHash strin
 	if len(scrTx.GetRelayerAddr()) > 0 {
 		relayerAddress = utility.EncodePubKey(scp.pubKeyConverter, scrTx.GetRelayerAddr())
 	}
+	parsedData := scp.callDataParser.parse(scrTx.GetRcvAddr(), scrTx.GetData())
+	returnCode, returnData := parseReturnData(scrTx.GetData())
+	prevTx, _ := txs[string(scrTx.GetPrevTxHash())].(*transaction.Transaction)
 
 	return &schema.SCResult{
-		Hash:           []byte(txHash),
+		Hash:           scrHash,
+		MiniBlockHash:  miniBlockHash,
+		BlockHash:      blockHash,
 		Nonce:          int64(scrTx.GetNonce()),
 		GasLimit:       int64(scrTx.GetGasLimit()),
 		GasPrice:       int64(scrTx.GetGasPrice()),
 		Value:          utility.GetBytes(scrTx.GetValue()),
 		Sender:         utility.EncodePubKey(scp.pubKeyConverter, scrTx.GetSndAddr()),
 		Receiver:       utility.EncodePubKey(scp.pubKeyConverter, scrTx.GetRcvAddr()),
+		ReceiverShard:  int32(miniBlock.ReceiverShardID),
+		SenderShard:    int32(miniBlock.SenderShardID),
 		RelayerAddr:    relayerAddress,
 		RelayedValue:   utility.GetBytes(scrTx.GetRelayedValue()),
 		Code:           scrTx.GetCode(),
 		Data:           scrTx.GetData(),
+		Function:       parsedData.function,
+		Arguments:      parsedData.arguments,
+		Operation:      parsedData.operation,
 		PrevTxHash:     scrTx.GetPrevTxHash(),
 		OriginalTxHash: scrTx.GetOriginalTxHash(),
 		CallType:       int32(scrTx.GetCallType()),
 		CodeMetadata:   scrTx.GetCodeMetadata(),
 		ReturnMessage:  scrTx.GetReturnMessage(),
-		Timestamp:      int64(timeStamp),
+		ReturnCode:     returnCode,
+		ReturnData:     returnData,
+		IsRefund:       isRefund(scrTx, returnCode, returnData, prevTx),
+		Timestamp:      int64(header.GetTimeStamp()),
 	}
 }
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (scp *scProcessor) IsInterfaceNil() bool {
+	return scp == nil
+}
@@ -12,8 +12,12 @@ import (
 	"github.com/ElrondNetwork/covalent-indexer-go/testscommon/mock"
 	"github.com/ElrondNetwork/elrond-go-core/core"
 	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/block"
+	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
 	"github.com/ElrondNetwork/elrond-go-core/data/smartContractResult"
 	"github.com/ElrondNetwork/elrond-go-core/data/vm"
+	"github.com/ElrondNetwork/elrond-go-core/hashing"
+	"github.com/ElrondNetwork/elrond-go-core/marshal"
 	"github.com/stretchr/testify/require"
 )
 
@@ -21,18 +25,30 @@ func TestNewSCProcessor(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		args        func() core.PubkeyConverter
+		args        func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer)
 		expectedErr error
 	}{
 		{
-			args: func() core.PubkeyConverter {
-				return nil
+			args: func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer) {
+				return nil, &mock.HasherMock{}, &mock.MarshallerStub{}
 			},
 			expectedErr: covalent.ErrNilPubKeyConverter,
 		},
 		{
-			args: func() core.PubkeyConverter {
-				return &mock.PubKeyConverterStub{}
+			args: func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer) {
+				return &mock.PubKeyConverterStub{}, nil, &mock.MarshallerStub{}
+			},
+			expectedErr: covalent.ErrNilHasher,
+		},
+		{
+			args: func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer) {
+				return &mock.PubKeyConverterStub{}, &mock.HasherMock{}, nil
+			},
+			expectedErr: covalent.ErrNilMarshaller,
+		},
+		{
+			args: func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer) {
+				return &mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}
 			},
 			expectedErr: nil,
 		},
@@ -65,11 +81,20 @@ func generateRandomSCR() *smartContractResult.SmartContractResult {
 	}
 }
 
+func TestScProcessor_ProcessSCRs_InvalidBody_ExpectError(t *testing.T) {
+	t.Parallel()
+
+	scp, _ := transactions.NewSCResultsProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+
+	ret, err := scp.ProcessSCRs(&block.Header{}, []byte("header hash"), nil, &indexer.Pool{})
+	require.Nil(t, ret)
+	require.Equal(t, covalent.ErrBlockBodyAssertion, err)
+}
+
 func TestScProcessor_ProcessSCs_TwoSCRs_OneNormalTx_ExpectTwoProcessedSCRs(t *testing.T) {
-	// TODO refactor this test: the processing is done by iterating on a map and the result is a slice that might,
-	// sometimes, have the transactions in another order
+	t.Parallel()
 
-	scp, _ := transactions.NewSCResultsProcessor(&mock.PubKeyConverterStub{})
+	scp, _ := transactions.NewSCResultsProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
 
 	tx1 := generateRandomSCR()
 	tx2 := generateRandomSCR()
@@ -80,14 +105,62 @@ func TestScProcessor_ProcessSCs_TwoSCRs_OneNormalTx_ExpectTwoProcessedSCRs(t *te
 		"hash2": tx2,
 		"hash3": tx3,
 	}
+	body := &block.Body{
+		MiniBlocks: []*block.MiniBlock{
+			{TxHashes: [][]byte{[]byte("hash3")}, Type: block.TxBlock},
+			{TxHashes: [][]byte{[]byte("hash1"), []byte("hash2"), []byte("hash3")}, Type: block.SmartContractResultBlock},
+		},
+	}
+	header := &block.Header{TimeStamp: 123}
 
-	ret := scp.ProcessSCRs(txPool, 123)
+	ret, err := scp.ProcessSCRs(header, []byte("header hash"), body, &indexer.Pool{Scrs: txPool})
+	require.Nil(t, err)
 
 	require.Len(t, ret, 2)
 	requireProcessedSCREqual(t, ret[0], tx1, "hash1", 123, &mock.PubKeyConverterStub{})
 	requireProcessedSCREqual(t, ret[1], tx2, "hash2", 123, &mock.PubKeyConverterStub{})
 }
 
+func TestScProcessor_ProcessSCRs_ExpectBlockAndMiniBlockLinkage(t *testing.T) {
+	t.Parallel()
+
+	scp, _ := transactions.NewSCResultsProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+
+	txPool := map[string]data.TransactionHandler{
+		"cross shard scr": generateRandomSCR(),
+		"not notarized":   generateRandomSCR(),
+	}
+	miniBlock := &block.MiniBlock{
+		TxHashes:        [][]byte{[]byte("cross shard scr"), []byte("not in pool")},
+		SenderShardID:   1,
+		ReceiverShardID: 2,
+		Type:            block.SmartContractResultBlock,
+	}
+	body := &block.Body{MiniBlocks: []*block.MiniBlock{miniBlock}}
+	header := &block.Header{ShardID: 2}
+
+	ret, err := scp.ProcessSCRs(header, []byte("header hash"), body, &indexer.Pool{Scrs: txPool})
+	require.Nil(t, err)
+	require.Len(t, ret, 2)
+
+	miniBlockHash, _ := core.CalculateHash(&mock.MarshallerStub{}, &mock.HasherMock{}, miniBlock)
+	require.Equal(t, []byte("cross shard scr"), ret[0].Hash)
+	require.Equal(t, miniBlockHash, ret[0].MiniBlockHash)
+	require.Equal(t, []byte("header hash"), ret[0].BlockHash)
+	require.Equal(t, int32(1), ret[0].SenderShard)
+	require.Equal(t, int32(2), ret[0].ReceiverShard)
+	require.Equal(t, int32(0), ret[0].MiniBlockIndex)
+	require.Equal(t, int32(0), ret[0].IndexInMiniBlock)
+
+	require.Equal(t, []byte("not notarized"), ret[1].Hash)
+	require.Nil(t, ret[1].MiniBlockHash)
+	require.Equal(t, []byte("header hash"), ret[1].BlockHash)
+	require.Equal(t, int32(2), ret[1].SenderShard)
+	require.Equal(t, int32(2), ret[1].ReceiverShard)
+	require.Equal(t, transactions.NotInMiniBlockIndex, ret[1].MiniBlockIndex)
+	require.Equal(t, transactions.NotInMiniBlockIndex, ret[1].IndexInMiniBlock)
+}
+
 func requireProcessedSCREqual(
 	t *testing.T,
 	processedSCR *schema.SCResult,
@@ -0,0 +1,86 @@
+package transactions
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/ElrondNetwork/elrond-go-core/data/vm"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+)
+
+const gasRefundForRelayerMessage = "gas refund for relayer"
+
+// knownReturnCodes holds the textual form of the return codes of the VM
+var knownReturnCodes = createKnownReturnCodes()
+
+func createKnownReturnCodes() map[string]struct{} {
+	returnCodes := make(map[string]struct{})
+	for returnCode := vmcommon.Ok; returnCode <= vmcommon.SimulateFailed; returnCode++ {
+		returnCodes[returnCode.String()] = struct{}{}
+	}
+
+	return returnCodes
+}
+
+// parseReturnData parses smart contract results data of the form @returnCode[@returnData...] into the textual form
+// of the return code and the hex decoded return data. Data which is not of this form has no return code
+func parseReturnData(scrData []byte) (string, [][]byte) {
+	returnCode, isReturnData := getReturnCode(scrData)
+	if !isReturnData {
+		return "", make([][]byte, 0)
+	}
+
+	tokens := strings.Split(string(scrData), returnDataSeparator)
+	returnData := make([][]byte, 0, len(tokens)-2)
+	for _, token := range tokens[2:] {
+		decodedToken, err := hex.DecodeString(token)
+		if err != nil {
+			decodedToken = []byte(token)
+		}
+		returnData = append(returnData, decodedToken)
+	}
+
+	return returnCode, returnData
+}
+
+// normalizeReturnCode converts the return codes sent as numbers (e.g. @04) to their textual form (e.g. user error),
+// the same as the ones sent as hex encoded text (e.g. @6f6b)
+func normalizeReturnCode(returnCode string) string {
+	_, isKnown := knownReturnCodes[returnCode]
+	if isKnown {
+		return returnCode
+	}
+
+	isNumeric := len(returnCode) == 1 && returnCode[0] <= byte(vmcommon.SimulateFailed)
+	if isNumeric {
+		return vmcommon.ReturnCode(returnCode[0]).String()
+	}
+
+	return returnCode
+}
+
+// isRefund checks whether a smart contract result is a gas refund. If the transaction which generated it is in the
+// block, the refund has to be sent to its sender with the next nonce. Otherwise, as it happens for cross shard
+// refunds, it has to be a direct ok result for a user account, without any other return data
+func isRefund(
+	scr *smartContractResult.SmartContractResult,
+	returnCode string,
+	returnData [][]byte,
+	prevTx *transaction.Transaction,
+) bool {
+	if scr.GetValue() == nil || scr.GetValue().Sign() <= 0 || returnCode != vmcommon.Ok.String() {
+		return false
+	}
+	if string(scr.GetReturnMessage()) == gasRefundForRelayerMessage {
+		return true
+	}
+	if prevTx != nil {
+		return isRefundSCR(scr, prevTx)
+	}
+
+	isDirectCall := scr.GetCallType() == vm.DirectCall
+	return isDirectCall && len(returnData) == 0 && !core.IsSmartContractAddress(scr.GetRcvAddr())
+}
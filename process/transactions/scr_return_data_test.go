@@ -0,0 +1,164 @@
+package transactions_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/covalent-indexer-go/process/transactions"
+	"github.com/ElrondNetwork/covalent-indexer-go/testscommon/mock"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/block"
+	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
+	"github.com/ElrondNetwork/elrond-go-core/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	"github.com/ElrondNetwork/elrond-go-core/data/vm"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSCResultsProcessor_ProcessSCRs_ReturnData(t *testing.T) {
+	t.Parallel()
+
+	okData := "@" + hex.EncodeToString([]byte("ok"))
+	sender := []byte("user address with thirty two len")
+	scAddress := append(make([]byte, 8), []byte("\x05\x00contract address of 22")...)
+	txs := map[string]data.TransactionHandler{
+		"tx hash": &transaction.Transaction{Nonce: 4, SndAddr: sender},
+	}
+
+	tests := []struct {
+		name               string
+		scr                *smartContractResult.SmartContractResult
+		expectedReturnCode string
+		expectedReturnData [][]byte
+		expectedIsRefund   bool
+	}{
+		{
+			name:               "no return data",
+			scr:                &smartContractResult.SmartContractResult{Data: []byte("claim")},
+			expectedReturnCode: "",
+			expectedReturnData: [][]byte{},
+		},
+		{
+			name:               "hex encoded ok with return data",
+			scr:                &smartContractResult.SmartContractResult{Data: []byte(okData + "@0a@@" + hex.EncodeToString([]byte("result")))},
+			expectedReturnCode: vmcommon.Ok.String(),
+			expectedReturnData: [][]byte{{0xa}, {}, []byte("result")},
+		},
+		{
+			name:               "hex encoded user error",
+			scr:                &smartContractResult.SmartContractResult{Data: []byte("@" + hex.EncodeToString([]byte("user error")))},
+			expectedReturnCode: vmcommon.UserError.String(),
+			expectedReturnData: [][]byte{},
+		},
+		{
+			name:               "numeric execution failed",
+			scr:                &smartContractResult.SmartContractResult{Data: []byte("@0a")},
+			expectedReturnCode: vmcommon.ExecutionFailed.String(),
+			expectedReturnData: [][]byte{},
+		},
+		{
+			name:               "numeric user error",
+			scr:                &smartContractResult.SmartContractResult{Data: []byte("@04")},
+			expectedReturnCode: vmcommon.UserError.String(),
+			expectedReturnData: [][]byte{},
+		},
+		{
+			name:               "not hex encoded return code",
+			scr:                &smartContractResult.SmartContractResult{Data: []byte("@ok")},
+			expectedReturnCode: vmcommon.Ok.String(),
+			expectedReturnData: [][]byte{},
+		},
+		{
+			name: "refund for sender of transaction in block",
+			scr: &smartContractResult.SmartContractResult{
+				Nonce:      5,
+				Value:      big.NewInt(100),
+				RcvAddr:    sender,
+				PrevTxHash: []byte("tx hash"),
+				Data:       []byte(okData),
+			},
+			expectedReturnCode: vmcommon.Ok.String(),
+			expectedReturnData: [][]byte{},
+			expectedIsRefund:   true,
+		},
+		{
+			name: "ok value transfer for sender of transaction in block, wrong nonce",
+			scr: &smartContractResult.SmartContractResult{
+				Nonce:      7,
+				Value:      big.NewInt(100),
+				RcvAddr:    sender,
+				PrevTxHash: []byte("tx hash"),
+				Data:       []byte(okData),
+			},
+			expectedReturnCode: vmcommon.Ok.String(),
+			expectedReturnData: [][]byte{},
+			expectedIsRefund:   false,
+		},
+		{
+			name: "cross shard refund",
+			scr: &smartContractResult.SmartContractResult{
+				Value:      big.NewInt(100),
+				RcvAddr:    sender,
+				PrevTxHash: []byte("tx from another shard"),
+				Data:       []byte(okData),
+				CallType:   vm.DirectCall,
+			},
+			expectedReturnCode: vmcommon.Ok.String(),
+			expectedReturnData: [][]byte{},
+			expectedIsRefund:   true,
+		},
+		{
+			name: "cross shard ok value transfer to smart contract",
+			scr: &smartContractResult.SmartContractResult{
+				Value:      big.NewInt(100),
+				RcvAddr:    scAddress,
+				PrevTxHash: []byte("tx from another shard"),
+				Data:       []byte(okData),
+			},
+			expectedReturnCode: vmcommon.Ok.String(),
+			expectedReturnData: [][]byte{},
+			expectedIsRefund:   false,
+		},
+		{
+			name: "gas refund for relayer",
+			scr: &smartContractResult.SmartContractResult{
+				Value:         big.NewInt(100),
+				RcvAddr:       []byte("relayer"),
+				PrevTxHash:    []byte("tx hash"),
+				Data:          []byte(okData),
+				ReturnMessage: []byte("gas refund for relayer"),
+			},
+			expectedReturnCode: vmcommon.Ok.String(),
+			expectedReturnData: [][]byte{},
+			expectedIsRefund:   true,
+		},
+		{
+			name: "failed execution with value",
+			scr: &smartContractResult.SmartContractResult{
+				Value:   big.NewInt(100),
+				RcvAddr: sender,
+				Data:    []byte("@04"),
+			},
+			expectedReturnCode: vmcommon.UserError.String(),
+			expectedReturnData: [][]byte{},
+			expectedIsRefund:   false,
+		},
+	}
+
+	for _, currTest := range tests {
+		pool := &indexer.Pool{
+			Txs:  txs,
+			Scrs: map[string]data.TransactionHandler{"scr hash": currTest.scr},
+		}
+
+		scp, _ := transactions.NewSCResultsProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+		ret, err := scp.ProcessSCRs(&block.Header{}, []byte("header hash"), &block.Body{}, pool)
+		require.Nil(t, err, currTest.name)
+		require.Len(t, ret, 1, currTest.name)
+		require.Equal(t, currTest.expectedReturnCode, ret[0].ReturnCode, currTest.name)
+		require.Equal(t, currTest.expectedReturnData, ret[0].ReturnData, currTest.name)
+		require.Equal(t, currTest.expectedIsRefund, ret[0].IsRefund, currTest.name)
+	}
+}
@@ -1,7 +1,10 @@
 package transactions
 
 import (
+	"math/big"
+
 	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/covalent-indexer-go/process"
 	"github.com/ElrondNetwork/covalent-indexer-go/process/utility"
 	"github.com/ElrondNetwork/covalent-indexer-go/schema"
 	"github.com/ElrondNetwork/elrond-go-core/core"
@@ -19,10 +22,22 @@ import (
 
 var log = logger.GetOrCreate("covalent/process/transactions/transactionProcessor")
 
+// miniBlockTypesProcessedElsewhere holds the miniblock types which do not hold transactions, their content
+// being processed by the smart contract results, receipts and peer changes processors
+var miniBlockTypesProcessedElsewhere = map[block.Type]struct{}{
+	block.SmartContractResultBlock: {},
+	block.ReceiptBlock:             {},
+	block.PeerBlock:                {},
+}
+
 type transactionProcessor struct {
-	hasher          hashing.Hasher
-	marshaller      marshal.Marshalizer
-	pubKeyConverter core.PubkeyConverter
+	hasher              hashing.Hasher
+	marshaller          marshal.Marshalizer
+	pubKeyConverter     core.PubkeyConverter
+	feeCalculator       process.FeeCalculator
+	feesEnabled         bool
+	callDataParser      *callDataParser
+	relayedTxMarshaller marshal.Marshalizer
 }
 
 // NewTransactionProcessor creates a new instance of transactions processor
@@ -30,6 +45,7 @@ func NewTransactionProcessor(
 	pubKeyConverter core.PubkeyConverter,
 	hasher hashing.Hasher,
 	marshaller marshal.Marshalizer,
+	feeCalculator process.FeeCalculator,
 ) (*transactionProcessor, error) {
 	if check.IfNil(pubKeyConverter) {
 		return nil, covalent.ErrNilPubKeyConverter
@@ -40,14 +56,30 @@ func NewTransactionProcessor(
 	if check.IfNil(hasher) {
 		return nil, covalent.ErrNilHasher
 	}
+	if check.IfNil(feeCalculator) {
+		return nil, covalent.ErrNilFeeCalculator
+	}
 
 	return &transactionProcessor{
-		pubKeyConverter: pubKeyConverter,
-		hasher:          hasher,
-		marshaller:      marshaller,
+		pubKeyConverter:     pubKeyConverter,
+		hasher:              hasher,
+		marshaller:          marshaller,
+		feeCalculator:       feeCalculator,
+		feesEnabled:         isFeeCalculatorEnabled(feeCalculator),
+		callDataParser:      newCallDataParser(),
+		relayedTxMarshaller: &marshal.JsonMarshalizer{},
 	}, nil
 }
 
+func isFeeCalculatorEnabled(feeCalculator process.FeeCalculator) bool {
+	statusHandler, ok := feeCalculator.(process.FeeCalculatorStatusHandler)
+	if !ok {
+		return true
+	}
+
+	return statusHandler.IsEnabled()
+}
+
 // ProcessTransactions converts transactions data to a specific structure defined by avro schema
 func (txp *transactionProcessor) ProcessTransactions(
 	header data.HeaderHandler,
@@ -60,14 +92,21 @@ func (txp *transactionProcessor) ProcessTransactions(
 		return nil, covalent.ErrBlockBodyAssertion
 	}
 
+	executionInfo := newTxsExecutionInfo(pool)
+
 	allTxs := make([]*schema.Transaction, 0, len(pool.Txs)+len(pool.Rewards)+len(pool.Invalid))
-	for _, currMiniBlock := range body.MiniBlocks {
+	unknownMiniBlocks := make(map[block.Type]int)
+	for mbIndex, currMiniBlock := range body.MiniBlocks {
 		currPool := getRelevantTxPoolBasedOnMBType(currMiniBlock, pool)
 		if currPool == nil {
+			_, processedElsewhere := miniBlockTypesProcessedElsewhere[currMiniBlock.Type]
+			if !processedElsewhere {
+				unknownMiniBlocks[currMiniBlock.Type]++
+			}
 			continue
 		}
 
-		txsInCurrMB, err := txp.processTxsFromMiniBlock(currPool, currMiniBlock, header, headerHash, currMiniBlock.Type)
+		txsInCurrMB, err := txp.processTxsFromMiniBlock(currPool, currMiniBlock, mbIndex, header, headerHash, executionInfo)
 		if err != nil {
 			log.Warn("transactionProcessor.processTxsFromMiniBlock", "error", err)
 			continue
@@ -75,15 +114,21 @@ func (txp *transactionProcessor) ProcessTransactions(
 		allTxs = append(allTxs, txsInCurrMB...)
 	}
 
+	for mbType, numMiniBlocks := range unknownMiniBlocks {
+		log.Warn("transactionProcessor.ProcessTransactions skipped miniblocks of unknown type",
+			"block hash", headerHash, "type", mbType.String(), "num miniblocks", numMiniBlocks)
+	}
+
 	return allTxs, nil
 }
 
 func (txp *transactionProcessor) processTxsFromMiniBlock(
 	transactions map[string]data.TransactionHandler,
 	miniBlock *erdBlock.MiniBlock,
+	miniBlockIndex int,
 	header data.HeaderHandler,
 	blockHash []byte,
-	mbType block.Type,
+	executionInfo *txsExecutionInfo,
 ) ([]*schema.Transaction, error) {
 	miniBlockHash, err := core.CalculateHash(txp.marshaller, txp.hasher, miniBlock)
 	if err != nil {
@@ -91,15 +136,17 @@ func (txp *transactionProcessor) processTxsFromMiniBlock(
 	}
 
 	txsInMiniBlock := make([]*schema.Transaction, 0, len(miniBlock.TxHashes))
-	for _, txHash := range miniBlock.TxHashes {
+	for txIndex, txHash := range miniBlock.TxHashes {
 		tx, isInPool := transactions[string(txHash)]
 		if !isInPool {
 			log.Warn("transactionProcessor.processTxsFromMiniBlock tx hash not found in tx pool", "hash", txHash)
 			continue
 		}
 
-		processedTx := txp.processTransaction(tx, txHash, miniBlockHash, blockHash, miniBlock, header, mbType)
+		processedTx := txp.processTransaction(tx, txHash, miniBlockHash, blockHash, miniBlock, header, executionInfo)
 		if processedTx != nil {
+			processedTx.MiniBlockIndex = int32(miniBlockIndex)
+			processedTx.IndexInMiniBlock = int32(txIndex)
 			txsInMiniBlock = append(txsInMiniBlock, processedTx)
 		}
 	}
@@ -114,17 +161,17 @@ func (txp *transactionProcessor) processTransaction(
 	blockHash []byte,
 	miniBlock *erdBlock.MiniBlock,
 	header data.HeaderHandler,
-	mbType block.Type,
+	executionInfo *txsExecutionInfo,
 ) *schema.Transaction {
 	var ret *schema.Transaction
 
-	switch mbType {
+	switch miniBlock.Type {
 	case block.TxBlock:
-		ret = txp.processNormalTransaction(tx, txHash, miniBlockHash, blockHash, miniBlock, header)
+		ret = txp.processNormalTransaction(tx, txHash, miniBlockHash, blockHash, miniBlock, header, executionInfo)
 	case block.RewardsBlock:
 		ret = txp.processRewardTransaction(tx, txHash, miniBlockHash, blockHash, miniBlock, header)
 	case block.InvalidBlock:
-		ret = txp.processNormalTransaction(tx, txHash, miniBlockHash, blockHash, miniBlock, header)
+		ret = txp.processNormalTransaction(tx, txHash, miniBlockHash, blockHash, miniBlock, header, executionInfo)
 	default:
 		return nil
 	}
@@ -139,13 +186,16 @@ func (txp *transactionProcessor) processNormalTransaction(
 	blockHash []byte,
 	miniBlock *erdBlock.MiniBlock,
 	header data.HeaderHandler,
+	executionInfo *txsExecutionInfo,
 ) *schema.Transaction {
 	tx, castOk := normalTx.(*transaction.Transaction)
 	if !castOk {
 		return nil
 	}
 
-	return &schema.Transaction{
+	parsedData := txp.callDataParser.parse(tx.GetRcvAddr(), tx.GetData())
+
+	processedTx := &schema.Transaction{
 		Hash:             txHash,
 		MiniBlockHash:    miniBlockHash,
 		BlockHash:        blockHash,
@@ -159,11 +209,39 @@ func (txp *transactionProcessor) processNormalTransaction(
 		GasPrice:         int64(tx.GetGasPrice()),
 		GasLimit:         int64(tx.GetGasLimit()),
 		Data:             tx.GetData(),
+		Function:         parsedData.function,
+		Arguments:        parsedData.arguments,
+		Operation:        parsedData.operation,
 		Signature:        tx.GetSignature(),
 		Timestamp:        int64(header.GetTimeStamp()),
 		SenderUserName:   tx.GetSndUserName(),
 		ReceiverUserName: tx.GetRcvUserName(),
+		Status:           executionInfo.getStatus(txHash, miniBlock, header.GetShardID()),
+		InnerTransaction: txp.processInnerTransaction(tx, txHash, parsedData, executionInfo),
 	}
+	if txp.feesEnabled {
+		txp.setGasUsedAndFees(processedTx, tx, txHash, miniBlock, executionInfo)
+	}
+
+	return processedTx
+}
+
+// setGasUsedAndFees fills the gas and fees of a transaction, which are left empty when no fee calculator is enabled
+func (txp *transactionProcessor) setGasUsedAndFees(
+	processedTx *schema.Transaction,
+	tx *transaction.Transaction,
+	txHash []byte,
+	miniBlock *erdBlock.MiniBlock,
+	executionInfo *txsExecutionInfo,
+) {
+	gasUsed, fee := txp.computeGasUsedAndFee(tx, txHash, miniBlock, executionInfo)
+	moveBalanceGas := txp.feeCalculator.ComputeGasLimit(tx)
+
+	processedTx.GasUsed = int64(gasUsed)
+	processedTx.Fee = utility.GetBytes(fee)
+	processedTx.InitialPaidFee = utility.GetBytes(txp.feeCalculator.ComputeTxFee(tx))
+	processedTx.MoveBalanceGas = int64(moveBalanceGas)
+	processedTx.ProcessingFee = utility.GetBytes(txp.computeProcessingFee(tx, gasUsed, moveBalanceGas))
 }
 
 func (txp *transactionProcessor) processRewardTransaction(
@@ -193,13 +271,46 @@ func (txp *transactionProcessor) processRewardTransaction(
 		GasPrice:         0,
 		GasLimit:         0,
 		Data:             nil,
+		Function:         "",
+		Arguments:        make([][]byte, 0),
+		Operation:        OperationTransfer,
 		Signature:        nil,
 		Timestamp:        int64(header.GetTimeStamp()),
 		SenderUserName:   nil,
 		ReceiverUserName: nil,
+		Status:           TxStatusSuccess,
+		GasUsed:          int64(0),
+		Fee:              utility.GetBytes(nil),
+		InitialPaidFee:   utility.GetBytes(nil),
+		MoveBalanceGas:   int64(0),
+		ProcessingFee:    utility.GetBytes(nil),
 	}
 }
 
+// computeGasUsedAndFee computes the gas used and the fee of a transaction. Invalid transactions only pay for
+// the move balance gas, while executed ones pay for their whole gas limit, from which the unused gas is refunded
+func (txp *transactionProcessor) computeGasUsedAndFee(
+	tx *transaction.Transaction,
+	txHash []byte,
+	miniBlock *erdBlock.MiniBlock,
+	executionInfo *txsExecutionInfo,
+) (uint64, *big.Int) {
+	if miniBlock.Type == block.InvalidBlock {
+		return txp.feeCalculator.ComputeGasLimit(tx), txp.feeCalculator.ComputeMoveBalanceFee(tx)
+	}
+
+	return txp.feeCalculator.ComputeGasUsedAndFeeBasedOnRefundValue(tx, executionInfo.getRefund(tx, txHash))
+}
+
+// computeProcessingFee computes the fee paid for the gas used beyond the move balance gas
+func (txp *transactionProcessor) computeProcessingFee(tx *transaction.Transaction, gasUsed uint64, moveBalanceGas uint64) *big.Int {
+	if gasUsed <= moveBalanceGas {
+		return big.NewInt(0)
+	}
+
+	return txp.feeCalculator.ComputeFeeForProcessing(tx, gasUsed-moveBalanceGas)
+}
+
 func getRelevantTxPoolBasedOnMBType(miniBlock *erdBlock.MiniBlock, pool *indexer.Pool) map[string]data.TransactionHandler {
 	var ret map[string]data.TransactionHandler
 
@@ -216,3 +327,8 @@ func getRelevantTxPoolBasedOnMBType(miniBlock *erdBlock.MiniBlock, pool *indexer
 
 	return ret
 }
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (txp *transactionProcessor) IsInterfaceNil() bool {
+	return txp == nil
+}
@@ -1,11 +1,15 @@
 package transactions_test
 
 import (
+	"encoding/hex"
 	"errors"
+	"math/big"
 	"math/rand"
 	"testing"
 
 	"github.com/ElrondNetwork/covalent-indexer-go"
+	"github.com/ElrondNetwork/covalent-indexer-go/process"
+	"github.com/ElrondNetwork/covalent-indexer-go/process/economics"
 	"github.com/ElrondNetwork/covalent-indexer-go/process/transactions"
 	"github.com/ElrondNetwork/covalent-indexer-go/process/utility"
 	"github.com/ElrondNetwork/covalent-indexer-go/schema"
@@ -15,6 +19,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go-core/data"
 	"github.com/ElrondNetwork/elrond-go-core/data/block"
 	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
+	"github.com/ElrondNetwork/elrond-go-core/data/receipt"
 	"github.com/ElrondNetwork/elrond-go-core/data/rewardTx"
 	"github.com/ElrondNetwork/elrond-go-core/data/smartContractResult"
 	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
@@ -85,30 +90,36 @@ func TestNewTransactionProcessor(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		args        func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer)
+		args        func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer, process.FeeCalculator)
 		expectedErr error
 	}{
 		{
-			args: func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer) {
-				return nil, &mock.HasherMock{}, &mock.MarshallerStub{}
+			args: func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer, process.FeeCalculator) {
+				return nil, &mock.HasherMock{}, &mock.MarshallerStub{}, &mock.FeeCalculatorStub{}
 			},
 			expectedErr: covalent.ErrNilPubKeyConverter,
 		},
 		{
-			args: func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer) {
-				return &mock.PubKeyConverterStub{}, nil, &mock.MarshallerStub{}
+			args: func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer, process.FeeCalculator) {
+				return &mock.PubKeyConverterStub{}, nil, &mock.MarshallerStub{}, &mock.FeeCalculatorStub{}
 			},
 			expectedErr: covalent.ErrNilHasher,
 		},
 		{
-			args: func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer) {
-				return &mock.PubKeyConverterStub{}, &mock.HasherMock{}, nil
+			args: func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer, process.FeeCalculator) {
+				return &mock.PubKeyConverterStub{}, &mock.HasherMock{}, nil, &mock.FeeCalculatorStub{}
 			},
 			expectedErr: covalent.ErrNilMarshaller,
 		},
 		{
-			args: func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer) {
-				return &mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}
+			args: func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer, process.FeeCalculator) {
+				return &mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}, nil
+			},
+			expectedErr: covalent.ErrNilFeeCalculator,
+		},
+		{
+			args: func() (core.PubkeyConverter, hashing.Hasher, marshal.Marshalizer, process.FeeCalculator) {
+				return &mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}, &mock.FeeCalculatorStub{}
 			},
 			expectedErr: nil,
 		},
@@ -126,7 +137,7 @@ func TestTransactionProcessor_ProcessTransactions_InvalidBody_ExpectError(t *tes
 	hData := generateRandomHeaderData()
 	body := data.BodyHandler(nil)
 
-	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}, &mock.FeeCalculatorStub{})
 	_, err := txp.ProcessTransactions(hData.header, hData.headerHash, body, &indexer.Pool{})
 
 	require.Equal(t, covalent.ErrBlockBodyAssertion, err)
@@ -162,7 +173,8 @@ func TestTransactionProcessor_ProcessTransactions_InvalidMarshaller_ExpectZeroPr
 			MarshalCalled: func(obj interface{}) ([]byte, error) {
 				return nil, errMarshaller
 			},
-		})
+		},
+		&mock.FeeCalculatorStub{})
 	ret, err := txp.ProcessTransactions(hData.header, hData.headerHash, body, pool)
 
 	require.Nil(t, err)
@@ -193,7 +205,7 @@ func TestTransactionProcessor_ProcessTransactions_EmptyRelevantBlocks_ExpectZero
 	},
 	}
 
-	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}, &mock.FeeCalculatorStub{})
 	ret, err := txp.ProcessTransactions(hData.header, hData.headerHash, body, &indexer.Pool{})
 
 	require.Nil(t, err)
@@ -224,7 +236,7 @@ func TestTransactionProcessor_ProcessTransactions_ThreeBlocks_TxsNotFoundInPool_
 	},
 	}
 
-	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}, &mock.FeeCalculatorStub{})
 	ret, err := txp.ProcessTransactions(hData.header, hData.headerHash, body, &indexer.Pool{})
 
 	require.Nil(t, err)
@@ -253,7 +265,7 @@ func TestTransactionProcessor_ProcessTransactions_OneTxBlock_OneTx_ExpectOneProc
 		Txs: txPool,
 	}
 
-	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}, &mock.FeeCalculatorStub{})
 	ret, _ := txp.ProcessTransactions(hData.header, hData.headerHash, body, pool)
 
 	require.Len(t, ret, 1)
@@ -282,7 +294,7 @@ func TestTransactionProcessor_ProcessTransactions_OneRewardBlock_OneRewardTx_Exp
 		Rewards: rewardsPool,
 	}
 
-	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}, &mock.FeeCalculatorStub{})
 	ret, _ := txp.ProcessTransactions(hData.header, hData.headerHash, body, pool)
 
 	require.Len(t, ret, 1)
@@ -311,7 +323,7 @@ func TestTransactionProcessor_ProcessTransactions_OneInvalidBlock_OneTx_ExpectOn
 		Invalid: invalidTxPool,
 	}
 
-	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}, &mock.FeeCalculatorStub{})
 	ret, _ := txp.ProcessTransactions(hData.header, hData.headerHash, body, pool)
 
 	require.Len(t, ret, 1)
@@ -360,13 +372,18 @@ func TestTransactionProcessor_ProcessTransactions_ThreeRelevantBlocks_ThreeRelev
 		Invalid: invalidTxPool,
 	}
 
-	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}, &mock.FeeCalculatorStub{})
 	ret, _ := txp.ProcessTransactions(hData.header, hData.headerHash, body, pool)
 
 	require.Len(t, ret, 3)
 	requireProcessedTransactionEqual(t, ret[0], normalTxData, body.GetMiniBlocks()[0], &mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
 	requireProcessedTransactionEqual(t, ret[1], rewardTxData, body.GetMiniBlocks()[1], &mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
 	requireProcessedTransactionEqual(t, ret[2], invalidTxData, body.GetMiniBlocks()[2], &mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+
+	for idx, processedTx := range ret {
+		require.Equal(t, int32(idx), processedTx.MiniBlockIndex)
+		require.Equal(t, int32(0), processedTx.IndexInMiniBlock)
+	}
 }
 
 func TestTransactionProcessor_ProcessTransactions_OneTxBLock_TwoNormalTxs_ExpectTwoProcessedTxs(t *testing.T) {
@@ -394,13 +411,18 @@ func TestTransactionProcessor_ProcessTransactions_OneTxBLock_TwoNormalTxs_Expect
 		Txs: txPool,
 	}
 
-	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}, &mock.FeeCalculatorStub{})
 	ret, _ := txp.ProcessTransactions(hData.header, hData.headerHash, body, pool)
 
 	require.Len(t, ret, 2)
 
 	requireProcessedTransactionEqual(t, ret[0], txData1, body.GetMiniBlocks()[0], &mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
 	requireProcessedTransactionEqual(t, ret[1], txData2, body.GetMiniBlocks()[0], &mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+
+	require.Equal(t, int32(0), ret[0].MiniBlockIndex)
+	require.Equal(t, int32(0), ret[0].IndexInMiniBlock)
+	require.Equal(t, int32(0), ret[1].MiniBlockIndex)
+	require.Equal(t, int32(1), ret[1].IndexInMiniBlock)
 }
 
 func TestTransactionProcessor_ProcessTransactions_TwoTxBlocks_TwoTxs_ExpectTwoProcessedTx(t *testing.T) {
@@ -433,7 +455,7 @@ func TestTransactionProcessor_ProcessTransactions_TwoTxBlocks_TwoTxs_ExpectTwoPr
 		Txs: txPool,
 	}
 
-	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}, &mock.FeeCalculatorStub{})
 	ret, _ := txp.ProcessTransactions(hData.header, hData.headerHash, body, pool)
 
 	require.Len(t, ret, 2)
@@ -474,7 +496,7 @@ func TestTransactionProcessor_ProcessTransactions_TwoRewardsBlocks_TwoRewardTxs_
 		Rewards: rewardsTxPool,
 	}
 
-	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}, &mock.FeeCalculatorStub{})
 	ret, _ := txp.ProcessTransactions(hData.header, hData.headerHash, body, pool)
 
 	require.Len(t, ret, 2)
@@ -504,7 +526,7 @@ func TestTransactionProcessor_ProcessTransactions_OneTxBlock_OneSCRTx_ExpectZero
 	pool := &indexer.Pool{
 		Txs: txPool,
 	}
-	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}, &mock.FeeCalculatorStub{})
 	ret, err := txp.ProcessTransactions(hData.header, hData.headerHash, body, pool)
 
 	require.Nil(t, err)
@@ -531,7 +553,7 @@ func TestTransactionProcessor_ProcessTransactions_OneSCRBlock_OneSCRTx_ExpectZer
 		Txs: txPool,
 	}
 
-	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{})
+	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}, &mock.FeeCalculatorStub{})
 	ret, err := txp.ProcessTransactions(hData.header, hData.headerHash, body, pool)
 
 	require.Nil(t, err)
@@ -573,6 +595,194 @@ func requireRewardTxEqual(
 	require.Equal(t, int64(tx.GetRound()), processedTx.Round)
 }
 
+func TestTransactionProcessor_ProcessTransactions_StatusGasUsedAndFee(t *testing.T) {
+	t.Parallel()
+
+	txHash := []byte("tx hash")
+	sender := []byte("sender")
+	newTx := func() *transaction.Transaction {
+		return &transaction.Transaction{Nonce: 7, SndAddr: sender, RcvAddr: []byte("receiver"), GasLimit: 1000, GasPrice: 10}
+	}
+	okData := []byte("@" + hex.EncodeToString([]byte("ok")))
+	userErrorData := []byte("@" + hex.EncodeToString([]byte("user error")))
+	numericOkData := []byte("@00")
+	numericUserErrorData := []byte("@04")
+
+	tests := []struct {
+		name            string
+		mbType          block.Type
+		mbSenderShard   uint32
+		mbReceiverShard uint32
+		pool            *indexer.Pool
+		expectedStatus  string
+		expectedGasUsed int64
+		expectedFee     *big.Int
+	}{
+		{
+			name:            "intra shard, no refund",
+			mbType:          block.TxBlock,
+			pool:            &indexer.Pool{},
+			expectedStatus:  transactions.TxStatusSuccess,
+			expectedGasUsed: 1000,
+			expectedFee:     big.NewInt(10000),
+		},
+		{
+			name:            "cross shard on source shard",
+			mbType:          block.TxBlock,
+			mbReceiverShard: 1,
+			pool:            &indexer.Pool{},
+			expectedStatus:  transactions.TxStatusPending,
+			expectedGasUsed: 1000,
+			expectedFee:     big.NewInt(10000),
+		},
+		{
+			name:            "cross shard on destination shard",
+			mbType:          block.TxBlock,
+			mbSenderShard:   1,
+			pool:            &indexer.Pool{},
+			expectedStatus:  transactions.TxStatusSuccess,
+			expectedGasUsed: 1000,
+			expectedFee:     big.NewInt(10000),
+		},
+		{
+			name:            "invalid",
+			mbType:          block.InvalidBlock,
+			pool:            &indexer.Pool{},
+			expectedStatus:  transactions.TxStatusInvalid,
+			expectedGasUsed: 50,
+			expectedFee:     big.NewInt(500),
+		},
+		{
+			name:   "refund receipt",
+			mbType: block.TxBlock,
+			pool: &indexer.Pool{Receipts: map[string]data.TransactionHandler{
+				"receipt": &receipt.Receipt{TxHash: txHash, Data: []byte("refundedGas"), Value: big.NewInt(4000)},
+			}},
+			expectedStatus:  transactions.TxStatusSuccess,
+			expectedGasUsed: 600,
+			expectedFee:     big.NewInt(6000),
+		},
+		{
+			name:   "failed with receipt",
+			mbType: block.TxBlock,
+			pool: &indexer.Pool{Receipts: map[string]data.TransactionHandler{
+				"receipt": &receipt.Receipt{TxHash: txHash, Data: []byte("insufficient funds"), Value: big.NewInt(10000)},
+			}},
+			expectedStatus:  transactions.TxStatusFail,
+			expectedGasUsed: 1000,
+			expectedFee:     big.NewInt(10000),
+		},
+		{
+			name:   "refund scr",
+			mbType: block.TxBlock,
+			pool: &indexer.Pool{Scrs: map[string]data.TransactionHandler{
+				"refund": &smartContractResult.SmartContractResult{
+					Nonce: 8, RcvAddr: sender, Value: big.NewInt(2000), Data: okData, PrevTxHash: txHash, OriginalTxHash: txHash},
+				"not for sender": &smartContractResult.SmartContractResult{
+					Nonce: 8, RcvAddr: []byte("other"), Value: big.NewInt(2000), Data: okData, PrevTxHash: txHash, OriginalTxHash: txHash},
+			}},
+			expectedStatus:  transactions.TxStatusSuccess,
+			expectedGasUsed: 800,
+			expectedFee:     big.NewInt(8000),
+		},
+		{
+			name:   "failed with scr error",
+			mbType: block.TxBlock,
+			pool: &indexer.Pool{Scrs: map[string]data.TransactionHandler{
+				"scr": &smartContractResult.SmartContractResult{
+					Nonce: 8, RcvAddr: sender, Data: userErrorData, PrevTxHash: txHash, OriginalTxHash: txHash},
+			}},
+			expectedStatus:  transactions.TxStatusFail,
+			expectedGasUsed: 1000,
+			expectedFee:     big.NewInt(10000),
+		},
+		{
+			name:   "refund scr with numeric return code",
+			mbType: block.TxBlock,
+			pool: &indexer.Pool{Scrs: map[string]data.TransactionHandler{
+				"refund": &smartContractResult.SmartContractResult{
+					Nonce: 8, RcvAddr: sender, Value: big.NewInt(2000), Data: numericOkData, PrevTxHash: txHash, OriginalTxHash: txHash},
+			}},
+			expectedStatus:  transactions.TxStatusSuccess,
+			expectedGasUsed: 800,
+			expectedFee:     big.NewInt(8000),
+		},
+		{
+			name:   "failed with numeric scr error",
+			mbType: block.TxBlock,
+			pool: &indexer.Pool{Scrs: map[string]data.TransactionHandler{
+				"scr": &smartContractResult.SmartContractResult{
+					Nonce: 8, RcvAddr: sender, Value: big.NewInt(2000), Data: numericUserErrorData, PrevTxHash: txHash, OriginalTxHash: txHash},
+			}},
+			expectedStatus:  transactions.TxStatusFail,
+			expectedGasUsed: 1000,
+			expectedFee:     big.NewInt(10000),
+		},
+		{
+			name:   "failed with signal error event",
+			mbType: block.TxBlock,
+			pool: &indexer.Pool{Logs: []*data.LogData{
+				{
+					TxHash:     string(txHash),
+					LogHandler: &transaction.Log{Events: []*transaction.Event{{Identifier: []byte("signalError")}}},
+				},
+			}},
+			expectedStatus:  transactions.TxStatusFail,
+			expectedGasUsed: 1000,
+			expectedFee:     big.NewInt(10000),
+		},
+	}
+
+	feeCalculator, _ := economics.NewFeeCalculator(&economics.EconomicsConfig{
+		FeeSettings: economics.FeeSettings{MinGasLimit: "50", GasPerDataByte: "0", GasPriceModifier: 1},
+	})
+
+	for _, currTest := range tests {
+		body := &block.Body{MiniBlocks: []*block.MiniBlock{
+			{
+				TxHashes:        [][]byte{txHash},
+				SenderShardID:   currTest.mbSenderShard,
+				ReceiverShardID: currTest.mbReceiverShard,
+				Type:            currTest.mbType},
+		}}
+		currTest.pool.Txs = map[string]data.TransactionHandler{string(txHash): newTx()}
+		currTest.pool.Invalid = map[string]data.TransactionHandler{string(txHash): newTx()}
+
+		txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}, feeCalculator)
+		ret, err := txp.ProcessTransactions(&block.Header{ShardID: 0}, []byte("header hash"), body, currTest.pool)
+
+		require.Nil(t, err, currTest.name)
+		require.Len(t, ret, 1, currTest.name)
+		require.Equal(t, currTest.expectedStatus, ret[0].Status, currTest.name)
+		require.Equal(t, currTest.expectedGasUsed, ret[0].GasUsed, currTest.name)
+		require.Equal(t, currTest.expectedFee.Bytes(), ret[0].Fee, currTest.name)
+		require.Equal(t, big.NewInt(10000).Bytes(), ret[0].InitialPaidFee, currTest.name)
+		require.Equal(t, int64(50), ret[0].MoveBalanceGas, currTest.name)
+		require.Equal(t, big.NewInt((currTest.expectedGasUsed-50)*10).Bytes(), ret[0].ProcessingFee, currTest.name)
+	}
+}
+
+func TestTransactionProcessor_ProcessTransactions_DisabledFeeCalculator_ExpectNullGasAndFees(t *testing.T) {
+	t.Parallel()
+
+	txHash := []byte("tx hash")
+	body := &block.Body{MiniBlocks: []*block.MiniBlock{{TxHashes: [][]byte{txHash}, Type: block.TxBlock}}}
+	pool := &indexer.Pool{Txs: map[string]data.TransactionHandler{
+		string(txHash): &transaction.Transaction{Nonce: 7, SndAddr: []byte("sender"), GasLimit: 1000, GasPrice: 10},
+	}}
+
+	txp, _ := transactions.NewTransactionProcessor(&mock.PubKeyConverterStub{}, &mock.HasherMock{}, &mock.MarshallerStub{}, economics.NewDisabledFeeCalculator())
+	ret, err := txp.ProcessTransactions(&block.Header{}, []byte("header hash"), body, pool)
+	require.Nil(t, err)
+	require.Len(t, ret, 1)
+	require.Nil(t, ret[0].GasUsed)
+	require.Nil(t, ret[0].Fee)
+	require.Nil(t, ret[0].InitialPaidFee)
+	require.Nil(t, ret[0].MoveBalanceGas)
+	require.Nil(t, ret[0].ProcessingFee)
+	require.Equal(t, int64(1000), ret[0].GasLimit)
+}
+
 func requireProcessedTransactionEqual(
 	t *testing.T,
 	processedTx *schema.Transaction,
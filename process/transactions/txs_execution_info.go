@@ -0,0 +1,207 @@
+package transactions
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/ElrondNetwork/covalent-indexer-go/process/utility"
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/block"
+	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
+	"github.com/ElrondNetwork/elrond-go-core/data/receipt"
+	"github.com/ElrondNetwork/elrond-go-core/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
+	vmcommon "github.com/ElrondNetwork/elrond-vm-common"
+)
+
+const (
+	// TxStatusSuccess defines the status of a successfully executed transaction
+	TxStatusSuccess = "success"
+	// TxStatusFail defines the status of a transaction whose execution failed
+	TxStatusFail = "fail"
+	// TxStatusInvalid defines the status of a transaction included in an invalid miniblock
+	TxStatusInvalid = "invalid"
+	// TxStatusPending defines the status of a cross shard transaction which is not yet executed in destination shard
+	TxStatusPending = "pending"
+)
+
+const (
+	refundGasMessage    = "refundedGas"
+	returnDataSeparator = "@"
+)
+
+// txsExecutionInfo holds the execution results of the transactions from a block, as found in its pool:
+// failed transactions, gas refunds, the smart contract results generated by each transaction and the
+// hashes of the smart contract results executing the inner transactions of relayed transactions
+type txsExecutionInfo struct {
+	failedTxs       map[string]struct{}
+	receiptsRefunds map[string]*big.Int
+	scrsByPrevTx    map[string][]*smartContractResult.SmartContractResult
+	relayedSCRs     map[string][][]byte
+}
+
+func newTxsExecutionInfo(pool *indexer.Pool) *txsExecutionInfo {
+	info := &txsExecutionInfo{
+		failedTxs:       make(map[string]struct{}),
+		receiptsRefunds: make(map[string]*big.Int),
+		scrsByPrevTx:    make(map[string][]*smartContractResult.SmartContractResult),
+		relayedSCRs:     make(map[string][][]byte),
+	}
+
+	info.processSCRs(pool.Scrs)
+	info.processReceipts(pool.Receipts)
+	info.processLogs(pool.Logs, pool.Scrs)
+
+	return info
+}
+
+func (info *txsExecutionInfo) processSCRs(scrs map[string]data.TransactionHandler) {
+	for _, hash := range utility.SortedHashes(scrs) {
+		scr, ok := scrs[hash].(*smartContractResult.SmartContractResult)
+		if !ok {
+			continue
+		}
+
+		prevTxHash := string(scr.GetPrevTxHash())
+		info.scrsByPrevTx[prevTxHash] = append(info.scrsByPrevTx[prevTxHash], scr)
+
+		if len(scr.GetRelayerAddr()) > 0 {
+			originalTxHash := utility.GetOriginalTxHash(scr, string(scr.GetPrevTxHash()))
+			info.relayedSCRs[originalTxHash] = append(info.relayedSCRs[originalTxHash], []byte(hash))
+		}
+
+		returnCode, isReturnData := getReturnCode(scr.GetData())
+		if isReturnData && returnCode != vmcommon.Ok.String() {
+			info.failedTxs[utility.GetOriginalTxHash(scr, string(scr.GetPrevTxHash()))] = struct{}{}
+		}
+	}
+}
+
+func (info *txsExecutionInfo) processReceipts(receipts map[string]data.TransactionHandler) {
+	for _, tx := range receipts {
+		rec, ok := tx.(*receipt.Receipt)
+		if !ok {
+			continue
+		}
+
+		txHash := string(rec.GetTxHash())
+		if string(rec.GetData()) != refundGasMessage {
+			// receipts other than gas refunds hold the error of a failed transaction
+			info.failedTxs[txHash] = struct{}{}
+			continue
+		}
+
+		refund, found := info.receiptsRefunds[txHash]
+		if !found {
+			refund = big.NewInt(0)
+			info.receiptsRefunds[txHash] = refund
+		}
+		if rec.GetValue() != nil {
+			refund.Add(refund, rec.GetValue())
+		}
+	}
+}
+
+func (info *txsExecutionInfo) processLogs(logs []*data.LogData, scrs map[string]data.TransactionHandler) {
+	for _, logData := range logs {
+		if logData == nil || check.IfNil(logData.LogHandler) {
+			continue
+		}
+
+		txHash := logData.TxHash
+		scr, isSCR := scrs[txHash].(*smartContractResult.SmartContractResult)
+		if isSCR {
+			txHash = utility.GetOriginalTxHash(scr, string(scr.GetPrevTxHash()))
+		}
+
+		for _, event := range logData.LogHandler.GetLogEvents() {
+			if !check.IfNil(event) && string(event.GetIdentifier()) == utility.SignalErrorOperation {
+				info.failedTxs[txHash] = struct{}{}
+			}
+		}
+	}
+}
+
+func (info *txsExecutionInfo) getStatus(txHash []byte, miniBlock *block.MiniBlock, selfShardID uint32) string {
+	if miniBlock.Type == block.InvalidBlock {
+		return TxStatusInvalid
+	}
+
+	_, failed := info.failedTxs[string(txHash)]
+	if failed {
+		return TxStatusFail
+	}
+
+	isCrossShardOnSource := miniBlock.SenderShardID == selfShardID && miniBlock.ReceiverShardID != selfShardID
+	if isCrossShardOnSource {
+		return TxStatusPending
+	}
+
+	return TxStatusSuccess
+}
+
+// getRefund returns the gas refunded to the sender of a transaction, either by a receipt or by a smart contract result
+func (info *txsExecutionInfo) getRefund(tx *transaction.Transaction, txHash []byte) *big.Int {
+	refund := big.NewInt(0)
+
+	receiptRefund, found := info.receiptsRefunds[string(txHash)]
+	if found {
+		refund.Add(refund, receiptRefund)
+	}
+
+	for _, scr := range info.scrsByPrevTx[string(txHash)] {
+		if isRefundSCR(scr, tx) {
+			refund.Add(refund, scr.GetValue())
+		}
+	}
+
+	return refund
+}
+
+// getRelayedSCRHashes returns the hashes of the smart contract results which carry the relayer address and
+// relayed value of the relayed transaction with the given hash
+func (info *txsExecutionInfo) getRelayedSCRHashes(txHash []byte) [][]byte {
+	hashes, found := info.relayedSCRs[string(txHash)]
+	if !found {
+		return make([][]byte, 0)
+	}
+
+	return hashes
+}
+
+func isRefundSCR(scr *smartContractResult.SmartContractResult, tx *transaction.Transaction) bool {
+	if scr.GetValue() == nil || scr.GetValue().Sign() <= 0 {
+		return false
+	}
+
+	isForSender := bytes.Equal(scr.GetRcvAddr(), tx.GetSndAddr())
+	isNextNonce := scr.GetNonce() == tx.GetNonce()+1
+	returnCode, isReturnData := getReturnCode(scr.GetData())
+	isOk := isReturnData && returnCode == vmcommon.Ok.String()
+
+	return isForSender && isNextNonce && isOk
+}
+
+// getReturnCode returns the return code found in smart contract results data, which has the
+// form @returnCode[@returnData...], the return code being either hex encoded or not (older versions).
+// The return code is normalized to its textual form
+func getReturnCode(scrData []byte) (string, bool) {
+	if !bytes.HasPrefix(scrData, []byte(returnDataSeparator)) {
+		return "", false
+	}
+
+	tokens := strings.Split(string(scrData), returnDataSeparator)
+	if len(tokens[1]) == 0 {
+		return "", false
+	}
+
+	returnCode, err := hex.DecodeString(tokens[1])
+	if err != nil {
+		return normalizeReturnCode(tokens[1]), true
+	}
+
+	return normalizeReturnCode(string(returnCode)), true
+}
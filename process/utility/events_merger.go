@@ -0,0 +1,69 @@
+package utility
+
+// EventsMerger merges the events detected in a block from log events with the ones detected from the call data
+// of transactions and smart contract results, such that each operation is recorded only once. Events are identified
+// by a key built by the caller from the event fields, so that it can be used for any type of event
+type EventsMerger struct {
+	all          []interface{}
+	byKey        map[string]interface{}
+	typesFromLog map[string]map[string]struct{}
+	failedTxs    map[string]struct{}
+}
+
+// NewEventsMerger creates a new instance of events merger
+func NewEventsMerger() *EventsMerger {
+	return &EventsMerger{
+		all:          make([]interface{}, 0),
+		byKey:        make(map[string]interface{}),
+		typesFromLog: make(map[string]map[string]struct{}),
+		failedTxs:    make(map[string]struct{}),
+	}
+}
+
+// MarkFailed records that the transaction with the given hash failed, so that its call data events are ignored
+func (em *EventsMerger) MarkFailed(txHash string) {
+	em.failedTxs[txHash] = struct{}{}
+}
+
+// AddFromLog adds an event found in the logs of the transaction with the given hash
+func (em *EventsMerger) AddFromLog(txHash string, eventType string, key string, event interface{}) {
+	if _, exists := em.typesFromLog[txHash]; !exists {
+		em.typesFromLog[txHash] = make(map[string]struct{})
+	}
+	em.typesFromLog[txHash][eventType] = struct{}{}
+
+	em.add(key, event)
+}
+
+// AddFromCallData adds an event found in the call data of the transaction with the given hash, unless an event of
+// the same type was found in its logs or the transaction failed. If an event with the same key was already added,
+// it is returned instead, so that it can be enriched with the details only found in call data
+func (em *EventsMerger) AddFromCallData(txHash string, eventType string, key string, event interface{}) interface{} {
+	existingEvent, exists := em.byKey[key]
+	if exists {
+		return existingEvent
+	}
+
+	_, typeFoundInLog := em.typesFromLog[txHash][eventType]
+	_, isFailed := em.failedTxs[txHash]
+	if typeFoundInLog || isFailed {
+		return nil
+	}
+
+	em.add(key, event)
+	return nil
+}
+
+// Events returns all merged events, in the order they were added
+func (em *EventsMerger) Events() []interface{} {
+	return em.all
+}
+
+func (em *EventsMerger) add(key string, event interface{}) {
+	if _, exists := em.byKey[key]; exists {
+		return
+	}
+
+	em.byKey[key] = event
+	em.all = append(em.all, event)
+}
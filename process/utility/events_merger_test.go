@@ -0,0 +1,47 @@
+package utility_test
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/covalent-indexer-go/process/utility"
+	"github.com/stretchr/testify/require"
+)
+
+type testEvent struct {
+	txHash string
+	detail string
+}
+
+func TestEventsMerger_AddFromLog_SameKey_ExpectOneEvent(t *testing.T) {
+	t.Parallel()
+
+	em := utility.NewEventsMerger()
+	em.AddFromLog("tx", "type", "key", &testEvent{txHash: "tx"})
+	em.AddFromLog("tx", "type", "key", &testEvent{txHash: "tx"})
+
+	require.Len(t, em.Events(), 1)
+}
+
+func TestEventsMerger_AddFromCallData(t *testing.T) {
+	t.Parallel()
+
+	em := utility.NewEventsMerger()
+	fromLog := &testEvent{txHash: "tx1"}
+	em.AddFromLog("tx1", "type", "key1", fromLog)
+	em.MarkFailed("tx3")
+
+	// same event found in log is returned to be enriched
+	existingEvent := em.AddFromCallData("tx1", "type", "key1", &testEvent{txHash: "tx1", detail: "detail"})
+	require.Equal(t, fromLog, existingEvent)
+
+	// event of a type found in the logs of the same tx, but with another key, is ignored
+	require.Nil(t, em.AddFromCallData("tx1", "type", "key2", &testEvent{txHash: "tx1"}))
+
+	// events of failed txs are ignored
+	require.Nil(t, em.AddFromCallData("tx3", "type", "key3", &testEvent{txHash: "tx3"}))
+
+	fromCallData := &testEvent{txHash: "tx2"}
+	require.Nil(t, em.AddFromCallData("tx2", "type", "key4", fromCallData))
+
+	require.Equal(t, []interface{}{fromLog, fromCallData}, em.Events())
+}
@@ -5,11 +5,17 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/ElrondNetwork/elrond-go-core/core"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/smartContractResult"
 	"github.com/elodina/go-avro"
 )
 
+// SignalErrorOperation is the identifier of the log event emitted when a transaction fails
+const SignalErrorOperation = "signalError"
+
 // HexSliceToByteSlice outputs a decoded byte slice representation of a hex string encoded slice input
 func HexSliceToByteSlice(in []string) ([][]byte, error) {
 	if in == nil {
@@ -52,6 +58,33 @@ func GetBytes(val *big.Int) []byte {
 	return big.NewInt(0).Bytes()
 }
 
+// GetSignedBytes returns the minimal big endian two's complement representation of a big int input, as used
+// by the avro decimal logical type, or []byte{} for zero or nil
+func GetSignedBytes(val *big.Int) []byte {
+	if val == nil || val.Sign() == 0 {
+		return big.NewInt(0).Bytes()
+	}
+	if val.Sign() > 0 {
+		ret := val.Bytes()
+		if ret[0]&0x80 != 0 {
+			ret = append([]byte{0}, ret...)
+		}
+		return ret
+	}
+
+	// -val = ^(|val| - 1), computed over the minimal number of bytes which keeps the sign bit set
+	magnitudeMinusOne := big.NewInt(0).Sub(big.NewInt(0).Neg(val), big.NewInt(1))
+	ret := magnitudeMinusOne.Bytes()
+	if len(ret) == 0 || ret[0]&0x80 != 0 {
+		ret = append([]byte{0}, ret...)
+	}
+	for idx := range ret {
+		ret[idx] = ^ret[idx]
+	}
+
+	return ret
+}
+
 // EncodePubKey returns a byte slice of the encoded pubKey input, using a pub key converter
 func EncodePubKey(pubKeyConverter core.PubkeyConverter, pubKey []byte) []byte {
 	return []byte(pubKeyConverter.Encode(pubKey))
@@ -90,3 +123,35 @@ func MetaChainShardAddress() []byte {
 	copy(ret, fmt.Sprintf("%d", core.MetachainShardId))
 	return ret
 }
+
+// SortedHashes returns the hashes of the given transactions, sorted ascending, so that they can be processed
+// in a deterministic order
+func SortedHashes(txs map[string]data.TransactionHandler) []string {
+	hashes := make([]string, 0, len(txs))
+	for hash := range txs {
+		hashes = append(hashes, hash)
+	}
+
+	sort.Strings(hashes)
+	return hashes
+}
+
+// GetOriginalTxHash returns the hash of the transaction which originated the given transaction, if it is a smart
+// contract result which provides it, otherwise the given hash
+func GetOriginalTxHash(tx data.TransactionHandler, hash string) string {
+	scr, isSCR := tx.(*smartContractResult.SmartContractResult)
+	if !isSCR || len(scr.GetOriginalTxHash()) == 0 {
+		return hash
+	}
+
+	return string(scr.GetOriginalTxHash())
+}
+
+// GetTopic returns the topic found at the given index, or nil if there are not enough topics
+func GetTopic(topics [][]byte, index int) []byte {
+	if index >= len(topics) {
+		return nil
+	}
+
+	return topics[index]
+}
@@ -7,6 +7,9 @@ import (
 	"github.com/ElrondNetwork/covalent-indexer-go/process/utility"
 	"github.com/ElrondNetwork/covalent-indexer-go/schema"
 	"github.com/ElrondNetwork/covalent-indexer-go/testscommon"
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/smartContractResult"
+	"github.com/ElrondNetwork/elrond-go-core/data/transaction"
 	"github.com/stretchr/testify/require"
 )
 
@@ -59,11 +62,68 @@ func TestGetBytes(t *testing.T) {
 	require.Equal(t, []byte{0xa}, utility.GetBytes(x))
 }
 
+func TestGetSignedBytes(t *testing.T) {
+	tests := []struct {
+		value    *big.Int
+		expected []byte
+	}{
+		{value: nil, expected: []byte{}},
+		{value: big.NewInt(0), expected: []byte{}},
+		{value: big.NewInt(1), expected: []byte{0x01}},
+		{value: big.NewInt(127), expected: []byte{0x7f}},
+		{value: big.NewInt(128), expected: []byte{0x00, 0x80}},
+		{value: big.NewInt(-1), expected: []byte{0xff}},
+		{value: big.NewInt(-128), expected: []byte{0x80}},
+		{value: big.NewInt(-129), expected: []byte{0xff, 0x7f}},
+		{value: big.NewInt(-256), expected: []byte{0xff, 0x00}},
+	}
+
+	for _, currTest := range tests {
+		require.Equal(t, currTest.expected, utility.GetSignedBytes(currTest.value), currTest.value.String())
+	}
+}
+
+func TestSortedHashes(t *testing.T) {
+	txs := map[string]data.TransactionHandler{
+		"hash3": &transaction.Transaction{},
+		"hash1": &transaction.Transaction{},
+		"hash2": &transaction.Transaction{},
+	}
+
+	require.Equal(t, []string{"hash1", "hash2", "hash3"}, utility.SortedHashes(txs))
+	require.Equal(t, []string{}, utility.SortedHashes(nil))
+}
+
+func TestGetOriginalTxHash(t *testing.T) {
+	require.Equal(t, "tx hash", utility.GetOriginalTxHash(&transaction.Transaction{}, "tx hash"))
+	require.Equal(t, "tx hash", utility.GetOriginalTxHash(nil, "tx hash"))
+	require.Equal(t, "scr hash", utility.GetOriginalTxHash(&smartContractResult.SmartContractResult{}, "scr hash"))
+	require.Equal(t, "original tx hash", utility.GetOriginalTxHash(
+		&smartContractResult.SmartContractResult{OriginalTxHash: []byte("original tx hash")}, "scr hash"))
+}
+
+func TestGetTopic(t *testing.T) {
+	topics := [][]byte{[]byte("topic0"), []byte("topic1")}
+
+	require.Equal(t, []byte("topic1"), utility.GetTopic(topics, 1))
+	require.Nil(t, utility.GetTopic(topics, 2))
+	require.Nil(t, utility.GetTopic(nil, 0))
+}
+
 func TestEncodeDecode(t *testing.T) {
 	account := &schema.AccountBalanceUpdate{
-		Address: testscommon.GenerateRandomFixedBytes(62),
-		Balance: big.NewInt(1000).Bytes(),
-		Nonce:   444,
+		Address:         testscommon.GenerateRandomFixedBytes(62),
+		Balance:         big.NewInt(1000).Bytes(),
+		Nonce:           444,
+		UserName:        []byte("alice"),
+		CodeMetadata:    []byte{},
+		DeveloperReward: big.NewInt(0).Bytes(),
+		PreviousBalance: big.NewInt(1500).Bytes(),
+		Delta:           utility.GetSignedBytes(big.NewInt(-500)),
+		PreviousNonce:   int64(443),
+		TokenBalanceUpdates: []*schema.TokenBalanceUpdate{
+			{Identifier: []byte("TKN-abcdef"), Nonce: 1, Balance: big.NewInt(10).Bytes()},
+		},
 	}
 
 	buffer, err := utility.Encode(account)
@@ -154,6 +214,7 @@ func TestEncode_Transaction(t *testing.T) {
 func TestEncode_SCR(t *testing.T) {
 	scRes := schema.SCResult{
 		Hash:           testscommon.GenerateRandomFixedBytes(32),
+		BlockHash:      testscommon.GenerateRandomFixedBytes(32),
 		Sender:         testscommon.GenerateRandomFixedBytes(62),
 		Receiver:       testscommon.GenerateRandomFixedBytes(62),
 		PrevTxHash:     testscommon.GenerateRandomFixedBytes(32),
@@ -167,6 +228,11 @@ func TestEncode_SCR(t *testing.T) {
 	_, err = utility.Encode(&scResNilHash)
 	require.NotNil(t, err)
 
+	scResNilBlockHash := scRes
+	scResNilBlockHash.BlockHash = nil
+	_, err = utility.Encode(&scResNilBlockHash)
+	require.NotNil(t, err)
+
 	scResNilSender := scRes
 	scResNilSender.Sender = nil
 	_, err = utility.Encode(&scResNilSender)
@@ -190,9 +256,10 @@ func TestEncode_SCR(t *testing.T) {
 
 func TestEncode_Receipt(t *testing.T) {
 	receipt := schema.Receipt{
-		Hash:   testscommon.GenerateRandomFixedBytes(32),
-		Sender: testscommon.GenerateRandomFixedBytes(62),
-		TxHash: testscommon.GenerateRandomFixedBytes(32),
+		Hash:      testscommon.GenerateRandomFixedBytes(32),
+		BlockHash: testscommon.GenerateRandomFixedBytes(32),
+		Sender:    testscommon.GenerateRandomFixedBytes(62),
+		TxHash:    testscommon.GenerateRandomFixedBytes(32),
 	}
 
 	_, err := utility.Encode(&receipt)
@@ -203,6 +270,11 @@ func TestEncode_Receipt(t *testing.T) {
 	_, err = utility.Encode(&receiptNilHash)
 	require.NotNil(t, err)
 
+	receiptNilBlockHash := receipt
+	receiptNilBlockHash.BlockHash = nil
+	_, err = utility.Encode(&receiptNilBlockHash)
+	require.NotNil(t, err)
+
 	receiptNilSender := receipt
 	receiptNilSender.Sender = nil
 	_, err = utility.Encode(&receiptNilSender)
@@ -222,6 +294,19 @@ func TestEncode_LogAndEvent(t *testing.T) {
 	event := schema.Event{}
 	_, err = utility.Encode(&event)
 	require.Nil(t, err)
+
+	event.NFTEvent = &schema.NFTEvent{
+		Type:     "create",
+		Token:    []byte("NFT-abcdef"),
+		Nonce:    1,
+		Quantity: big.NewInt(1).Bytes(),
+		Metadata: &schema.NFTMetadata{
+			Name: []byte("name"),
+			URIs: [][]byte{[]byte("uri")},
+		},
+	}
+	_, err = utility.Encode(&event)
+	require.Nil(t, err)
 }
 
 func TestEncode_AccountBalanceUpdate(t *testing.T) {
@@ -237,6 +322,27 @@ func TestEncode_AccountBalanceUpdate(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestEncode_TokenTransfer(t *testing.T) {
+	transfer := schema.TokenTransfer{
+		TxHash:     testscommon.GenerateRandomFixedBytes(32),
+		Identifier: []byte("TKN-abcdef"),
+		Amount:     big.NewInt(10).Bytes(),
+		Sender:     testscommon.GenerateRandomFixedBytes(62),
+		Receiver:   testscommon.GenerateRandomFixedBytes(62),
+	}
+	_, err := utility.Encode(&transfer)
+	require.Nil(t, err)
+
+	transfer.Decimals = int32(18)
+	buffer, err := utility.Encode(&transfer)
+	require.Nil(t, err)
+
+	decodedTransfer := &schema.TokenTransfer{}
+	err = utility.Decode(decodedTransfer, buffer)
+	require.Nil(t, err)
+	require.Equal(t, int32(18), decodedTransfer.Decimals)
+}
+
 func TestEncode_BlockResult(t *testing.T) {
 	block := schema.Block{
 		Hash:          testscommon.GenerateRandomFixedBytes(32),
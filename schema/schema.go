@@ -3,22 +3,36 @@ package schema
 import "github.com/elodina/go-avro"
 
 type BlockResult struct {
-	Block        *Block
-	Transactions []*Transaction
-	SCResults    []*SCResult
-	Receipts     []*Receipt
-	Logs         []*Log
-	StateChanges []*AccountBalanceUpdate
+	Block               *Block
+	Transactions        []*Transaction
+	SCResults           []*SCResult
+	Receipts            []*Receipt
+	Logs                []*Log
+	StateChanges        []*AccountBalanceUpdate
+	ValidatorsRating    []*ValidatorRating
+	TokenTransfers      []*TokenTransfer
+	PeerChanges         []*PeerChange
+	ContractEvents      []*ContractEvent
+	CallTrees           []*CallTree
+	StakingEvents       []*StakingEvent
+	TokenRegistryEvents []*TokenRegistryEvent
 }
 
 func NewBlockResult() *BlockResult {
 	return &BlockResult{
-		Block:        NewBlock(),
-		Transactions: make([]*Transaction, 0),
-		SCResults:    make([]*SCResult, 0),
-		Receipts:     make([]*Receipt, 0),
-		Logs:         make([]*Log, 0),
-		StateChanges: make([]*AccountBalanceUpdate, 0),
+		Block:               NewBlock(),
+		Transactions:        make([]*Transaction, 0),
+		SCResults:           make([]*SCResult, 0),
+		Receipts:            make([]*Receipt, 0),
+		Logs:                make([]*Log, 0),
+		StateChanges:        make([]*AccountBalanceUpdate, 0),
+		ValidatorsRating:    make([]*ValidatorRating, 0),
+		TokenTransfers:      make([]*TokenTransfer, 0),
+		PeerChanges:         make([]*PeerChange, 0),
+		ContractEvents:      make([]*ContractEvent, 0),
+		CallTrees:           make([]*CallTree, 0),
+		StakingEvents:       make([]*StakingEvent, 0),
+		TokenRegistryEvents: make([]*TokenRegistryEvent, 0),
 	}
 }
 
@@ -49,6 +63,17 @@ type Block struct {
 	DeveloperFees         []byte
 	EpochStartBlock       bool
 	EpochStartInfo        *EpochStartInfo
+	ChainID               []byte
+	HeaderVersion         int32
+	SoftwareVersion       []byte
+	RandSeed              []byte
+	PrevRandSeed          []byte
+	LeaderSignature       []byte
+	Signature             []byte
+	ReceiptsHash          []byte
+	EpochStartMetaHash    []byte
+	NotarizedShardHeaders []*NotarizedShardHeader
+	ScheduledData         *ScheduledData
 }
 
 func NewBlock() *Block {
@@ -59,6 +84,12 @@ func NewBlock() *Block {
 		StateRootHash:   make([]byte, 32),
 		AccumulatedFees: []byte{},
 		DeveloperFees:   []byte{},
+		ChainID:         []byte{},
+		SoftwareVersion: []byte{},
+		RandSeed:        []byte{},
+		PrevRandSeed:    []byte{},
+		LeaderSignature: []byte{},
+		Signature:       []byte{},
 	}
 }
 
@@ -71,6 +102,7 @@ func (o *Block) Schema() avro.Schema {
 
 type MiniBlock struct {
 	Hash            []byte
+	Index           int32
 	SenderShardID   int32
 	ReceiverShardID int32
 	Type            int32
@@ -99,18 +131,15 @@ type EpochStartInfo struct {
 	RewardsPerBlock                  []byte
 	RewardsForProtocolSustainability []byte
 	NodePrice                        []byte
-	PrevEpochStartRound              int32
+	PrevEpochStartRound              interface{}
 	PrevEpochStartHash               []byte
+	LastFinalizedHeaders             []*EpochStartShardData
+	EpochStartMetaHash               []byte
 }
 
 func NewEpochStartInfo() *EpochStartInfo {
 	return &EpochStartInfo{
-		TotalSupply:                      []byte{},
-		TotalToDistribute:                []byte{},
-		TotalNewlyMinted:                 []byte{},
-		RewardsPerBlock:                  []byte{},
-		RewardsForProtocolSustainability: []byte{},
-		NodePrice:                        []byte{},
+		LastFinalizedHeaders: make([]*EpochStartShardData, 0),
 	}
 }
 
@@ -121,10 +150,111 @@ func (o *EpochStartInfo) Schema() avro.Schema {
 	return _EpochStartInfo_schema
 }
 
+type EpochStartShardData struct {
+	ShardID                 int32
+	Epoch                   int32
+	Round                   int64
+	Nonce                   int64
+	HeaderHash              []byte
+	RootHash                []byte
+	FirstPendingMetaBlock   []byte
+	LastFinishedMetaBlock   []byte
+	PendingMiniBlockHeaders []*MiniBlockHeader
+}
+
+func NewEpochStartShardData() *EpochStartShardData {
+	return &EpochStartShardData{
+		HeaderHash:              make([]byte, 32),
+		RootHash:                make([]byte, 32),
+		PendingMiniBlockHeaders: make([]*MiniBlockHeader, 0),
+	}
+}
+
+func (o *EpochStartShardData) Schema() avro.Schema {
+	if _EpochStartShardData_schema_err != nil {
+		panic(_EpochStartShardData_schema_err)
+	}
+	return _EpochStartShardData_schema
+}
+
+type MiniBlockHeader struct {
+	Hash            []byte
+	SenderShardID   int32
+	ReceiverShardID int32
+	TxCount         int32
+	Type            int32
+}
+
+func NewMiniBlockHeader() *MiniBlockHeader {
+	return &MiniBlockHeader{
+		Hash: make([]byte, 32),
+	}
+}
+
+func (o *MiniBlockHeader) Schema() avro.Schema {
+	if _MiniBlockHeader_schema_err != nil {
+		panic(_MiniBlockHeader_schema_err)
+	}
+	return _MiniBlockHeader_schema
+}
+
+type NotarizedShardHeader struct {
+	ShardID          int32
+	Nonce            int64
+	Round            int64
+	HeaderHash       []byte
+	PrevHash         []byte
+	TxCount          int32
+	AccumulatedFees  []byte
+	DeveloperFees    []byte
+	MiniBlockHeaders []*MiniBlockHeader
+}
+
+func NewNotarizedShardHeader() *NotarizedShardHeader {
+	return &NotarizedShardHeader{
+		HeaderHash:       make([]byte, 32),
+		AccumulatedFees:  []byte{},
+		DeveloperFees:    []byte{},
+		MiniBlockHeaders: make([]*MiniBlockHeader, 0),
+	}
+}
+
+func (o *NotarizedShardHeader) Schema() avro.Schema {
+	if _NotarizedShardHeader_schema_err != nil {
+		panic(_NotarizedShardHeader_schema_err)
+	}
+	return _NotarizedShardHeader_schema
+}
+
+type ScheduledData struct {
+	RootHash        []byte
+	AccumulatedFees []byte
+	DeveloperFees   []byte
+	GasProvided     int64
+	GasPenalized    int64
+	GasRefunded     int64
+}
+
+func NewScheduledData() *ScheduledData {
+	return &ScheduledData{
+		AccumulatedFees: []byte{},
+		DeveloperFees:   []byte{},
+	}
+}
+
+func (o *ScheduledData) Schema() avro.Schema {
+	if _ScheduledData_schema_err != nil {
+		panic(_ScheduledData_schema_err)
+	}
+	return _ScheduledData_schema
+}
+
 type Transaction struct {
 	Hash             []byte
 	MiniBlockHash    []byte
 	BlockHash        []byte
+	MiniBlockIndex   int32
+	IndexInMiniBlock int32
 	Nonce            int64
 	Round            int64
 	Value            []byte
@@ -135,10 +265,20 @@ type Transaction struct {
 	GasPrice         int64
 	GasLimit         int64
 	Data             []byte
+	Function         string
+	Arguments        [][]byte
+	Operation        string
 	Signature        []byte
 	Timestamp        int64
 	SenderUserName   []byte
 	ReceiverUserName []byte
+	Status           string
+	GasUsed          interface{}
+	Fee              []byte
+	InitialPaidFee   []byte
+	MoveBalanceGas   interface{}
+	ProcessingFee    []byte
+	InnerTransaction *InnerTransaction
 }
 
 func NewTransaction() *Transaction {
@@ -150,6 +290,7 @@ func NewTransaction() *Transaction {
 		Receiver:         make([]byte, 62),
 		Sender:           make([]byte, 62),
 		Data:             []byte{},
+		Arguments:        make([][]byte, 0),
 		SenderUserName:   []byte{},
 		ReceiverUserName: []byte{},
 	}
@@ -162,39 +303,85 @@ func (o *Transaction) Schema() avro.Schema {
 	return _Transaction_schema
 }
 
-type SCResult struct {
-	Hash           []byte
+type InnerTransaction struct {
+	Version        int32
 	Nonce          int64
-	GasLimit       int64
-	GasPrice       int64
-	Value          []byte
 	Sender         []byte
 	Receiver       []byte
-	RelayerAddr    []byte
-	RelayedValue   []byte
-	Code           []byte
+	Value          []byte
+	GasPrice       int64
+	GasLimit       interface{}
 	Data           []byte
-	PrevTxHash     []byte
-	OriginalTxHash []byte
-	CallType       int32
-	CodeMetadata   []byte
-	ReturnMessage  []byte
-	Timestamp      int64
+	Signature      []byte
+	SCResultHashes [][]byte
+}
+
+func NewInnerTransaction() *InnerTransaction {
+	return &InnerTransaction{
+		Sender:         make([]byte, 62),
+		Receiver:       make([]byte, 62),
+		Value:          []byte{},
+		Data:           []byte{},
+		Signature:      []byte{},
+		SCResultHashes: make([][]byte, 0),
+	}
+}
+
+func (o *InnerTransaction) Schema() avro.Schema {
+	if _InnerTransaction_schema_err != nil {
+		panic(_InnerTransaction_schema_err)
+	}
+	return _InnerTransaction_schema
+}
+
+type SCResult struct {
+	Hash             []byte
+	MiniBlockHash    []byte
+	BlockHash        []byte
+	MiniBlockIndex   int32
+	IndexInMiniBlock int32
+	Nonce            int64
+	GasLimit         int64
+	GasPrice         int64
+	Value            []byte
+	Sender           []byte
+	Receiver         []byte
+	ReceiverShard    int32
+	SenderShard      int32
+	RelayerAddr      []byte
+	RelayedValue     []byte
+	Code             []byte
+	Data             []byte
+	Function         string
+	Arguments        [][]byte
+	Operation        string
+	PrevTxHash       []byte
+	OriginalTxHash   []byte
+	CallType         int32
+	CodeMetadata     []byte
+	ReturnMessage    []byte
+	ReturnCode       string
+	ReturnData       [][]byte
+	IsRefund         bool
+	Timestamp        int64
 }
 
 func NewSCResult() *SCResult {
 	return &SCResult{
 		Hash:           make([]byte, 32),
+		BlockHash:      make([]byte, 32),
 		Value:          []byte{},
 		Sender:         make([]byte, 62),
 		Receiver:       make([]byte, 62),
 		RelayedValue:   []byte{},
 		Code:           []byte{},
 		Data:           []byte{},
+		Arguments:      make([][]byte, 0),
 		PrevTxHash:     make([]byte, 32),
 		OriginalTxHash: make([]byte, 32),
 		CodeMetadata:   []byte{},
 		ReturnMessage:  []byte{},
+		ReturnData:     make([][]byte, 0),
 	}
 }
 
@@ -206,21 +393,26 @@ func (o *SCResult) Schema() avro.Schema {
 }
 
 type Receipt struct {
-	Hash      []byte
-	Value     []byte
-	Sender    []byte
-	Data      []byte
-	TxHash    []byte
-	Timestamp int64
+	Hash          []byte
+	MiniBlockHash []byte
+	BlockHash     []byte
+	Value         []byte
+	Sender        []byte
+	ReceiverShard int32
+	SenderShard   int32
+	Data          []byte
+	TxHash        []byte
+	Timestamp     int64
 }
 
 func NewReceipt() *Receipt {
 	return &Receipt{
-		Hash:   make([]byte, 32),
-		Value:  []byte{},
-		Sender: make([]byte, 62),
-		Data:   []byte{},
-		TxHash: make([]byte, 32),
+		Hash:      make([]byte, 32),
+		BlockHash: make([]byte, 32),
+		Value:     []byte{},
+		Sender:    make([]byte, 62),
+		Data:      []byte{},
+		TxHash:    make([]byte, 32),
 	}
 }
 
@@ -232,15 +424,18 @@ func (o *Receipt) Schema() avro.Schema {
 }
 
 type Log struct {
-	ID      []byte
-	Address []byte
-	Events  []*Event
+	ID        []byte
+	BlockHash []byte
+	Index     int32
+	Address   []byte
+	Events    []*Event
 }
 
 func NewLog() *Log {
 	return &Log{
-		ID:     make([]byte, 32),
-		Events: make([]*Event, 0),
+		ID:        make([]byte, 32),
+		BlockHash: make([]byte, 32),
+		Events:    make([]*Event, 0),
 	}
 }
 
@@ -256,6 +451,8 @@ type Event struct {
 	Identifier []byte
 	Topics     [][]byte
 	Data       []byte
+	Index      int32
+	NFTEvent   *NFTEvent
 }
 
 func NewEvent() *Event {
@@ -273,16 +470,79 @@ func (o *Event) Schema() avro.Schema {
 	return _Event_schema
 }
 
+type NFTEvent struct {
+	Type       string
+	Token      []byte
+	Nonce      int64
+	Quantity   []byte
+	Metadata   *NFTMetadata
+	Attributes []byte
+}
+
+func NewNFTEvent() *NFTEvent {
+	return &NFTEvent{
+		Token:    []byte{},
+		Quantity: []byte{},
+	}
+}
+
+func (o *NFTEvent) Schema() avro.Schema {
+	if _NFTEvent_schema_err != nil {
+		panic(_NFTEvent_schema_err)
+	}
+	return _NFTEvent_schema
+}
+
+type NFTMetadata struct {
+	Name       []byte
+	Creator    []byte
+	Royalties  int32
+	Hash       []byte
+	Attributes []byte
+	URIs       [][]byte
+}
+
+func NewNFTMetadata() *NFTMetadata {
+	return &NFTMetadata{
+		Name:       []byte{},
+		Hash:       []byte{},
+		Attributes: []byte{},
+		URIs:       make([][]byte, 0),
+	}
+}
+
+func (o *NFTMetadata) Schema() avro.Schema {
+	if _NFTMetadata_schema_err != nil {
+		panic(_NFTMetadata_schema_err)
+	}
+	return _NFTMetadata_schema
+}
+
 type AccountBalanceUpdate struct {
-	Address []byte
-	Balance []byte
-	Nonce   int64
+	Address             []byte
+	Balance             []byte
+	Nonce               int64
+	UserName            []byte
+	OwnerAddress        []byte
+	CodeHash            []byte
+	RootHash            []byte
+	CodeMetadata        []byte
+	DeveloperReward     []byte
+	IsSmartContract     bool
+	PreviousBalance     []byte
+	Delta               []byte
+	PreviousNonce       interface{}
+	TokenBalanceUpdates []*TokenBalanceUpdate
 }
 
 func NewAccountBalanceUpdate() *AccountBalanceUpdate {
 	return &AccountBalanceUpdate{
-		Address: make([]byte, 62),
-		Balance: []byte{},
+		Address:             make([]byte, 62),
+		Balance:             []byte{},
+		UserName:            []byte{},
+		CodeMetadata:        []byte{},
+		DeveloperReward:     []byte{},
+		TokenBalanceUpdates: make([]*TokenBalanceUpdate, 0),
 	}
 }
 
@@ -293,6 +553,260 @@ func (o *AccountBalanceUpdate) Schema() avro.Schema {
 	return _AccountBalanceUpdate_schema
 }
 
+type TokenBalanceUpdate struct {
+	Identifier []byte
+	Nonce      int64
+	Balance    []byte
+}
+
+func NewTokenBalanceUpdate() *TokenBalanceUpdate {
+	return &TokenBalanceUpdate{
+		Identifier: []byte{},
+		Balance:    []byte{},
+	}
+}
+
+func (o *TokenBalanceUpdate) Schema() avro.Schema {
+	if _TokenBalanceUpdate_schema_err != nil {
+		panic(_TokenBalanceUpdate_schema_err)
+	}
+	return _TokenBalanceUpdate_schema
+}
+
+type ValidatorRating struct {
+	PublicKey []byte
+	Rating    float32
+	Epoch     int32
+	ShardID   int32
+}
+
+func NewValidatorRating() *ValidatorRating {
+	return &ValidatorRating{
+		PublicKey: []byte{},
+	}
+}
+
+func (o *ValidatorRating) Schema() avro.Schema {
+	if _ValidatorRating_schema_err != nil {
+		panic(_ValidatorRating_schema_err)
+	}
+	return _ValidatorRating_schema
+}
+
+type TokenTransfer struct {
+	TxHash     []byte
+	Identifier []byte
+	Nonce      int64
+	Amount     []byte
+	Sender     []byte
+	Receiver   []byte
+	Decimals   interface{}
+}
+
+func NewTokenTransfer() *TokenTransfer {
+	return &TokenTransfer{
+		TxHash:     make([]byte, 32),
+		Identifier: []byte{},
+		Amount:     []byte{},
+		Sender:     make([]byte, 62),
+		Receiver:   make([]byte, 62),
+	}
+}
+
+func (o *TokenTransfer) Schema() avro.Schema {
+	if _TokenTransfer_schema_err != nil {
+		panic(_TokenTransfer_schema_err)
+	}
+	return _TokenTransfer_schema
+}
+
+type PeerChange struct {
+	BlockHash        []byte
+	MiniBlockHash    []byte
+	MiniBlockIndex   int32
+	IndexInMiniBlock int32
+	Epoch            int32
+	PublicKey        []byte
+	ShardID          int32
+	List             string
+	Index            int32
+	TempRating       int32
+}
+
+func NewPeerChange() *PeerChange {
+	return &PeerChange{
+		BlockHash:     make([]byte, 32),
+		MiniBlockHash: make([]byte, 32),
+		PublicKey:     []byte{},
+	}
+}
+
+func (o *PeerChange) Schema() avro.Schema {
+	if _PeerChange_schema_err != nil {
+		panic(_PeerChange_schema_err)
+	}
+	return _PeerChange_schema
+}
+
+type ContractEvent struct {
+	TxHash          []byte
+	Type            string
+	ContractAddress []byte
+	Owner           []byte
+	PreviousOwner   []byte
+	CodeHash        []byte
+	CodeMetadata    *CodeMetadata
+}
+
+func NewContractEvent() *ContractEvent {
+	return &ContractEvent{
+		TxHash:          make([]byte, 32),
+		ContractAddress: make([]byte, 62),
+		Owner:           make([]byte, 62),
+	}
+}
+
+func (o *ContractEvent) Schema() avro.Schema {
+	if _ContractEvent_schema_err != nil {
+		panic(_ContractEvent_schema_err)
+	}
+	return _ContractEvent_schema
+}
+
+type CodeMetadata struct {
+	Upgradeable bool
+	Readable    bool
+	Payable     bool
+	PayableBySC bool
+}
+
+func NewCodeMetadata() *CodeMetadata {
+	return &CodeMetadata{}
+}
+
+func (o *CodeMetadata) Schema() avro.Schema {
+	if _CodeMetadata_schema_err != nil {
+		panic(_CodeMetadata_schema_err)
+	}
+	return _CodeMetadata_schema
+}
+
+type CallTree struct {
+	OriginalTxHash []byte
+	IsPartial      bool
+	Nodes          []*CallTreeNode
+}
+
+func NewCallTree() *CallTree {
+	return &CallTree{
+		OriginalTxHash: make([]byte, 32),
+		Nodes:          make([]*CallTreeNode, 0),
+	}
+}
+
+func (o *CallTree) Schema() avro.Schema {
+	if _CallTree_schema_err != nil {
+		panic(_CallTree_schema_err)
+	}
+	return _CallTree_schema
+}
+
+type CallTreeNode struct {
+	Hash         []byte
+	ParentHash   []byte
+	Depth        int32
+	CallType     int32
+	CallbackHash []byte
+}
+
+func NewCallTreeNode() *CallTreeNode {
+	return &CallTreeNode{
+		Hash:       make([]byte, 32),
+		ParentHash: make([]byte, 32),
+	}
+}
+
+func (o *CallTreeNode) Schema() avro.Schema {
+	if _CallTreeNode_schema_err != nil {
+		panic(_CallTreeNode_schema_err)
+	}
+	return _CallTreeNode_schema
+}
+
+type StakingEvent struct {
+	TxHash             []byte
+	Type               string
+	Caller             []byte
+	Contract           []byte
+	DelegationContract []byte
+	Amount             []byte
+}
+
+func NewStakingEvent() *StakingEvent {
+	return &StakingEvent{
+		TxHash:   make([]byte, 32),
+		Caller:   make([]byte, 62),
+		Contract: make([]byte, 62),
+	}
+}
+
+func (o *StakingEvent) Schema() avro.Schema {
+	if _StakingEvent_schema_err != nil {
+		panic(_StakingEvent_schema_err)
+	}
+	return _StakingEvent_schema
+}
+
+type TokenRegistryEvent struct {
+	TxHash     []byte
+	Type       string
+	Caller     []byte
+	Identifier []byte
+	Issue      *TokenIssue
+	Owner      []byte
+	Address    []byte
+	Roles      []string
+}
+
+func NewTokenRegistryEvent() *TokenRegistryEvent {
+	return &TokenRegistryEvent{
+		TxHash:     make([]byte, 32),
+		Caller:     make([]byte, 62),
+		Identifier: []byte{},
+		Roles:      make([]string, 0),
+	}
+}
+
+func (o *TokenRegistryEvent) Schema() avro.Schema {
+	if _TokenRegistryEvent_schema_err != nil {
+		panic(_TokenRegistryEvent_schema_err)
+	}
+	return _TokenRegistryEvent_schema
+}
+
+type TokenIssue struct {
+	Name          []byte
+	Ticker        []byte
+	TokenType     string
+	Decimals      int32
+	InitialSupply []byte
+}
+
+func NewTokenIssue() *TokenIssue {
+	return &TokenIssue{
+		Name:          []byte{},
+		Ticker:        []byte{},
+		InitialSupply: []byte{},
+	}
+}
+
+func (o *TokenIssue) Schema() avro.Schema {
+	if _TokenIssue_schema_err != nil {
+		panic(_TokenIssue_schema_err)
+	}
+	return _TokenIssue_schema
+}
+
 // Generated by codegen. Please do not modify.
 var _BlockResult_schema, _BlockResult_schema_err = avro.ParseSchema(`{
     "type": "record",
@@ -344,6 +858,10 @@ var _BlockResult_schema, _BlockResult_schema_err = avro.ParseSchema(`{
                                                 "name": "hash"
                                             }
                                         },
+                                        {
+                                            "name": "Index",
+                                            "type": "int"
+                                        },
                                         {
                                             "name": "SenderShardID",
                                             "type": "int"
@@ -461,31 +979,59 @@ var _BlockResult_schema, _BlockResult_schema_err = avro.ParseSchema(`{
                                 "fields": [
                                     {
                                         "name": "TotalSupply",
-                                        "type": "bytes"
+                                        "default": null,
+                                        "type": [
+                                            "null",
+                                            "bytes"
+                                        ]
                                     },
                                     {
                                         "name": "TotalToDistribute",
-                                        "type": "bytes"
+                                        "default": null,
+                                        "type": [
+                                            "null",
+                                            "bytes"
+                                        ]
                                     },
                                     {
                                         "name": "TotalNewlyMinted",
-                                        "type": "bytes"
+                                        "default": null,
+                                        "type": [
+                                            "null",
+                                            "bytes"
+                                        ]
                                     },
                                     {
                                         "name": "RewardsPerBlock",
-                                        "type": "bytes"
+                                        "default": null,
+                                        "type": [
+                                            "null",
+                                            "bytes"
+                                        ]
                                     },
                                     {
                                         "name": "RewardsForProtocolSustainability",
-                                        "type": "bytes"
+                                        "default": null,
+                                        "type": [
+                                            "null",
+                                            "bytes"
+                                        ]
                                     },
                                     {
                                         "name": "NodePrice",
-                                        "type": "bytes"
+                                        "default": null,
+                                        "type": [
+                                            "null",
+                                            "bytes"
+                                        ]
                                     },
                                     {
                                         "name": "PrevEpochStartRound",
-                                        "type": "int"
+                                        "default": null,
+                                        "type": [
+                                            "null",
+                                            "int"
+                                        ]
                                     },
                                     {
                                         "name": "PrevEpochStartHash",
@@ -498,28 +1044,308 @@ var _BlockResult_schema, _BlockResult_schema_err = avro.ParseSchema(`{
                                                 "name": "hash"
                                             }
                                         ]
+                                    },
+                                    {
+                                        "name": "LastFinalizedHeaders",
+                                        "type": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "record",
+                                                "name": "EpochStartShardData",
+                                                "fields": [
+                                                    {
+                                                        "name": "ShardID",
+                                                        "type": "int"
+                                                    },
+                                                    {
+                                                        "name": "Epoch",
+                                                        "type": "int"
+                                                    },
+                                                    {
+                                                        "name": "Round",
+                                                        "type": "long"
+                                                    },
+                                                    {
+                                                        "name": "Nonce",
+                                                        "type": "long"
+                                                    },
+                                                    {
+                                                        "name": "HeaderHash",
+                                                        "type": {
+                                                            "type": "fixed",
+                                                            "size": 32,
+                                                            "name": "hash"
+                                                        }
+                                                    },
+                                                    {
+                                                        "name": "RootHash",
+                                                        "type": {
+                                                            "type": "fixed",
+                                                            "size": 32,
+                                                            "name": "hash"
+                                                        }
+                                                    },
+                                                    {
+                                                        "name": "FirstPendingMetaBlock",
+                                                        "default": null,
+                                                        "type": [
+                                                            "null",
+                                                            {
+                                                                "type": "fixed",
+                                                                "size": 32,
+                                                                "name": "hash"
+                                                            }
+                                                        ]
+                                                    },
+                                                    {
+                                                        "name": "LastFinishedMetaBlock",
+                                                        "default": null,
+                                                        "type": [
+                                                            "null",
+                                                            {
+                                                                "type": "fixed",
+                                                                "size": 32,
+                                                                "name": "hash"
+                                                            }
+                                                        ]
+                                                    },
+                                                    {
+                                                        "name": "PendingMiniBlockHeaders",
+                                                        "type": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "type": "record",
+                                                                "name": "MiniBlockHeader",
+                                                                "fields": [
+                                                                    {
+                                                                        "name": "Hash",
+                                                                        "type": {
+                                                                            "type": "fixed",
+                                                                            "size": 32,
+                                                                            "name": "hash"
+                                                                        }
+                                                                    },
+                                                                    {
+                                                                        "name": "SenderShardID",
+                                                                        "type": "int"
+                                                                    },
+                                                                    {
+                                                                        "name": "ReceiverShardID",
+                                                                        "type": "int"
+                                                                    },
+                                                                    {
+                                                                        "name": "TxCount",
+                                                                        "type": "int"
+                                                                    },
+                                                                    {
+                                                                        "name": "Type",
+                                                                        "type": "int"
+                                                                    }
+                                                                ]
+                                                            }
+                                                        }
+                                                    }
+                                                ]
+                                            }
+                                        }
+                                    },
+                                    {
+                                        "name": "EpochStartMetaHash",
+                                        "default": null,
+                                        "type": [
+                                            "null",
+                                            {
+                                                "type": "fixed",
+                                                "size": 32,
+                                                "name": "hash"
+                                            }
+                                        ]
                                     }
                                 ]
                             }
                         ]
-                    }
-                ]
-            }
-        },
-        {
-            "name": "Transactions",
-            "type": {
-                "type": "array",
-                "items": {
-                    "type": "record",
-                    "name": "Transaction",
-                    "fields": [
-                        {
-                            "name": "Hash",
-                            "type": {
-                                "type": "fixed",
-                                "size": 32,
-                                "name": "hash"
+                    },
+                    {
+                        "name": "ChainID",
+                        "type": "bytes"
+                    },
+                    {
+                        "name": "HeaderVersion",
+                        "type": "int"
+                    },
+                    {
+                        "name": "SoftwareVersion",
+                        "type": "bytes"
+                    },
+                    {
+                        "name": "RandSeed",
+                        "type": "bytes"
+                    },
+                    {
+                        "name": "PrevRandSeed",
+                        "type": "bytes"
+                    },
+                    {
+                        "name": "LeaderSignature",
+                        "type": "bytes"
+                    },
+                    {
+                        "name": "Signature",
+                        "type": "bytes"
+                    },
+                    {
+                        "name": "ReceiptsHash",
+                        "default": null,
+                        "type": [
+                            "null",
+                            {
+                                "type": "fixed",
+                                "size": 32,
+                                "name": "hash"
+                            }
+                        ]
+                    },
+                    {
+                        "name": "EpochStartMetaHash",
+                        "default": null,
+                        "type": [
+                            "null",
+                            {
+                                "type": "fixed",
+                                "size": 32,
+                                "name": "hash"
+                            }
+                        ]
+                    },
+                    {
+                        "name": "NotarizedShardHeaders",
+                        "default": null,
+                        "type": [
+                            "null",
+                            {
+                                "type": "array",
+                                "items": {
+                                    "type": "record",
+                                    "name": "NotarizedShardHeader",
+                                    "fields": [
+                                        {
+                                            "name": "ShardID",
+                                            "type": "int"
+                                        },
+                                        {
+                                            "name": "Nonce",
+                                            "type": "long"
+                                        },
+                                        {
+                                            "name": "Round",
+                                            "type": "long"
+                                        },
+                                        {
+                                            "name": "HeaderHash",
+                                            "type": {
+                                                "type": "fixed",
+                                                "size": 32,
+                                                "name": "hash"
+                                            }
+                                        },
+                                        {
+                                            "name": "PrevHash",
+                                            "default": null,
+                                            "type": [
+                                                "null",
+                                                {
+                                                    "type": "fixed",
+                                                    "size": 32,
+                                                    "name": "hash"
+                                                }
+                                            ]
+                                        },
+                                        {
+                                            "name": "TxCount",
+                                            "type": "int"
+                                        },
+                                        {
+                                            "name": "AccumulatedFees",
+                                            "type": "bytes"
+                                        },
+                                        {
+                                            "name": "DeveloperFees",
+                                            "type": "bytes"
+                                        },
+                                        {
+                                            "name": "MiniBlockHeaders",
+                                            "type": {
+                                                "type": "array",
+                                                "items": "MiniBlockHeader"
+                                            }
+                                        }
+                                    ]
+                                }
+                            }
+                        ]
+                    },
+                    {
+                        "name": "ScheduledData",
+                        "default": null,
+                        "type": [
+                            "null",
+                            {
+                                "type": "record",
+                                "name": "ScheduledData",
+                                "fields": [
+                                    {
+                                        "name": "RootHash",
+                                        "default": null,
+                                        "type": [
+                                            "null",
+                                            {
+                                                "type": "fixed",
+                                                "size": 32,
+                                                "name": "hash"
+                                            }
+                                        ]
+                                    },
+                                    {
+                                        "name": "AccumulatedFees",
+                                        "type": "bytes"
+                                    },
+                                    {
+                                        "name": "DeveloperFees",
+                                        "type": "bytes"
+                                    },
+                                    {
+                                        "name": "GasProvided",
+                                        "type": "long"
+                                    },
+                                    {
+                                        "name": "GasPenalized",
+                                        "type": "long"
+                                    },
+                                    {
+                                        "name": "GasRefunded",
+                                        "type": "long"
+                                    }
+                                ]
+                            }
+                        ]
+                    }
+                ]
+            }
+        },
+        {
+            "name": "Transactions",
+            "type": {
+                "type": "array",
+                "items": {
+                    "type": "record",
+                    "name": "Transaction",
+                    "fields": [
+                        {
+                            "name": "Hash",
+                            "type": {
+                                "type": "fixed",
+                                "size": 32,
+                                "name": "hash"
                             }
                         },
                         {
@@ -538,6 +1364,14 @@ var _BlockResult_schema, _BlockResult_schema_err = avro.ParseSchema(`{
                                 "name": "hash"
                             }
                         },
+                        {
+                            "name": "MiniBlockIndex",
+                            "type": "int"
+                        },
+                        {
+                            "name": "IndexInMiniBlock",
+                            "type": "int"
+                        },
                         {
                             "name": "Nonce",
                             "type": "long"
@@ -586,6 +1420,21 @@ var _BlockResult_schema, _BlockResult_schema_err = avro.ParseSchema(`{
                             "name": "Data",
                             "type": "bytes"
                         },
+                        {
+                            "name": "Function",
+                            "type": "string"
+                        },
+                        {
+                            "name": "Arguments",
+                            "type": {
+                                "type": "array",
+                                "items": "bytes"
+                            }
+                        },
+                        {
+                            "name": "Operation",
+                            "type": "string"
+                        },
                         {
                             "name": "Signature",
                             "default": null,
@@ -609,6 +1458,122 @@ var _BlockResult_schema, _BlockResult_schema_err = avro.ParseSchema(`{
                         {
                             "name": "ReceiverUserName",
                             "type": "bytes"
+                        },
+                        {
+                            "name": "Status",
+                            "type": "string"
+                        },
+                        {
+                            "name": "GasUsed",
+                            "default": null,
+                            "type": [
+                                "null",
+                                "long"
+                            ]
+                        },
+                        {
+                            "name": "Fee",
+                            "default": null,
+                            "type": [
+                                "null",
+                                "bytes"
+                            ]
+                        },
+                        {
+                            "name": "InitialPaidFee",
+                            "default": null,
+                            "type": [
+                                "null",
+                                "bytes"
+                            ]
+                        },
+                        {
+                            "name": "MoveBalanceGas",
+                            "default": null,
+                            "type": [
+                                "null",
+                                "long"
+                            ]
+                        },
+                        {
+                            "name": "ProcessingFee",
+                            "default": null,
+                            "type": [
+                                "null",
+                                "bytes"
+                            ]
+                        },
+                        {
+                            "name": "InnerTransaction",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "record",
+                                    "name": "InnerTransaction",
+                                    "fields": [
+                                        {
+                                            "name": "Version",
+                                            "type": "int"
+                                        },
+                                        {
+                                            "name": "Nonce",
+                                            "type": "long"
+                                        },
+                                        {
+                                            "name": "Sender",
+                                            "type": {
+                                                "type": "fixed",
+                                                "size": 62,
+                                                "name": "address"
+                                            }
+                                        },
+                                        {
+                                            "name": "Receiver",
+                                            "type": {
+                                                "type": "fixed",
+                                                "size": 62,
+                                                "name": "address"
+                                            }
+                                        },
+                                        {
+                                            "name": "Value",
+                                            "type": "bytes"
+                                        },
+                                        {
+                                            "name": "GasPrice",
+                                            "type": "long"
+                                        },
+                                        {
+                                            "name": "GasLimit",
+                                            "default": null,
+                                            "type": [
+                                                "null",
+                                                "long"
+                                            ]
+                                        },
+                                        {
+                                            "name": "Data",
+                                            "type": "bytes"
+                                        },
+                                        {
+                                            "name": "Signature",
+                                            "type": "bytes"
+                                        },
+                                        {
+                                            "name": "SCResultHashes",
+                                            "type": {
+                                                "type": "array",
+                                                "items": {
+                                                    "type": "fixed",
+                                                    "size": 32,
+                                                    "name": "hash"
+                                                }
+                                            }
+                                        }
+                                    ]
+                                }
+                            ]
                         }
                     ]
                 }
@@ -631,13 +1596,41 @@ var _BlockResult_schema, _BlockResult_schema_err = avro.ParseSchema(`{
                             }
                         },
                         {
-                            "name": "Nonce",
-                            "type": "long"
-                        },
-                        {
-                            "name": "GasLimit",
-                            "type": "long"
-                        },
+                            "name": "MiniBlockHash",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "fixed",
+                                    "size": 32,
+                                    "name": "hash"
+                                }
+                            ]
+                        },
+                        {
+                            "name": "BlockHash",
+                            "type": {
+                                "type": "fixed",
+                                "size": 32,
+                                "name": "hash"
+                            }
+                        },
+                        {
+                            "name": "MiniBlockIndex",
+                            "type": "int"
+                        },
+                        {
+                            "name": "IndexInMiniBlock",
+                            "type": "int"
+                        },
+                        {
+                            "name": "Nonce",
+                            "type": "long"
+                        },
+                        {
+                            "name": "GasLimit",
+                            "type": "long"
+                        },
                         {
                             "name": "GasPrice",
                             "type": "long"
@@ -662,6 +1655,14 @@ var _BlockResult_schema, _BlockResult_schema_err = avro.ParseSchema(`{
                                 "name": "address"
                             }
                         },
+                        {
+                            "name": "ReceiverShard",
+                            "type": "int"
+                        },
+                        {
+                            "name": "SenderShard",
+                            "type": "int"
+                        },
                         {
                             "name": "RelayerAddr",
                             "default": null,
@@ -686,6 +1687,21 @@ var _BlockResult_schema, _BlockResult_schema_err = avro.ParseSchema(`{
                             "name": "Data",
                             "type": "bytes"
                         },
+                        {
+                            "name": "Function",
+                            "type": "string"
+                        },
+                        {
+                            "name": "Arguments",
+                            "type": {
+                                "type": "array",
+                                "items": "bytes"
+                            }
+                        },
+                        {
+                            "name": "Operation",
+                            "type": "string"
+                        },
                         {
                             "name": "PrevTxHash",
                             "type": {
@@ -714,6 +1730,21 @@ var _BlockResult_schema, _BlockResult_schema_err = avro.ParseSchema(`{
                             "name": "ReturnMessage",
                             "type": "bytes"
                         },
+                        {
+                            "name": "ReturnCode",
+                            "type": "string"
+                        },
+                        {
+                            "name": "ReturnData",
+                            "type": {
+                                "type": "array",
+                                "items": "bytes"
+                            }
+                        },
+                        {
+                            "name": "IsRefund",
+                            "type": "boolean"
+                        },
                         {
                             "name": "Timestamp",
                             "type": "long"
@@ -738,6 +1769,26 @@ var _BlockResult_schema, _BlockResult_schema_err = avro.ParseSchema(`{
                                 "name": "hash"
                             }
                         },
+                        {
+                            "name": "MiniBlockHash",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "fixed",
+                                    "size": 32,
+                                    "name": "hash"
+                                }
+                            ]
+                        },
+                        {
+                            "name": "BlockHash",
+                            "type": {
+                                "type": "fixed",
+                                "size": 32,
+                                "name": "hash"
+                            }
+                        },
                         {
                             "name": "Value",
                             "type": "bytes"
@@ -750,6 +1801,14 @@ var _BlockResult_schema, _BlockResult_schema_err = avro.ParseSchema(`{
                                 "name": "address"
                             }
                         },
+                        {
+                            "name": "ReceiverShard",
+                            "type": "int"
+                        },
+                        {
+                            "name": "SenderShard",
+                            "type": "int"
+                        },
                         {
                             "name": "Data",
                             "type": "bytes"
@@ -786,6 +1845,18 @@ var _BlockResult_schema, _BlockResult_schema_err = avro.ParseSchema(`{
                                 "name": "hash"
                             }
                         },
+                        {
+                            "name": "BlockHash",
+                            "type": {
+                                "type": "fixed",
+                                "size": 32,
+                                "name": "hash"
+                            }
+                        },
+                        {
+                            "name": "Index",
+                            "type": "int"
+                        },
                         {
                             "name": "Address",
                             "default": null,
@@ -832,6 +1903,95 @@ var _BlockResult_schema, _BlockResult_schema_err = avro.ParseSchema(`{
                                         {
                                             "name": "Data",
                                             "type": "bytes"
+                                        },
+                                        {
+                                            "name": "Index",
+                                            "type": "int"
+                                        },
+                                        {
+                                            "name": "NFTEvent",
+                                            "default": null,
+                                            "type": [
+                                                "null",
+                                                {
+                                                    "type": "record",
+                                                    "name": "NFTEvent",
+                                                    "fields": [
+                                                        {
+                                                            "name": "Type",
+                                                            "type": "string"
+                                                        },
+                                                        {
+                                                            "name": "Token",
+                                                            "type": "bytes"
+                                                        },
+                                                        {
+                                                            "name": "Nonce",
+                                                            "type": "long"
+                                                        },
+                                                        {
+                                                            "name": "Quantity",
+                                                            "type": "bytes"
+                                                        },
+                                                        {
+                                                            "name": "Metadata",
+                                                            "default": null,
+                                                            "type": [
+                                                                "null",
+                                                                {
+                                                                    "type": "record",
+                                                                    "name": "NFTMetadata",
+                                                                    "fields": [
+                                                                        {
+                                                                            "name": "Name",
+                                                                            "type": "bytes"
+                                                                        },
+                                                                        {
+                                                                            "name": "Creator",
+                                                                            "default": null,
+                                                                            "type": [
+                                                                                "null",
+                                                                                {
+                                                                                    "type": "fixed",
+                                                                                    "size": 62,
+                                                                                    "name": "address"
+                                                                                }
+                                                                            ]
+                                                                        },
+                                                                        {
+                                                                            "name": "Royalties",
+                                                                            "type": "int"
+                                                                        },
+                                                                        {
+                                                                            "name": "Hash",
+                                                                            "type": "bytes"
+                                                                        },
+                                                                        {
+                                                                            "name": "Attributes",
+                                                                            "type": "bytes"
+                                                                        },
+                                                                        {
+                                                                            "name": "URIs",
+                                                                            "type": {
+                                                                                "type": "array",
+                                                                                "items": "bytes"
+                                                                            }
+                                                                        }
+                                                                    ]
+                                                                }
+                                                            ]
+                                                        },
+                                                        {
+                                                            "name": "Attributes",
+                                                            "default": null,
+                                                            "type": [
+                                                                "null",
+                                                                "bytes"
+                                                            ]
+                                                        }
+                                                    ]
+                                                }
+                                            ]
                                         }
                                     ]
                                 }
@@ -864,126 +2024,2709 @@ var _BlockResult_schema, _BlockResult_schema_err = avro.ParseSchema(`{
                         {
                             "name": "Nonce",
                             "type": "long"
+                        },
+                        {
+                            "name": "UserName",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "OwnerAddress",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "fixed",
+                                    "size": 62,
+                                    "name": "address"
+                                }
+                            ]
+                        },
+                        {
+                            "name": "CodeHash",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "fixed",
+                                    "size": 32,
+                                    "name": "hash"
+                                }
+                            ]
+                        },
+                        {
+                            "name": "RootHash",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "fixed",
+                                    "size": 32,
+                                    "name": "hash"
+                                }
+                            ]
+                        },
+                        {
+                            "name": "CodeMetadata",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "DeveloperReward",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "IsSmartContract",
+                            "type": "boolean"
+                        },
+                        {
+                            "name": "PreviousBalance",
+                            "default": null,
+                            "type": [
+                                "null",
+                                "bytes"
+                            ]
+                        },
+                        {
+                            "name": "Delta",
+                            "default": null,
+                            "type": [
+                                "null",
+                                "bytes"
+                            ]
+                        },
+                        {
+                            "name": "PreviousNonce",
+                            "default": null,
+                            "type": [
+                                "null",
+                                "long"
+                            ]
+                        },
+                        {
+                            "name": "TokenBalanceUpdates",
+                            "type": {
+                                "type": "array",
+                                "items": {
+                                    "type": "record",
+                                    "name": "TokenBalanceUpdate",
+                                    "fields": [
+                                        {
+                                            "name": "Identifier",
+                                            "type": "bytes"
+                                        },
+                                        {
+                                            "name": "Nonce",
+                                            "type": "long"
+                                        },
+                                        {
+                                            "name": "Balance",
+                                            "type": "bytes"
+                                        }
+                                    ]
+                                }
+                            }
                         }
                     ]
                 }
             }
-        }
-    ]
-}`)
-
-// Generated by codegen. Please do not modify.
-var _Block_schema, _Block_schema_err = avro.ParseSchema(`{
-    "type": "record",
-    "name": "Block",
-    "fields": [
-        {
-            "name": "Nonce",
-            "type": "long"
-        },
-        {
-            "name": "Round",
-            "type": "long"
-        },
-        {
-            "name": "Epoch",
-            "type": "int"
         },
         {
-            "name": "Hash",
+            "name": "ValidatorsRating",
             "type": {
-                "type": "fixed",
-                "size": 32,
-                "name": "hash"
+                "type": "array",
+                "items": {
+                    "type": "record",
+                    "name": "ValidatorRating",
+                    "fields": [
+                        {
+                            "name": "PublicKey",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "Rating",
+                            "type": "float"
+                        },
+                        {
+                            "name": "Epoch",
+                            "type": "int"
+                        },
+                        {
+                            "name": "ShardID",
+                            "type": "int"
+                        }
+                    ]
+                }
             }
         },
         {
-            "name": "MiniBlocks",
-            "default": null,
-            "type": [
-                "null",
-                {
-                    "type": "array",
-                    "items": {
-                        "type": "record",
-                        "name": "MiniBlock",
-                        "fields": [
-                            {
-                                "name": "Hash",
-                                "type": {
-                                    "type": "fixed",
-                                    "size": 32,
-                                    "name": "hash"
-                                }
-                            },
-                            {
-                                "name": "SenderShardID",
-                                "type": "int"
+            "name": "TokenTransfers",
+            "type": {
+                "type": "array",
+                "items": {
+                    "type": "record",
+                    "name": "TokenTransfer",
+                    "fields": [
+                        {
+                            "name": "TxHash",
+                            "type": {
+                                "type": "fixed",
+                                "size": 32,
+                                "name": "hash"
+                            }
+                        },
+                        {
+                            "name": "Identifier",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "Nonce",
+                            "type": "long"
+                        },
+                        {
+                            "name": "Amount",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "Sender",
+                            "type": {
+                                "type": "fixed",
+                                "size": 62,
+                                "name": "address"
+                            }
+                        },
+                        {
+                            "name": "Receiver",
+                            "type": {
+                                "type": "fixed",
+                                "size": 62,
+                                "name": "address"
+                            }
+                        },
+                        {
+                            "name": "Decimals",
+                            "default": null,
+                            "type": [
+                                "null",
+                                "int"
+                            ]
+                        }
+                    ]
+                }
+            }
+        },
+        {
+            "name": "PeerChanges",
+            "type": {
+                "type": "array",
+                "items": {
+                    "type": "record",
+                    "name": "PeerChange",
+                    "fields": [
+                        {
+                            "name": "BlockHash",
+                            "type": {
+                                "type": "fixed",
+                                "size": 32,
+                                "name": "hash"
+                            }
+                        },
+                        {
+                            "name": "MiniBlockHash",
+                            "type": {
+                                "type": "fixed",
+                                "size": 32,
+                                "name": "hash"
+                            }
+                        },
+                        {
+                            "name": "MiniBlockIndex",
+                            "type": "int"
+                        },
+                        {
+                            "name": "IndexInMiniBlock",
+                            "type": "int"
+                        },
+                        {
+                            "name": "Epoch",
+                            "type": "int"
+                        },
+                        {
+                            "name": "PublicKey",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "ShardID",
+                            "type": "int"
+                        },
+                        {
+                            "name": "List",
+                            "type": "string"
+                        },
+                        {
+                            "name": "Index",
+                            "type": "int"
+                        },
+                        {
+                            "name": "TempRating",
+                            "type": "int"
+                        }
+                    ]
+                }
+            }
+        },
+        {
+            "name": "ContractEvents",
+            "type": {
+                "type": "array",
+                "items": {
+                    "type": "record",
+                    "name": "ContractEvent",
+                    "fields": [
+                        {
+                            "name": "TxHash",
+                            "type": {
+                                "type": "fixed",
+                                "size": 32,
+                                "name": "hash"
+                            }
+                        },
+                        {
+                            "name": "Type",
+                            "type": "string"
+                        },
+                        {
+                            "name": "ContractAddress",
+                            "type": {
+                                "type": "fixed",
+                                "size": 62,
+                                "name": "address"
+                            }
+                        },
+                        {
+                            "name": "Owner",
+                            "type": {
+                                "type": "fixed",
+                                "size": 62,
+                                "name": "address"
+                            }
+                        },
+                        {
+                            "name": "PreviousOwner",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "fixed",
+                                    "size": 62,
+                                    "name": "address"
+                                }
+                            ]
+                        },
+                        {
+                            "name": "CodeHash",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "fixed",
+                                    "size": 32,
+                                    "name": "hash"
+                                }
+                            ]
+                        },
+                        {
+                            "name": "CodeMetadata",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "record",
+                                    "name": "CodeMetadata",
+                                    "fields": [
+                                        {
+                                            "name": "Upgradeable",
+                                            "type": "boolean"
+                                        },
+                                        {
+                                            "name": "Readable",
+                                            "type": "boolean"
+                                        },
+                                        {
+                                            "name": "Payable",
+                                            "type": "boolean"
+                                        },
+                                        {
+                                            "name": "PayableBySC",
+                                            "type": "boolean"
+                                        }
+                                    ]
+                                }
+                            ]
+                        }
+                    ]
+                }
+            }
+        },
+        {
+            "name": "CallTrees",
+            "type": {
+                "type": "array",
+                "items": {
+                    "type": "record",
+                    "name": "CallTree",
+                    "fields": [
+                        {
+                            "name": "OriginalTxHash",
+                            "type": {
+                                "type": "fixed",
+                                "size": 32,
+                                "name": "hash"
+                            }
+                        },
+                        {
+                            "name": "IsPartial",
+                            "type": "boolean"
+                        },
+                        {
+                            "name": "Nodes",
+                            "type": {
+                                "type": "array",
+                                "items": {
+                                    "type": "record",
+                                    "name": "CallTreeNode",
+                                    "fields": [
+                                        {
+                                            "name": "Hash",
+                                            "type": {
+                                                "type": "fixed",
+                                                "size": 32,
+                                                "name": "hash"
+                                            }
+                                        },
+                                        {
+                                            "name": "ParentHash",
+                                            "type": {
+                                                "type": "fixed",
+                                                "size": 32,
+                                                "name": "hash"
+                                            }
+                                        },
+                                        {
+                                            "name": "Depth",
+                                            "type": "int"
+                                        },
+                                        {
+                                            "name": "CallType",
+                                            "type": "int"
+                                        },
+                                        {
+                                            "name": "CallbackHash",
+                                            "default": null,
+                                            "type": [
+                                                "null",
+                                                {
+                                                    "type": "fixed",
+                                                    "size": 32,
+                                                    "name": "hash"
+                                                }
+                                            ]
+                                        }
+                                    ]
+                                }
+                            }
+                        }
+                    ]
+                }
+            }
+        },
+        {
+            "name": "StakingEvents",
+            "type": {
+                "type": "array",
+                "items": {
+                    "type": "record",
+                    "name": "StakingEvent",
+                    "fields": [
+                        {
+                            "name": "TxHash",
+                            "type": {
+                                "type": "fixed",
+                                "size": 32,
+                                "name": "hash"
+                            }
+                        },
+                        {
+                            "name": "Type",
+                            "type": "string"
+                        },
+                        {
+                            "name": "Caller",
+                            "type": {
+                                "type": "fixed",
+                                "size": 62,
+                                "name": "address"
+                            }
+                        },
+                        {
+                            "name": "Contract",
+                            "type": {
+                                "type": "fixed",
+                                "size": 62,
+                                "name": "address"
+                            }
+                        },
+                        {
+                            "name": "DelegationContract",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "fixed",
+                                    "size": 62,
+                                    "name": "address"
+                                }
+                            ]
+                        },
+                        {
+                            "name": "Amount",
+                            "default": null,
+                            "type": [
+                                "null",
+                                "bytes"
+                            ]
+                        }
+                    ]
+                }
+            }
+        },
+        {
+            "name": "TokenRegistryEvents",
+            "type": {
+                "type": "array",
+                "items": {
+                    "type": "record",
+                    "name": "TokenRegistryEvent",
+                    "fields": [
+                        {
+                            "name": "TxHash",
+                            "type": {
+                                "type": "fixed",
+                                "size": 32,
+                                "name": "hash"
+                            }
+                        },
+                        {
+                            "name": "Type",
+                            "type": "string"
+                        },
+                        {
+                            "name": "Caller",
+                            "type": {
+                                "type": "fixed",
+                                "size": 62,
+                                "name": "address"
+                            }
+                        },
+                        {
+                            "name": "Identifier",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "Issue",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "record",
+                                    "name": "TokenIssue",
+                                    "fields": [
+                                        {
+                                            "name": "Name",
+                                            "type": "bytes"
+                                        },
+                                        {
+                                            "name": "Ticker",
+                                            "type": "bytes"
+                                        },
+                                        {
+                                            "name": "TokenType",
+                                            "type": "string"
+                                        },
+                                        {
+                                            "name": "Decimals",
+                                            "type": "int"
+                                        },
+                                        {
+                                            "name": "InitialSupply",
+                                            "type": "bytes"
+                                        }
+                                    ]
+                                }
+                            ]
+                        },
+                        {
+                            "name": "Owner",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "fixed",
+                                    "size": 62,
+                                    "name": "address"
+                                }
+                            ]
+                        },
+                        {
+                            "name": "Address",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "fixed",
+                                    "size": 62,
+                                    "name": "address"
+                                }
+                            ]
+                        },
+                        {
+                            "name": "Roles",
+                            "type": {
+                                "type": "array",
+                                "items": "string"
+                            }
+                        }
+                    ]
+                }
+            }
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _Block_schema, _Block_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "Block",
+    "fields": [
+        {
+            "name": "Nonce",
+            "type": "long"
+        },
+        {
+            "name": "Round",
+            "type": "long"
+        },
+        {
+            "name": "Epoch",
+            "type": "int"
+        },
+        {
+            "name": "Hash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "MiniBlocks",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "array",
+                    "items": {
+                        "type": "record",
+                        "name": "MiniBlock",
+                        "fields": [
+                            {
+                                "name": "Hash",
+                                "type": {
+                                    "type": "fixed",
+                                    "size": 32,
+                                    "name": "hash"
+                                }
+                            },
+                            {
+                                "name": "Index",
+                                "type": "int"
+                            },
+                            {
+                                "name": "SenderShardID",
+                                "type": "int"
+                            },
+                            {
+                                "name": "ReceiverShardID",
+                                "type": "int"
+                            },
+                            {
+                                "name": "Type",
+                                "type": "int"
+                            },
+                            {
+                                "name": "Timestamp",
+                                "type": "long"
                             },
                             {
-                                "name": "ReceiverShardID",
+                                "name": "TxHashes",
+                                "type": {
+                                    "type": "array",
+                                    "items": "bytes"
+                                }
+                            }
+                        ]
+                    }
+                }
+            ]
+        },
+        {
+            "name": "NotarizedBlocksHashes",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "array",
+                    "items": {
+                        "type": "fixed",
+                        "size": 32,
+                        "name": "hash"
+                    }
+                }
+            ]
+        },
+        {
+            "name": "Proposer",
+            "type": "long"
+        },
+        {
+            "name": "Validators",
+            "type": {
+                "type": "array",
+                "items": "long"
+            }
+        },
+        {
+            "name": "PubKeysBitmap",
+            "type": "bytes"
+        },
+        {
+            "name": "Size",
+            "type": "long"
+        },
+        {
+            "name": "Timestamp",
+            "type": "long"
+        },
+        {
+            "name": "StateRootHash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "PrevHash",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 32,
+                    "name": "hash"
+                }
+            ]
+        },
+        {
+            "name": "ShardID",
+            "type": "int"
+        },
+        {
+            "name": "TxCount",
+            "type": "int"
+        },
+        {
+            "name": "AccumulatedFees",
+            "type": "bytes"
+        },
+        {
+            "name": "DeveloperFees",
+            "type": "bytes"
+        },
+        {
+            "name": "EpochStartBlock",
+            "type": "boolean"
+        },
+        {
+            "name": "EpochStartInfo",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "record",
+                    "name": "EpochStartInfo",
+                    "fields": [
+                        {
+                            "name": "TotalSupply",
+                            "default": null,
+                            "type": [
+                                "null",
+                                "bytes"
+                            ]
+                        },
+                        {
+                            "name": "TotalToDistribute",
+                            "default": null,
+                            "type": [
+                                "null",
+                                "bytes"
+                            ]
+                        },
+                        {
+                            "name": "TotalNewlyMinted",
+                            "default": null,
+                            "type": [
+                                "null",
+                                "bytes"
+                            ]
+                        },
+                        {
+                            "name": "RewardsPerBlock",
+                            "default": null,
+                            "type": [
+                                "null",
+                                "bytes"
+                            ]
+                        },
+                        {
+                            "name": "RewardsForProtocolSustainability",
+                            "default": null,
+                            "type": [
+                                "null",
+                                "bytes"
+                            ]
+                        },
+                        {
+                            "name": "NodePrice",
+                            "default": null,
+                            "type": [
+                                "null",
+                                "bytes"
+                            ]
+                        },
+                        {
+                            "name": "PrevEpochStartRound",
+                            "default": null,
+                            "type": [
+                                "null",
+                                "int"
+                            ]
+                        },
+                        {
+                            "name": "PrevEpochStartHash",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "fixed",
+                                    "size": 32,
+                                    "name": "hash"
+                                }
+                            ]
+                        },
+                        {
+                            "name": "LastFinalizedHeaders",
+                            "type": {
+                                "type": "array",
+                                "items": {
+                                    "type": "record",
+                                    "name": "EpochStartShardData",
+                                    "fields": [
+                                        {
+                                            "name": "ShardID",
+                                            "type": "int"
+                                        },
+                                        {
+                                            "name": "Epoch",
+                                            "type": "int"
+                                        },
+                                        {
+                                            "name": "Round",
+                                            "type": "long"
+                                        },
+                                        {
+                                            "name": "Nonce",
+                                            "type": "long"
+                                        },
+                                        {
+                                            "name": "HeaderHash",
+                                            "type": {
+                                                "type": "fixed",
+                                                "size": 32,
+                                                "name": "hash"
+                                            }
+                                        },
+                                        {
+                                            "name": "RootHash",
+                                            "type": {
+                                                "type": "fixed",
+                                                "size": 32,
+                                                "name": "hash"
+                                            }
+                                        },
+                                        {
+                                            "name": "FirstPendingMetaBlock",
+                                            "default": null,
+                                            "type": [
+                                                "null",
+                                                {
+                                                    "type": "fixed",
+                                                    "size": 32,
+                                                    "name": "hash"
+                                                }
+                                            ]
+                                        },
+                                        {
+                                            "name": "LastFinishedMetaBlock",
+                                            "default": null,
+                                            "type": [
+                                                "null",
+                                                {
+                                                    "type": "fixed",
+                                                    "size": 32,
+                                                    "name": "hash"
+                                                }
+                                            ]
+                                        },
+                                        {
+                                            "name": "PendingMiniBlockHeaders",
+                                            "type": {
+                                                "type": "array",
+                                                "items": {
+                                                    "type": "record",
+                                                    "name": "MiniBlockHeader",
+                                                    "fields": [
+                                                        {
+                                                            "name": "Hash",
+                                                            "type": {
+                                                                "type": "fixed",
+                                                                "size": 32,
+                                                                "name": "hash"
+                                                            }
+                                                        },
+                                                        {
+                                                            "name": "SenderShardID",
+                                                            "type": "int"
+                                                        },
+                                                        {
+                                                            "name": "ReceiverShardID",
+                                                            "type": "int"
+                                                        },
+                                                        {
+                                                            "name": "TxCount",
+                                                            "type": "int"
+                                                        },
+                                                        {
+                                                            "name": "Type",
+                                                            "type": "int"
+                                                        }
+                                                    ]
+                                                }
+                                            }
+                                        }
+                                    ]
+                                }
+                            }
+                        },
+                        {
+                            "name": "EpochStartMetaHash",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "fixed",
+                                    "size": 32,
+                                    "name": "hash"
+                                }
+                            ]
+                        }
+                    ]
+                }
+            ]
+        },
+        {
+            "name": "ChainID",
+            "type": "bytes"
+        },
+        {
+            "name": "HeaderVersion",
+            "type": "int"
+        },
+        {
+            "name": "SoftwareVersion",
+            "type": "bytes"
+        },
+        {
+            "name": "RandSeed",
+            "type": "bytes"
+        },
+        {
+            "name": "PrevRandSeed",
+            "type": "bytes"
+        },
+        {
+            "name": "LeaderSignature",
+            "type": "bytes"
+        },
+        {
+            "name": "Signature",
+            "type": "bytes"
+        },
+        {
+            "name": "ReceiptsHash",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 32,
+                    "name": "hash"
+                }
+            ]
+        },
+        {
+            "name": "EpochStartMetaHash",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 32,
+                    "name": "hash"
+                }
+            ]
+        },
+        {
+            "name": "NotarizedShardHeaders",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "array",
+                    "items": {
+                        "type": "record",
+                        "name": "NotarizedShardHeader",
+                        "fields": [
+                            {
+                                "name": "ShardID",
                                 "type": "int"
                             },
                             {
-                                "name": "Type",
-                                "type": "int"
+                                "name": "Nonce",
+                                "type": "long"
                             },
                             {
-                                "name": "Timestamp",
+                                "name": "Round",
                                 "type": "long"
                             },
                             {
-                                "name": "TxHashes",
+                                "name": "HeaderHash",
+                                "type": {
+                                    "type": "fixed",
+                                    "size": 32,
+                                    "name": "hash"
+                                }
+                            },
+                            {
+                                "name": "PrevHash",
+                                "default": null,
+                                "type": [
+                                    "null",
+                                    {
+                                        "type": "fixed",
+                                        "size": 32,
+                                        "name": "hash"
+                                    }
+                                ]
+                            },
+                            {
+                                "name": "TxCount",
+                                "type": "int"
+                            },
+                            {
+                                "name": "AccumulatedFees",
+                                "type": "bytes"
+                            },
+                            {
+                                "name": "DeveloperFees",
+                                "type": "bytes"
+                            },
+                            {
+                                "name": "MiniBlockHeaders",
                                 "type": {
                                     "type": "array",
-                                    "items": "bytes"
+                                    "items": "MiniBlockHeader"
+                                }
+                            }
+                        ]
+                    }
+                }
+            ]
+        },
+        {
+            "name": "ScheduledData",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "record",
+                    "name": "ScheduledData",
+                    "fields": [
+                        {
+                            "name": "RootHash",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "fixed",
+                                    "size": 32,
+                                    "name": "hash"
+                                }
+                            ]
+                        },
+                        {
+                            "name": "AccumulatedFees",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "DeveloperFees",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "GasProvided",
+                            "type": "long"
+                        },
+                        {
+                            "name": "GasPenalized",
+                            "type": "long"
+                        },
+                        {
+                            "name": "GasRefunded",
+                            "type": "long"
+                        }
+                    ]
+                }
+            ]
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _MiniBlock_schema, _MiniBlock_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "MiniBlock",
+    "fields": [
+        {
+            "name": "Hash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "Index",
+            "type": "int"
+        },
+        {
+            "name": "SenderShardID",
+            "type": "int"
+        },
+        {
+            "name": "ReceiverShardID",
+            "type": "int"
+        },
+        {
+            "name": "Type",
+            "type": "int"
+        },
+        {
+            "name": "Timestamp",
+            "type": "long"
+        },
+        {
+            "name": "TxHashes",
+            "type": {
+                "type": "array",
+                "items": "bytes"
+            }
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _EpochStartInfo_schema, _EpochStartInfo_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "EpochStartInfo",
+    "fields": [
+        {
+            "name": "TotalSupply",
+            "default": null,
+            "type": [
+                "null",
+                "bytes"
+            ]
+        },
+        {
+            "name": "TotalToDistribute",
+            "default": null,
+            "type": [
+                "null",
+                "bytes"
+            ]
+        },
+        {
+            "name": "TotalNewlyMinted",
+            "default": null,
+            "type": [
+                "null",
+                "bytes"
+            ]
+        },
+        {
+            "name": "RewardsPerBlock",
+            "default": null,
+            "type": [
+                "null",
+                "bytes"
+            ]
+        },
+        {
+            "name": "RewardsForProtocolSustainability",
+            "default": null,
+            "type": [
+                "null",
+                "bytes"
+            ]
+        },
+        {
+            "name": "NodePrice",
+            "default": null,
+            "type": [
+                "null",
+                "bytes"
+            ]
+        },
+        {
+            "name": "PrevEpochStartRound",
+            "default": null,
+            "type": [
+                "null",
+                "int"
+            ]
+        },
+        {
+            "name": "PrevEpochStartHash",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 32,
+                    "name": "hash"
+                }
+            ]
+        },
+        {
+            "name": "LastFinalizedHeaders",
+            "type": {
+                "type": "array",
+                "items": {
+                    "type": "record",
+                    "name": "EpochStartShardData",
+                    "fields": [
+                        {
+                            "name": "ShardID",
+                            "type": "int"
+                        },
+                        {
+                            "name": "Epoch",
+                            "type": "int"
+                        },
+                        {
+                            "name": "Round",
+                            "type": "long"
+                        },
+                        {
+                            "name": "Nonce",
+                            "type": "long"
+                        },
+                        {
+                            "name": "HeaderHash",
+                            "type": {
+                                "type": "fixed",
+                                "size": 32,
+                                "name": "hash"
+                            }
+                        },
+                        {
+                            "name": "RootHash",
+                            "type": {
+                                "type": "fixed",
+                                "size": 32,
+                                "name": "hash"
+                            }
+                        },
+                        {
+                            "name": "FirstPendingMetaBlock",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "fixed",
+                                    "size": 32,
+                                    "name": "hash"
+                                }
+                            ]
+                        },
+                        {
+                            "name": "LastFinishedMetaBlock",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "fixed",
+                                    "size": 32,
+                                    "name": "hash"
+                                }
+                            ]
+                        },
+                        {
+                            "name": "PendingMiniBlockHeaders",
+                            "type": {
+                                "type": "array",
+                                "items": {
+                                    "type": "record",
+                                    "name": "MiniBlockHeader",
+                                    "fields": [
+                                        {
+                                            "name": "Hash",
+                                            "type": {
+                                                "type": "fixed",
+                                                "size": 32,
+                                                "name": "hash"
+                                            }
+                                        },
+                                        {
+                                            "name": "SenderShardID",
+                                            "type": "int"
+                                        },
+                                        {
+                                            "name": "ReceiverShardID",
+                                            "type": "int"
+                                        },
+                                        {
+                                            "name": "TxCount",
+                                            "type": "int"
+                                        },
+                                        {
+                                            "name": "Type",
+                                            "type": "int"
+                                        }
+                                    ]
+                                }
+                            }
+                        }
+                    ]
+                }
+            }
+        },
+        {
+            "name": "EpochStartMetaHash",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 32,
+                    "name": "hash"
+                }
+            ]
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _EpochStartShardData_schema, _EpochStartShardData_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "EpochStartShardData",
+    "fields": [
+        {
+            "name": "ShardID",
+            "type": "int"
+        },
+        {
+            "name": "Epoch",
+            "type": "int"
+        },
+        {
+            "name": "Round",
+            "type": "long"
+        },
+        {
+            "name": "Nonce",
+            "type": "long"
+        },
+        {
+            "name": "HeaderHash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "RootHash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "FirstPendingMetaBlock",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 32,
+                    "name": "hash"
+                }
+            ]
+        },
+        {
+            "name": "LastFinishedMetaBlock",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 32,
+                    "name": "hash"
+                }
+            ]
+        },
+        {
+            "name": "PendingMiniBlockHeaders",
+            "type": {
+                "type": "array",
+                "items": {
+                    "type": "record",
+                    "name": "MiniBlockHeader",
+                    "fields": [
+                        {
+                            "name": "Hash",
+                            "type": {
+                                "type": "fixed",
+                                "size": 32,
+                                "name": "hash"
+                            }
+                        },
+                        {
+                            "name": "SenderShardID",
+                            "type": "int"
+                        },
+                        {
+                            "name": "ReceiverShardID",
+                            "type": "int"
+                        },
+                        {
+                            "name": "TxCount",
+                            "type": "int"
+                        },
+                        {
+                            "name": "Type",
+                            "type": "int"
+                        }
+                    ]
+                }
+            }
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _MiniBlockHeader_schema, _MiniBlockHeader_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "MiniBlockHeader",
+    "fields": [
+        {
+            "name": "Hash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "SenderShardID",
+            "type": "int"
+        },
+        {
+            "name": "ReceiverShardID",
+            "type": "int"
+        },
+        {
+            "name": "TxCount",
+            "type": "int"
+        },
+        {
+            "name": "Type",
+            "type": "int"
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _NotarizedShardHeader_schema, _NotarizedShardHeader_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "NotarizedShardHeader",
+    "fields": [
+        {
+            "name": "ShardID",
+            "type": "int"
+        },
+        {
+            "name": "Nonce",
+            "type": "long"
+        },
+        {
+            "name": "Round",
+            "type": "long"
+        },
+        {
+            "name": "HeaderHash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "PrevHash",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 32,
+                    "name": "hash"
+                }
+            ]
+        },
+        {
+            "name": "TxCount",
+            "type": "int"
+        },
+        {
+            "name": "AccumulatedFees",
+            "type": "bytes"
+        },
+        {
+            "name": "DeveloperFees",
+            "type": "bytes"
+        },
+        {
+            "name": "MiniBlockHeaders",
+            "type": {
+                "type": "array",
+                "items": "MiniBlockHeader"
+            }
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _ScheduledData_schema, _ScheduledData_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "ScheduledData",
+    "fields": [
+        {
+            "name": "RootHash",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 32,
+                    "name": "hash"
+                }
+            ]
+        },
+        {
+            "name": "AccumulatedFees",
+            "type": "bytes"
+        },
+        {
+            "name": "DeveloperFees",
+            "type": "bytes"
+        },
+        {
+            "name": "GasProvided",
+            "type": "long"
+        },
+        {
+            "name": "GasPenalized",
+            "type": "long"
+        },
+        {
+            "name": "GasRefunded",
+            "type": "long"
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _Transaction_schema, _Transaction_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "Transaction",
+    "fields": [
+        {
+            "name": "Hash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "MiniBlockHash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "BlockHash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "MiniBlockIndex",
+            "type": "int"
+        },
+        {
+            "name": "IndexInMiniBlock",
+            "type": "int"
+        },
+        {
+            "name": "Nonce",
+            "type": "long"
+        },
+        {
+            "name": "Round",
+            "type": "long"
+        },
+        {
+            "name": "Value",
+            "type": "bytes"
+        },
+        {
+            "name": "Receiver",
+            "type": {
+                "type": "fixed",
+                "size": 62,
+                "name": "address"
+            }
+        },
+        {
+            "name": "Sender",
+            "type": {
+                "type": "fixed",
+                "size": 62,
+                "name": "address"
+            }
+        },
+        {
+            "name": "ReceiverShard",
+            "type": "int"
+        },
+        {
+            "name": "SenderShard",
+            "type": "int"
+        },
+        {
+            "name": "GasPrice",
+            "type": "long"
+        },
+        {
+            "name": "GasLimit",
+            "type": "long"
+        },
+        {
+            "name": "Data",
+            "type": "bytes"
+        },
+        {
+            "name": "Function",
+            "type": "string"
+        },
+        {
+            "name": "Arguments",
+            "type": {
+                "type": "array",
+                "items": "bytes"
+            }
+        },
+        {
+            "name": "Operation",
+            "type": "string"
+        },
+        {
+            "name": "Signature",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 64,
+                    "name": "signature"
+                }
+            ]
+        },
+        {
+            "name": "Timestamp",
+            "type": "long"
+        },
+        {
+            "name": "SenderUserName",
+            "type": "bytes"
+        },
+        {
+            "name": "ReceiverUserName",
+            "type": "bytes"
+        },
+        {
+            "name": "Status",
+            "type": "string"
+        },
+        {
+            "name": "GasUsed",
+            "default": null,
+            "type": [
+                "null",
+                "long"
+            ]
+        },
+        {
+            "name": "Fee",
+            "default": null,
+            "type": [
+                "null",
+                "bytes"
+            ]
+        },
+        {
+            "name": "InitialPaidFee",
+            "default": null,
+            "type": [
+                "null",
+                "bytes"
+            ]
+        },
+        {
+            "name": "MoveBalanceGas",
+            "default": null,
+            "type": [
+                "null",
+                "long"
+            ]
+        },
+        {
+            "name": "ProcessingFee",
+            "default": null,
+            "type": [
+                "null",
+                "bytes"
+            ]
+        },
+        {
+            "name": "InnerTransaction",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "record",
+                    "name": "InnerTransaction",
+                    "fields": [
+                        {
+                            "name": "Version",
+                            "type": "int"
+                        },
+                        {
+                            "name": "Nonce",
+                            "type": "long"
+                        },
+                        {
+                            "name": "Sender",
+                            "type": {
+                                "type": "fixed",
+                                "size": 62,
+                                "name": "address"
+                            }
+                        },
+                        {
+                            "name": "Receiver",
+                            "type": {
+                                "type": "fixed",
+                                "size": 62,
+                                "name": "address"
+                            }
+                        },
+                        {
+                            "name": "Value",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "GasPrice",
+                            "type": "long"
+                        },
+                        {
+                            "name": "GasLimit",
+                            "default": null,
+                            "type": [
+                                "null",
+                                "long"
+                            ]
+                        },
+                        {
+                            "name": "Data",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "Signature",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "SCResultHashes",
+                            "type": {
+                                "type": "array",
+                                "items": {
+                                    "type": "fixed",
+                                    "size": 32,
+                                    "name": "hash"
+                                }
+                            }
+                        }
+                    ]
+                }
+            ]
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _InnerTransaction_schema, _InnerTransaction_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "InnerTransaction",
+    "fields": [
+        {
+            "name": "Version",
+            "type": "int"
+        },
+        {
+            "name": "Nonce",
+            "type": "long"
+        },
+        {
+            "name": "Sender",
+            "type": {
+                "type": "fixed",
+                "size": 62,
+                "name": "address"
+            }
+        },
+        {
+            "name": "Receiver",
+            "type": {
+                "type": "fixed",
+                "size": 62,
+                "name": "address"
+            }
+        },
+        {
+            "name": "Value",
+            "type": "bytes"
+        },
+        {
+            "name": "GasPrice",
+            "type": "long"
+        },
+        {
+            "name": "GasLimit",
+            "default": null,
+            "type": [
+                "null",
+                "long"
+            ]
+        },
+        {
+            "name": "Data",
+            "type": "bytes"
+        },
+        {
+            "name": "Signature",
+            "type": "bytes"
+        },
+        {
+            "name": "SCResultHashes",
+            "type": {
+                "type": "array",
+                "items": {
+                    "type": "fixed",
+                    "size": 32,
+                    "name": "hash"
+                }
+            }
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _SCResult_schema, _SCResult_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "SCResult",
+    "fields": [
+        {
+            "name": "Hash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "MiniBlockHash",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 32,
+                    "name": "hash"
+                }
+            ]
+        },
+        {
+            "name": "BlockHash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "MiniBlockIndex",
+            "type": "int"
+        },
+        {
+            "name": "IndexInMiniBlock",
+            "type": "int"
+        },
+        {
+            "name": "Nonce",
+            "type": "long"
+        },
+        {
+            "name": "GasLimit",
+            "type": "long"
+        },
+        {
+            "name": "GasPrice",
+            "type": "long"
+        },
+        {
+            "name": "Value",
+            "type": "bytes"
+        },
+        {
+            "name": "Sender",
+            "type": {
+                "type": "fixed",
+                "size": 62,
+                "name": "address"
+            }
+        },
+        {
+            "name": "Receiver",
+            "type": {
+                "type": "fixed",
+                "size": 62,
+                "name": "address"
+            }
+        },
+        {
+            "name": "ReceiverShard",
+            "type": "int"
+        },
+        {
+            "name": "SenderShard",
+            "type": "int"
+        },
+        {
+            "name": "RelayerAddr",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 62,
+                    "name": "address"
+                }
+            ]
+        },
+        {
+            "name": "RelayedValue",
+            "type": "bytes"
+        },
+        {
+            "name": "Code",
+            "type": "bytes"
+        },
+        {
+            "name": "Data",
+            "type": "bytes"
+        },
+        {
+            "name": "Function",
+            "type": "string"
+        },
+        {
+            "name": "Arguments",
+            "type": {
+                "type": "array",
+                "items": "bytes"
+            }
+        },
+        {
+            "name": "Operation",
+            "type": "string"
+        },
+        {
+            "name": "PrevTxHash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "OriginalTxHash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "CallType",
+            "type": "int"
+        },
+        {
+            "name": "CodeMetadata",
+            "type": "bytes"
+        },
+        {
+            "name": "ReturnMessage",
+            "type": "bytes"
+        },
+        {
+            "name": "ReturnCode",
+            "type": "string"
+        },
+        {
+            "name": "ReturnData",
+            "type": {
+                "type": "array",
+                "items": "bytes"
+            }
+        },
+        {
+            "name": "IsRefund",
+            "type": "boolean"
+        },
+        {
+            "name": "Timestamp",
+            "type": "long"
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _Receipt_schema, _Receipt_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "Receipt",
+    "fields": [
+        {
+            "name": "Hash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "MiniBlockHash",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 32,
+                    "name": "hash"
+                }
+            ]
+        },
+        {
+            "name": "BlockHash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "Value",
+            "type": "bytes"
+        },
+        {
+            "name": "Sender",
+            "type": {
+                "type": "fixed",
+                "size": 62,
+                "name": "address"
+            }
+        },
+        {
+            "name": "ReceiverShard",
+            "type": "int"
+        },
+        {
+            "name": "SenderShard",
+            "type": "int"
+        },
+        {
+            "name": "Data",
+            "type": "bytes"
+        },
+        {
+            "name": "TxHash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "Timestamp",
+            "type": "long"
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _Log_schema, _Log_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "Log",
+    "fields": [
+        {
+            "name": "ID",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "BlockHash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "Index",
+            "type": "int"
+        },
+        {
+            "name": "Address",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 62,
+                    "name": "address"
+                }
+            ]
+        },
+        {
+            "name": "Events",
+            "type": {
+                "type": "array",
+                "items": {
+                    "type": "record",
+                    "name": "Event",
+                    "fields": [
+                        {
+                            "name": "Address",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "fixed",
+                                    "size": 62,
+                                    "name": "address"
+                                }
+                            ]
+                        },
+                        {
+                            "name": "Identifier",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "Topics",
+                            "type": {
+                                "type": "array",
+                                "items": "bytes"
+                            }
+                        },
+                        {
+                            "name": "Data",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "Index",
+                            "type": "int"
+                        },
+                        {
+                            "name": "NFTEvent",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "record",
+                                    "name": "NFTEvent",
+                                    "fields": [
+                                        {
+                                            "name": "Type",
+                                            "type": "string"
+                                        },
+                                        {
+                                            "name": "Token",
+                                            "type": "bytes"
+                                        },
+                                        {
+                                            "name": "Nonce",
+                                            "type": "long"
+                                        },
+                                        {
+                                            "name": "Quantity",
+                                            "type": "bytes"
+                                        },
+                                        {
+                                            "name": "Metadata",
+                                            "default": null,
+                                            "type": [
+                                                "null",
+                                                {
+                                                    "type": "record",
+                                                    "name": "NFTMetadata",
+                                                    "fields": [
+                                                        {
+                                                            "name": "Name",
+                                                            "type": "bytes"
+                                                        },
+                                                        {
+                                                            "name": "Creator",
+                                                            "default": null,
+                                                            "type": [
+                                                                "null",
+                                                                {
+                                                                    "type": "fixed",
+                                                                    "size": 62,
+                                                                    "name": "address"
+                                                                }
+                                                            ]
+                                                        },
+                                                        {
+                                                            "name": "Royalties",
+                                                            "type": "int"
+                                                        },
+                                                        {
+                                                            "name": "Hash",
+                                                            "type": "bytes"
+                                                        },
+                                                        {
+                                                            "name": "Attributes",
+                                                            "type": "bytes"
+                                                        },
+                                                        {
+                                                            "name": "URIs",
+                                                            "type": {
+                                                                "type": "array",
+                                                                "items": "bytes"
+                                                            }
+                                                        }
+                                                    ]
+                                                }
+                                            ]
+                                        },
+                                        {
+                                            "name": "Attributes",
+                                            "default": null,
+                                            "type": [
+                                                "null",
+                                                "bytes"
+                                            ]
+                                        }
+                                    ]
+                                }
+                            ]
+                        }
+                    ]
+                }
+            }
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _Event_schema, _Event_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "Event",
+    "fields": [
+        {
+            "name": "Address",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 62,
+                    "name": "address"
+                }
+            ]
+        },
+        {
+            "name": "Identifier",
+            "type": "bytes"
+        },
+        {
+            "name": "Topics",
+            "type": {
+                "type": "array",
+                "items": "bytes"
+            }
+        },
+        {
+            "name": "Data",
+            "type": "bytes"
+        },
+        {
+            "name": "Index",
+            "type": "int"
+        },
+        {
+            "name": "NFTEvent",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "record",
+                    "name": "NFTEvent",
+                    "fields": [
+                        {
+                            "name": "Type",
+                            "type": "string"
+                        },
+                        {
+                            "name": "Token",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "Nonce",
+                            "type": "long"
+                        },
+                        {
+                            "name": "Quantity",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "Metadata",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "record",
+                                    "name": "NFTMetadata",
+                                    "fields": [
+                                        {
+                                            "name": "Name",
+                                            "type": "bytes"
+                                        },
+                                        {
+                                            "name": "Creator",
+                                            "default": null,
+                                            "type": [
+                                                "null",
+                                                {
+                                                    "type": "fixed",
+                                                    "size": 62,
+                                                    "name": "address"
+                                                }
+                                            ]
+                                        },
+                                        {
+                                            "name": "Royalties",
+                                            "type": "int"
+                                        },
+                                        {
+                                            "name": "Hash",
+                                            "type": "bytes"
+                                        },
+                                        {
+                                            "name": "Attributes",
+                                            "type": "bytes"
+                                        },
+                                        {
+                                            "name": "URIs",
+                                            "type": {
+                                                "type": "array",
+                                                "items": "bytes"
+                                            }
+                                        }
+                                    ]
                                 }
+                            ]
+                        },
+                        {
+                            "name": "Attributes",
+                            "default": null,
+                            "type": [
+                                "null",
+                                "bytes"
+                            ]
+                        }
+                    ]
+                }
+            ]
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _NFTEvent_schema, _NFTEvent_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "NFTEvent",
+    "fields": [
+        {
+            "name": "Type",
+            "type": "string"
+        },
+        {
+            "name": "Token",
+            "type": "bytes"
+        },
+        {
+            "name": "Nonce",
+            "type": "long"
+        },
+        {
+            "name": "Quantity",
+            "type": "bytes"
+        },
+        {
+            "name": "Metadata",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "record",
+                    "name": "NFTMetadata",
+                    "fields": [
+                        {
+                            "name": "Name",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "Creator",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "fixed",
+                                    "size": 62,
+                                    "name": "address"
+                                }
+                            ]
+                        },
+                        {
+                            "name": "Royalties",
+                            "type": "int"
+                        },
+                        {
+                            "name": "Hash",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "Attributes",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "URIs",
+                            "type": {
+                                "type": "array",
+                                "items": "bytes"
                             }
-                        ]
-                    }
+                        }
+                    ]
                 }
             ]
         },
         {
-            "name": "NotarizedBlocksHashes",
+            "name": "Attributes",
+            "default": null,
+            "type": [
+                "null",
+                "bytes"
+            ]
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _NFTMetadata_schema, _NFTMetadata_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "NFTMetadata",
+    "fields": [
+        {
+            "name": "Name",
+            "type": "bytes"
+        },
+        {
+            "name": "Creator",
             "default": null,
             "type": [
                 "null",
                 {
-                    "type": "array",
-                    "items": {
-                        "type": "fixed",
-                        "size": 32,
-                        "name": "hash"
-                    }
+                    "type": "fixed",
+                    "size": 62,
+                    "name": "address"
                 }
             ]
         },
         {
-            "name": "Proposer",
-            "type": "long"
+            "name": "Royalties",
+            "type": "int"
         },
         {
-            "name": "Validators",
+            "name": "Hash",
+            "type": "bytes"
+        },
+        {
+            "name": "Attributes",
+            "type": "bytes"
+        },
+        {
+            "name": "URIs",
             "type": {
                 "type": "array",
-                "items": "long"
+                "items": "bytes"
+            }
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _AccountBalanceUpdate_schema, _AccountBalanceUpdate_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "AccountBalanceUpdate",
+    "fields": [
+        {
+            "name": "Address",
+            "type": {
+                "type": "fixed",
+                "size": 62,
+                "name": "address"
             }
         },
         {
-            "name": "PubKeysBitmap",
+            "name": "Balance",
             "type": "bytes"
         },
         {
-            "name": "Size",
+            "name": "Nonce",
             "type": "long"
         },
         {
-            "name": "Timestamp",
+            "name": "UserName",
+            "type": "bytes"
+        },
+        {
+            "name": "OwnerAddress",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 62,
+                    "name": "address"
+                }
+            ]
+        },
+        {
+            "name": "CodeHash",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 32,
+                    "name": "hash"
+                }
+            ]
+        },
+        {
+            "name": "RootHash",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 32,
+                    "name": "hash"
+                }
+            ]
+        },
+        {
+            "name": "CodeMetadata",
+            "type": "bytes"
+        },
+        {
+            "name": "DeveloperReward",
+            "type": "bytes"
+        },
+        {
+            "name": "IsSmartContract",
+            "type": "boolean"
+        },
+        {
+            "name": "PreviousBalance",
+            "default": null,
+            "type": [
+                "null",
+                "bytes"
+            ]
+        },
+        {
+            "name": "Delta",
+            "default": null,
+            "type": [
+                "null",
+                "bytes"
+            ]
+        },
+        {
+            "name": "PreviousNonce",
+            "default": null,
+            "type": [
+                "null",
+                "long"
+            ]
+        },
+        {
+            "name": "TokenBalanceUpdates",
+            "type": {
+                "type": "array",
+                "items": {
+                    "type": "record",
+                    "name": "TokenBalanceUpdate",
+                    "fields": [
+                        {
+                            "name": "Identifier",
+                            "type": "bytes"
+                        },
+                        {
+                            "name": "Nonce",
+                            "type": "long"
+                        },
+                        {
+                            "name": "Balance",
+                            "type": "bytes"
+                        }
+                    ]
+                }
+            }
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _TokenBalanceUpdate_schema, _TokenBalanceUpdate_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "TokenBalanceUpdate",
+    "fields": [
+        {
+            "name": "Identifier",
+            "type": "bytes"
+        },
+        {
+            "name": "Nonce",
             "type": "long"
         },
         {
-            "name": "StateRootHash",
+            "name": "Balance",
+            "type": "bytes"
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _ValidatorRating_schema, _ValidatorRating_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "ValidatorRating",
+    "fields": [
+        {
+            "name": "PublicKey",
+            "type": "bytes"
+        },
+        {
+            "name": "Rating",
+            "type": "float"
+        },
+        {
+            "name": "Epoch",
+            "type": "int"
+        },
+        {
+            "name": "ShardID",
+            "type": "int"
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _TokenTransfer_schema, _TokenTransfer_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "TokenTransfer",
+    "fields": [
+        {
+            "name": "TxHash",
             "type": {
                 "type": "fixed",
                 "size": 32,
@@ -991,100 +4734,51 @@ var _Block_schema, _Block_schema_err = avro.ParseSchema(`{
             }
         },
         {
-            "name": "PrevHash",
-            "default": null,
-            "type": [
-                "null",
-                {
-                    "type": "fixed",
-                    "size": 32,
-                    "name": "hash"
-                }
-            ]
-        },
-        {
-            "name": "ShardID",
-            "type": "int"
+            "name": "Identifier",
+            "type": "bytes"
         },
         {
-            "name": "TxCount",
-            "type": "int"
+            "name": "Nonce",
+            "type": "long"
         },
         {
-            "name": "AccumulatedFees",
+            "name": "Amount",
             "type": "bytes"
         },
         {
-            "name": "DeveloperFees",
-            "type": "bytes"
+            "name": "Sender",
+            "type": {
+                "type": "fixed",
+                "size": 62,
+                "name": "address"
+            }
         },
         {
-            "name": "EpochStartBlock",
-            "type": "boolean"
+            "name": "Receiver",
+            "type": {
+                "type": "fixed",
+                "size": 62,
+                "name": "address"
+            }
         },
         {
-            "name": "EpochStartInfo",
+            "name": "Decimals",
             "default": null,
             "type": [
                 "null",
-                {
-                    "type": "record",
-                    "name": "EpochStartInfo",
-                    "fields": [
-                        {
-                            "name": "TotalSupply",
-                            "type": "bytes"
-                        },
-                        {
-                            "name": "TotalToDistribute",
-                            "type": "bytes"
-                        },
-                        {
-                            "name": "TotalNewlyMinted",
-                            "type": "bytes"
-                        },
-                        {
-                            "name": "RewardsPerBlock",
-                            "type": "bytes"
-                        },
-                        {
-                            "name": "RewardsForProtocolSustainability",
-                            "type": "bytes"
-                        },
-                        {
-                            "name": "NodePrice",
-                            "type": "bytes"
-                        },
-                        {
-                            "name": "PrevEpochStartRound",
-                            "type": "int"
-                        },
-                        {
-                            "name": "PrevEpochStartHash",
-                            "default": null,
-                            "type": [
-                                "null",
-                                {
-                                    "type": "fixed",
-                                    "size": 32,
-                                    "name": "hash"
-                                }
-                            ]
-                        }
-                    ]
-                }
+                "int"
             ]
         }
     ]
 }`)
 
 // Generated by codegen. Please do not modify.
-var _MiniBlock_schema, _MiniBlock_schema_err = avro.ParseSchema(`{
+var _PeerChange_schema, _PeerChange_schema_err = avro.ParseSchema(`{
     "type": "record",
-    "name": "MiniBlock",
+    "name": "PeerChange",
     "fields": [
         {
-            "name": "Hash",
+            "name": "BlockHash",
             "type": {
                 "type": "fixed",
                 "size": 32,
@@ -1092,66 +4786,95 @@ var _MiniBlock_schema, _MiniBlock_schema_err = avro.ParseSchema(`{
             }
         },
         {
-            "name": "SenderShardID",
+            "name": "MiniBlockHash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
+        },
+        {
+            "name": "MiniBlockIndex",
             "type": "int"
         },
         {
-            "name": "ReceiverShardID",
+            "name": "IndexInMiniBlock",
             "type": "int"
         },
         {
-            "name": "Type",
+            "name": "Epoch",
             "type": "int"
         },
         {
-            "name": "Timestamp",
-            "type": "long"
+            "name": "PublicKey",
+            "type": "bytes"
         },
         {
-            "name": "TxHashes",
-            "type": {
-                "type": "array",
-                "items": "bytes"
-            }
+            "name": "ShardID",
+            "type": "int"
+        },
+        {
+            "name": "List",
+            "type": "string"
+        },
+        {
+            "name": "Index",
+            "type": "int"
+        },
+        {
+            "name": "TempRating",
+            "type": "int"
         }
     ]
 }`)
 
 // Generated by codegen. Please do not modify.
-var _EpochStartInfo_schema, _EpochStartInfo_schema_err = avro.ParseSchema(`{
+var _ContractEvent_schema, _ContractEvent_schema_err = avro.ParseSchema(`{
     "type": "record",
-    "name": "EpochStartInfo",
+    "name": "ContractEvent",
     "fields": [
         {
-            "name": "TotalSupply",
-            "type": "bytes"
-        },
-        {
-            "name": "TotalToDistribute",
-            "type": "bytes"
-        },
-        {
-            "name": "TotalNewlyMinted",
-            "type": "bytes"
+            "name": "TxHash",
+            "type": {
+                "type": "fixed",
+                "size": 32,
+                "name": "hash"
+            }
         },
         {
-            "name": "RewardsPerBlock",
-            "type": "bytes"
+            "name": "Type",
+            "type": "string"
         },
         {
-            "name": "RewardsForProtocolSustainability",
-            "type": "bytes"
+            "name": "ContractAddress",
+            "type": {
+                "type": "fixed",
+                "size": 62,
+                "name": "address"
+            }
         },
         {
-            "name": "NodePrice",
-            "type": "bytes"
+            "name": "Owner",
+            "type": {
+                "type": "fixed",
+                "size": 62,
+                "name": "address"
+            }
         },
         {
-            "name": "PrevEpochStartRound",
-            "type": "int"
+            "name": "PreviousOwner",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 62,
+                    "name": "address"
+                }
+            ]
         },
         {
-            "name": "PrevEpochStartHash",
+            "name": "CodeHash",
             "default": null,
             "type": [
                 "null",
@@ -1161,17 +4884,70 @@ var _EpochStartInfo_schema, _EpochStartInfo_schema_err = avro.ParseSchema(`{
                     "name": "hash"
                 }
             ]
+        },
+        {
+            "name": "CodeMetadata",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "record",
+                    "name": "CodeMetadata",
+                    "fields": [
+                        {
+                            "name": "Upgradeable",
+                            "type": "boolean"
+                        },
+                        {
+                            "name": "Readable",
+                            "type": "boolean"
+                        },
+                        {
+                            "name": "Payable",
+                            "type": "boolean"
+                        },
+                        {
+                            "name": "PayableBySC",
+                            "type": "boolean"
+                        }
+                    ]
+                }
+            ]
         }
     ]
 }`)
 
 // Generated by codegen. Please do not modify.
-var _Transaction_schema, _Transaction_schema_err = avro.ParseSchema(`{
+var _CodeMetadata_schema, _CodeMetadata_schema_err = avro.ParseSchema(`{
     "type": "record",
-    "name": "Transaction",
+    "name": "CodeMetadata",
     "fields": [
         {
-            "name": "Hash",
+            "name": "Upgradeable",
+            "type": "boolean"
+        },
+        {
+            "name": "Readable",
+            "type": "boolean"
+        },
+        {
+            "name": "Payable",
+            "type": "boolean"
+        },
+        {
+            "name": "PayableBySC",
+            "type": "boolean"
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _CallTree_schema, _CallTree_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "CallTree",
+    "fields": [
+        {
+            "name": "OriginalTxHash",
             "type": {
                 "type": "fixed",
                 "size": 32,
@@ -1179,7 +4955,67 @@ var _Transaction_schema, _Transaction_schema_err = avro.ParseSchema(`{
             }
         },
         {
-            "name": "MiniBlockHash",
+            "name": "IsPartial",
+            "type": "boolean"
+        },
+        {
+            "name": "Nodes",
+            "type": {
+                "type": "array",
+                "items": {
+                    "type": "record",
+                    "name": "CallTreeNode",
+                    "fields": [
+                        {
+                            "name": "Hash",
+                            "type": {
+                                "type": "fixed",
+                                "size": 32,
+                                "name": "hash"
+                            }
+                        },
+                        {
+                            "name": "ParentHash",
+                            "type": {
+                                "type": "fixed",
+                                "size": 32,
+                                "name": "hash"
+                            }
+                        },
+                        {
+                            "name": "Depth",
+                            "type": "int"
+                        },
+                        {
+                            "name": "CallType",
+                            "type": "int"
+                        },
+                        {
+                            "name": "CallbackHash",
+                            "default": null,
+                            "type": [
+                                "null",
+                                {
+                                    "type": "fixed",
+                                    "size": 32,
+                                    "name": "hash"
+                                }
+                            ]
+                        }
+                    ]
+                }
+            }
+        }
+    ]
+}`)
+
+// Generated by codegen. Please do not modify.
+var _CallTreeNode_schema, _CallTreeNode_schema_err = avro.ParseSchema(`{
+    "type": "record",
+    "name": "CallTreeNode",
+    "fields": [
+        {
+            "name": "Hash",
             "type": {
                 "type": "fixed",
                 "size": 32,
@@ -1187,7 +5023,7 @@ var _Transaction_schema, _Transaction_schema_err = avro.ParseSchema(`{
             }
         },
         {
-            "name": "BlockHash",
+            "name": "ParentHash",
             "type": {
                 "type": "fixed",
                 "size": 32,
@@ -1195,87 +5031,35 @@ var _Transaction_schema, _Transaction_schema_err = avro.ParseSchema(`{
             }
         },
         {
-            "name": "Nonce",
-            "type": "long"
-        },
-        {
-            "name": "Round",
-            "type": "long"
-        },
-        {
-            "name": "Value",
-            "type": "bytes"
-        },
-        {
-            "name": "Receiver",
-            "type": {
-                "type": "fixed",
-                "size": 62,
-                "name": "address"
-            }
-        },
-        {
-            "name": "Sender",
-            "type": {
-                "type": "fixed",
-                "size": 62,
-                "name": "address"
-            }
-        },
-        {
-            "name": "ReceiverShard",
+            "name": "Depth",
             "type": "int"
         },
         {
-            "name": "SenderShard",
+            "name": "CallType",
             "type": "int"
         },
         {
-            "name": "GasPrice",
-            "type": "long"
-        },
-        {
-            "name": "GasLimit",
-            "type": "long"
-        },
-        {
-            "name": "Data",
-            "type": "bytes"
-        },
-        {
-            "name": "Signature",
+            "name": "CallbackHash",
             "default": null,
             "type": [
                 "null",
                 {
                     "type": "fixed",
-                    "size": 64,
-                    "name": "signature"
+                    "size": 32,
+                    "name": "hash"
                 }
             ]
-        },
-        {
-            "name": "Timestamp",
-            "type": "long"
-        },
-        {
-            "name": "SenderUserName",
-            "type": "bytes"
-        },
-        {
-            "name": "ReceiverUserName",
-            "type": "bytes"
         }
     ]
 }`)
 
 // Generated by codegen. Please do not modify.
-var _SCResult_schema, _SCResult_schema_err = avro.ParseSchema(`{
+var _StakingEvent_schema, _StakingEvent_schema_err = avro.ParseSchema(`{
     "type": "record",
-    "name": "SCResult",
+    "name": "StakingEvent",
     "fields": [
         {
-            "name": "Hash",
+            "name": "TxHash",
             "type": {
                 "type": "fixed",
                 "size": 32,
@@ -1283,23 +5067,11 @@ var _SCResult_schema, _SCResult_schema_err = avro.ParseSchema(`{
             }
         },
         {
-            "name": "Nonce",
-            "type": "long"
-        },
-        {
-            "name": "GasLimit",
-            "type": "long"
-        },
-        {
-            "name": "GasPrice",
-            "type": "long"
-        },
-        {
-            "name": "Value",
-            "type": "bytes"
+            "name": "Type",
+            "type": "string"
         },
         {
-            "name": "Sender",
+            "name": "Caller",
             "type": {
                 "type": "fixed",
                 "size": 62,
@@ -1307,7 +5079,7 @@ var _SCResult_schema, _SCResult_schema_err = avro.ParseSchema(`{
             }
         },
         {
-            "name": "Receiver",
+            "name": "Contract",
             "type": {
                 "type": "fixed",
                 "size": 62,
@@ -1315,7 +5087,7 @@ var _SCResult_schema, _SCResult_schema_err = avro.ParseSchema(`{
             }
         },
         {
-            "name": "RelayerAddr",
+            "name": "DelegationContract",
             "default": null,
             "type": [
                 "null",
@@ -1327,59 +5099,23 @@ var _SCResult_schema, _SCResult_schema_err = avro.ParseSchema(`{
             ]
         },
         {
-            "name": "RelayedValue",
-            "type": "bytes"
-        },
-        {
-            "name": "Code",
-            "type": "bytes"
-        },
-        {
-            "name": "Data",
-            "type": "bytes"
-        },
-        {
-            "name": "PrevTxHash",
-            "type": {
-                "type": "fixed",
-                "size": 32,
-                "name": "hash"
-            }
-        },
-        {
-            "name": "OriginalTxHash",
-            "type": {
-                "type": "fixed",
-                "size": 32,
-                "name": "hash"
-            }
-        },
-        {
-            "name": "CallType",
-            "type": "int"
-        },
-        {
-            "name": "CodeMetadata",
-            "type": "bytes"
-        },
-        {
-            "name": "ReturnMessage",
-            "type": "bytes"
-        },
-        {
-            "name": "Timestamp",
-            "type": "long"
+            "name": "Amount",
+            "default": null,
+            "type": [
+                "null",
+                "bytes"
+            ]
         }
     ]
 }`)
 
 // Generated by codegen. Please do not modify.
-var _Receipt_schema, _Receipt_schema_err = avro.ParseSchema(`{
+var _TokenRegistryEvent_schema, _TokenRegistryEvent_schema_err = avro.ParseSchema(`{
     "type": "record",
-    "name": "Receipt",
+    "name": "TokenRegistryEvent",
     "fields": [
         {
-            "name": "Hash",
+            "name": "TxHash",
             "type": {
                 "type": "fixed",
                 "size": 32,
@@ -1387,11 +5123,11 @@ var _Receipt_schema, _Receipt_schema_err = avro.ParseSchema(`{
             }
         },
         {
-            "name": "Value",
-            "type": "bytes"
+            "name": "Type",
+            "type": "string"
         },
         {
-            "name": "Sender",
+            "name": "Caller",
             "type": {
                 "type": "fixed",
                 "size": 62,
@@ -1399,98 +5135,44 @@ var _Receipt_schema, _Receipt_schema_err = avro.ParseSchema(`{
             }
         },
         {
-            "name": "Data",
+            "name": "Identifier",
             "type": "bytes"
         },
         {
-            "name": "TxHash",
-            "type": {
-                "type": "fixed",
-                "size": 32,
-                "name": "hash"
-            }
-        },
-        {
-            "name": "Timestamp",
-            "type": "long"
-        }
-    ]
-}`)
-
-// Generated by codegen. Please do not modify.
-var _Log_schema, _Log_schema_err = avro.ParseSchema(`{
-    "type": "record",
-    "name": "Log",
-    "fields": [
-        {
-            "name": "ID",
-            "type": {
-                "type": "fixed",
-                "size": 32,
-                "name": "hash"
-            }
-        },
-        {
-            "name": "Address",
+            "name": "Issue",
             "default": null,
             "type": [
                 "null",
                 {
-                    "type": "fixed",
-                    "size": 62,
-                    "name": "address"
-                }
-            ]
-        },
-        {
-            "name": "Events",
-            "type": {
-                "type": "array",
-                "items": {
                     "type": "record",
-                    "name": "Event",
+                    "name": "TokenIssue",
                     "fields": [
                         {
-                            "name": "Address",
-                            "default": null,
-                            "type": [
-                                "null",
-                                {
-                                    "type": "fixed",
-                                    "size": 62,
-                                    "name": "address"
-                                }
-                            ]
+                            "name": "Name",
+                            "type": "bytes"
                         },
                         {
-                            "name": "Identifier",
+                            "name": "Ticker",
                             "type": "bytes"
                         },
                         {
-                            "name": "Topics",
-                            "type": {
-                                "type": "array",
-                                "items": "bytes"
-                            }
+                            "name": "TokenType",
+                            "type": "string"
                         },
                         {
-                            "name": "Data",
+                            "name": "Decimals",
+                            "type": "int"
+                        },
+                        {
+                            "name": "InitialSupply",
                             "type": "bytes"
                         }
                     ]
                 }
-            }
-        }
-    ]
-}`)
-
-// Generated by codegen. Please do not modify.
-var _Event_schema, _Event_schema_err = avro.ParseSchema(`{
-    "type": "record",
-    "name": "Event",
-    "fields": [
+            ]
+        },
         {
-            "name": "Address",
+            "name": "Owner",
             "default": null,
             "type": [
                 "null",
@@ -1502,43 +5184,51 @@ var _Event_schema, _Event_schema_err = avro.ParseSchema(`{
             ]
         },
         {
-            "name": "Identifier",
-            "type": "bytes"
+            "name": "Address",
+            "default": null,
+            "type": [
+                "null",
+                {
+                    "type": "fixed",
+                    "size": 62,
+                    "name": "address"
+                }
+            ]
         },
         {
-            "name": "Topics",
+            "name": "Roles",
             "type": {
                 "type": "array",
-                "items": "bytes"
+                "items": "string"
             }
-        },
-        {
-            "name": "Data",
-            "type": "bytes"
         }
     ]
 }`)
 
 // Generated by codegen. Please do not modify.
-var _AccountBalanceUpdate_schema, _AccountBalanceUpdate_schema_err = avro.ParseSchema(`{
+var _TokenIssue_schema, _TokenIssue_schema_err = avro.ParseSchema(`{
     "type": "record",
-    "name": "AccountBalanceUpdate",
+    "name": "TokenIssue",
     "fields": [
         {
-            "name": "Address",
-            "type": {
-                "type": "fixed",
-                "size": 62,
-                "name": "address"
-            }
+            "name": "Name",
+            "type": "bytes"
         },
         {
-            "name": "Balance",
+            "name": "Ticker",
             "type": "bytes"
         },
         {
-            "name": "Nonce",
-            "type": "long"
+            "name": "TokenType",
+            "type": "string"
+        },
+        {
+            "name": "Decimals",
+            "type": "int"
+        },
+        {
+            "name": "InitialSupply",
+            "type": "bytes"
         }
     ]
 }`)
@@ -2,11 +2,15 @@ package mock
 
 import (
 	"github.com/ElrondNetwork/covalent-indexer-go/schema"
+	"github.com/ElrondNetwork/elrond-go-core/data"
 	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
 )
 
 type DataHandlerStub struct {
-	ProcessDataCalled func(args *indexer.ArgsSaveBlockData) (*schema.BlockResult, error)
+	ProcessDataCalled             func(args *indexer.ArgsSaveBlockData) (*schema.BlockResult, error)
+	ProcessValidatorsRatingCalled func(indexID string, ratings []*indexer.ValidatorRatingInfo) error
+	ProcessAccountsSnapshotCalled func(blockTimestamp uint64, accounts []data.UserAccountHandler)
+	RevertBlockCalled             func(header data.HeaderHandler)
 }
 
 func (dhs *DataHandlerStub) ProcessData(args *indexer.ArgsSaveBlockData) (*schema.BlockResult, error) {
@@ -15,3 +19,23 @@ func (dhs *DataHandlerStub) ProcessData(args *indexer.ArgsSaveBlockData) (*schem
 	}
 	return nil, nil
 }
+
+func (dhs *DataHandlerStub) ProcessValidatorsRating(indexID string, ratings []*indexer.ValidatorRatingInfo) error {
+	if dhs.ProcessValidatorsRatingCalled != nil {
+		return dhs.ProcessValidatorsRatingCalled(indexID, ratings)
+	}
+
+	return nil
+}
+
+func (dhs *DataHandlerStub) ProcessAccountsSnapshot(blockTimestamp uint64, accounts []data.UserAccountHandler) {
+	if dhs.ProcessAccountsSnapshotCalled != nil {
+		dhs.ProcessAccountsSnapshotCalled(blockTimestamp, accounts)
+	}
+}
+
+func (dhs *DataHandlerStub) RevertBlock(header data.HeaderHandler) {
+	if dhs.RevertBlockCalled != nil {
+		dhs.RevertBlockCalled(header)
+	}
+}
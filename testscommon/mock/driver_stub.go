@@ -0,0 +1,83 @@
+package mock
+
+import (
+	"github.com/ElrondNetwork/elrond-go-core/data"
+	"github.com/ElrondNetwork/elrond-go-core/data/indexer"
+)
+
+// DriverStub that will be used for testing
+type DriverStub struct {
+	SaveBlockCalled             func(args *indexer.ArgsSaveBlockData) error
+	RevertIndexedBlockCalled    func(header data.HeaderHandler, body data.BodyHandler) error
+	SaveRoundsInfoCalled        func(roundsInfos []*indexer.RoundInfo) error
+	SaveValidatorsPubKeysCalled func(validatorsPubKeys map[uint32][][]byte, epoch uint32) error
+	SaveValidatorsRatingCalled  func(indexID string, infoRating []*indexer.ValidatorRatingInfo) error
+	SaveAccountsCalled          func(blockTimestamp uint64, acc []data.UserAccountHandler) error
+	FinalizedBlockCalled        func(headerHash []byte) error
+}
+
+// SaveBlock calls a custom save block function if defined, otherwise returns nil
+func (ds *DriverStub) SaveBlock(args *indexer.ArgsSaveBlockData) error {
+	if ds.SaveBlockCalled != nil {
+		return ds.SaveBlockCalled(args)
+	}
+	return nil
+}
+
+// RevertIndexedBlock calls a custom revert function if defined, otherwise returns nil
+func (ds *DriverStub) RevertIndexedBlock(header data.HeaderHandler, body data.BodyHandler) error {
+	if ds.RevertIndexedBlockCalled != nil {
+		return ds.RevertIndexedBlockCalled(header, body)
+	}
+	return nil
+}
+
+// SaveRoundsInfo calls a custom save rounds info function if defined, otherwise returns nil
+func (ds *DriverStub) SaveRoundsInfo(roundsInfos []*indexer.RoundInfo) error {
+	if ds.SaveRoundsInfoCalled != nil {
+		return ds.SaveRoundsInfoCalled(roundsInfos)
+	}
+	return nil
+}
+
+// SaveValidatorsPubKeys calls a custom save validators pub keys function if defined, otherwise returns nil
+func (ds *DriverStub) SaveValidatorsPubKeys(validatorsPubKeys map[uint32][][]byte, epoch uint32) error {
+	if ds.SaveValidatorsPubKeysCalled != nil {
+		return ds.SaveValidatorsPubKeysCalled(validatorsPubKeys, epoch)
+	}
+	return nil
+}
+
+// SaveValidatorsRating calls a custom save validators rating function if defined, otherwise returns nil
+func (ds *DriverStub) SaveValidatorsRating(indexID string, infoRating []*indexer.ValidatorRatingInfo) error {
+	if ds.SaveValidatorsRatingCalled != nil {
+		return ds.SaveValidatorsRatingCalled(indexID, infoRating)
+	}
+	return nil
+}
+
+// SaveAccounts calls a custom save accounts function if defined, otherwise returns nil
+func (ds *DriverStub) SaveAccounts(blockTimestamp uint64, acc []data.UserAccountHandler) error {
+	if ds.SaveAccountsCalled != nil {
+		return ds.SaveAccountsCalled(blockTimestamp, acc)
+	}
+	return nil
+}
+
+// FinalizedBlock calls a custom finalized block function if defined, otherwise returns nil
+func (ds *DriverStub) FinalizedBlock(headerHash []byte) error {
+	if ds.FinalizedBlockCalled != nil {
+		return ds.FinalizedBlockCalled(headerHash)
+	}
+	return nil
+}
+
+// Close returns nil
+func (ds *DriverStub) Close() error {
+	return nil
+}
+
+// IsInterfaceNil returns true if interface is nil, false otherwise
+func (ds *DriverStub) IsInterfaceNil() bool {
+	return ds == nil
+}
@@ -0,0 +1,76 @@
+package mock
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go-core/data"
+)
+
+// FeeCalculatorStub that will be used for testing
+type FeeCalculatorStub struct {
+	ComputeGasLimitCalled                        func(tx data.TransactionWithFeeHandler) uint64
+	ComputeMoveBalanceFeeCalled                  func(tx data.TransactionWithFeeHandler) *big.Int
+	ComputeFeeForProcessingCalled                func(tx data.TransactionWithFeeHandler, gasToUse uint64) *big.Int
+	ComputeTxFeeCalled                           func(tx data.TransactionWithFeeHandler) *big.Int
+	ComputeGasUsedAndFeeBasedOnRefundValueCalled func(tx data.TransactionWithFeeHandler, refundValue *big.Int) (uint64, *big.Int)
+	IsEnabledCalled                              func() bool
+}
+
+// ComputeGasLimit calls a custom compute gas limit function if defined, otherwise returns 0
+func (fcs *FeeCalculatorStub) ComputeGasLimit(tx data.TransactionWithFeeHandler) uint64 {
+	if fcs.ComputeGasLimitCalled != nil {
+		return fcs.ComputeGasLimitCalled(tx)
+	}
+
+	return 0
+}
+
+// ComputeMoveBalanceFee calls a custom compute move balance fee function if defined, otherwise returns 0
+func (fcs *FeeCalculatorStub) ComputeMoveBalanceFee(tx data.TransactionWithFeeHandler) *big.Int {
+	if fcs.ComputeMoveBalanceFeeCalled != nil {
+		return fcs.ComputeMoveBalanceFeeCalled(tx)
+	}
+
+	return big.NewInt(0)
+}
+
+// ComputeFeeForProcessing calls a custom compute fee for processing function if defined, otherwise returns 0
+func (fcs *FeeCalculatorStub) ComputeFeeForProcessing(tx data.TransactionWithFeeHandler, gasToUse uint64) *big.Int {
+	if fcs.ComputeFeeForProcessingCalled != nil {
+		return fcs.ComputeFeeForProcessingCalled(tx, gasToUse)
+	}
+
+	return big.NewInt(0)
+}
+
+// ComputeTxFee calls a custom compute tx fee function if defined, otherwise returns 0
+func (fcs *FeeCalculatorStub) ComputeTxFee(tx data.TransactionWithFeeHandler) *big.Int {
+	if fcs.ComputeTxFeeCalled != nil {
+		return fcs.ComputeTxFeeCalled(tx)
+	}
+
+	return big.NewInt(0)
+}
+
+// ComputeGasUsedAndFeeBasedOnRefundValue calls a custom compute gas used and fee function if defined, otherwise returns 0, 0
+func (fcs *FeeCalculatorStub) ComputeGasUsedAndFeeBasedOnRefundValue(tx data.TransactionWithFeeHandler, refundValue *big.Int) (uint64, *big.Int) {
+	if fcs.ComputeGasUsedAndFeeBasedOnRefundValueCalled != nil {
+		return fcs.ComputeGasUsedAndFeeBasedOnRefundValueCalled(tx, refundValue)
+	}
+
+	return 0, big.NewInt(0)
+}
+
+// IsEnabled calls a custom is enabled function if defined, otherwise returns true
+func (fcs *FeeCalculatorStub) IsEnabled() bool {
+	if fcs.IsEnabledCalled != nil {
+		return fcs.IsEnabledCalled()
+	}
+
+	return true
+}
+
+// IsInterfaceNil returns true if interface is nil, false otherwise
+func (fcs *FeeCalculatorStub) IsInterfaceNil() bool {
+	return fcs == nil
+}
@@ -0,0 +1,53 @@
+package mock
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go-core/data/block"
+)
+
+// HeaderV2Mock mimics a version 2 shard header, which wraps a block.Header and holds scheduled execution data
+type HeaderV2Mock struct {
+	*block.Header
+	ScheduledRootHash        []byte
+	ScheduledAccumulatedFees *big.Int
+	ScheduledDeveloperFees   *big.Int
+	ScheduledGasProvided     uint64
+	ScheduledGasPenalized    uint64
+	ScheduledGasRefunded     uint64
+}
+
+// GetScheduledRootHash -
+func (hv2 *HeaderV2Mock) GetScheduledRootHash() []byte {
+	return hv2.ScheduledRootHash
+}
+
+// GetScheduledAccumulatedFees -
+func (hv2 *HeaderV2Mock) GetScheduledAccumulatedFees() *big.Int {
+	return hv2.ScheduledAccumulatedFees
+}
+
+// GetScheduledDeveloperFees -
+func (hv2 *HeaderV2Mock) GetScheduledDeveloperFees() *big.Int {
+	return hv2.ScheduledDeveloperFees
+}
+
+// GetScheduledGasProvided -
+func (hv2 *HeaderV2Mock) GetScheduledGasProvided() uint64 {
+	return hv2.ScheduledGasProvided
+}
+
+// GetScheduledGasPenalized -
+func (hv2 *HeaderV2Mock) GetScheduledGasPenalized() uint64 {
+	return hv2.ScheduledGasPenalized
+}
+
+// GetScheduledGasRefunded -
+func (hv2 *HeaderV2Mock) GetScheduledGasRefunded() uint64 {
+	return hv2.ScheduledGasRefunded
+}
+
+// IsInterfaceNil returns true if interface is nil, false otherwise
+func (hv2 *HeaderV2Mock) IsInterfaceNil() bool {
+	return hv2 == nil
+}
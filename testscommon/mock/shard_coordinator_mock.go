@@ -2,11 +2,16 @@ package mock
 
 // ShardCoordinatorMock -
 type ShardCoordinatorMock struct {
-	SelfID uint32
+	SelfID          uint32
+	ComputeIdCalled func(address []byte) uint32
 }
 
-// ComputeId returns 0
-func (scm *ShardCoordinatorMock) ComputeId([]byte) uint32 {
+// ComputeId returns 0 if ComputeIdCalled is not set
+func (scm *ShardCoordinatorMock) ComputeId(address []byte) uint32 {
+	if scm.ComputeIdCalled != nil {
+		return scm.ComputeIdCalled(address)
+	}
+
 	return 0
 }
 
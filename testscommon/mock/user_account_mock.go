@@ -9,6 +9,15 @@ import (
 type UserAccountMock struct {
 	CurrentBalance int64
 	CurrentNonce   uint64
+	Address        []byte
+	UserName       []byte
+	OwnerAddress   []byte
+	CodeHash       []byte
+	RootHash       []byte
+	CodeMetadata   []byte
+	DevReward      *big.Int
+
+	RetrieveValueFromDataTrieTrackerCalled func(key []byte) ([]byte, error)
 }
 
 // IncreaseNonce -
@@ -21,8 +30,11 @@ func (uas *UserAccountMock) GetBalance() *big.Int {
 	return big.NewInt(uas.CurrentBalance)
 }
 
-// AddressBytes returns a byte slice of ("addr" + CurrentBalance)
+// AddressBytes returns Address member if set, otherwise a byte slice of ("addr" + CurrentBalance)
 func (uas *UserAccountMock) AddressBytes() []byte {
+	if uas.Address != nil {
+		return uas.Address
+	}
 	return []byte("addr" + strconv.Itoa(int(uas.CurrentBalance)))
 }
 
@@ -32,12 +44,46 @@ func (uas *UserAccountMock) GetNonce() uint64 {
 	return uas.CurrentNonce
 }
 
+// GetUserName returns UserName member
+func (uas *UserAccountMock) GetUserName() []byte {
+	return uas.UserName
+}
+
+// GetOwnerAddress returns OwnerAddress member
+func (uas *UserAccountMock) GetOwnerAddress() []byte {
+	return uas.OwnerAddress
+}
+
+// GetCodeHash returns CodeHash member
+func (uas *UserAccountMock) GetCodeHash() []byte {
+	return uas.CodeHash
+}
+
+// GetRootHash returns RootHash member
+func (uas *UserAccountMock) GetRootHash() []byte {
+	return uas.RootHash
+}
+
+// GetCodeMetadata returns CodeMetadata member
+func (uas *UserAccountMock) GetCodeMetadata() []byte {
+	return uas.CodeMetadata
+}
+
+// GetDeveloperReward returns DevReward member
+func (uas *UserAccountMock) GetDeveloperReward() *big.Int {
+	return uas.DevReward
+}
+
 // IsInterfaceNil returns true if interface is nil, false otherwise
 func (uas *UserAccountMock) IsInterfaceNil() bool {
 	return uas == nil
 }
 
-// RetrieveValueFromDataTrieTracker -
-func (uas *UserAccountMock) RetrieveValueFromDataTrieTracker([]byte) ([]byte, error) {
+// RetrieveValueFromDataTrieTracker calls a custom retrieve function if defined, otherwise returns nil, nil
+func (uas *UserAccountMock) RetrieveValueFromDataTrieTracker(key []byte) ([]byte, error) {
+	if uas.RetrieveValueFromDataTrieTrackerCalled != nil {
+		return uas.RetrieveValueFromDataTrieTrackerCalled(key)
+	}
+
 	return nil, nil
 }